@@ -5,14 +5,36 @@ import (
 	"context"
 	"log"
 	"os"
+	_ "time/tzdata" // часовые пояса задач не должны зависеть от tzdata в образе
 
+	"todo/internal/access"
+	apikeyrepo "todo/internal/apikey"
+	attachmentrepo "todo/internal/attachment"
+	"todo/internal/auth"
+	"todo/internal/blob"
+	commentrepo "todo/internal/comment"
 	"todo/internal/config"
 	gen "todo/internal/gen/todo/v1"
+	apikeygrpc "todo/internal/handler/grpc/apikey"
+	attachmentgrpc "todo/internal/handler/grpc/attachment"
+	commentgrpc "todo/internal/handler/grpc/comment"
+	projectgrpc "todo/internal/handler/grpc/project"
 	todogrpc "todo/internal/handler/grpc/todo"
+	webhookgrpc "todo/internal/handler/grpc/webhook"
+	"todo/internal/idempotency"
+	"todo/internal/jwt"
+	projectrepo "todo/internal/project"
 	"todo/internal/server"
+	apikeysvc "todo/internal/service/apikey"
+	attachmentsvc "todo/internal/service/attachment"
+	commentsvc "todo/internal/service/comment"
+	projectsvc "todo/internal/service/project"
 	todosvc "todo/internal/service/todo"
+	webhooksvc "todo/internal/service/webhook"
 	"todo/internal/storage"
 	todorepo "todo/internal/todo"
+	"todo/internal/watch"
+	webhookrepo "todo/internal/webhook"
 
 	"google.golang.org/grpc"
 )
@@ -37,12 +59,74 @@ func main() {
 		log.Fatalf("apply migrations: %v", err)
 	}
 
+	store, err := blob.NewFSStore(cfg.Attachments.Root)
+	if err != nil {
+		log.Fatalf("open attachment store: %v", err)
+	}
+	accessRepo := access.NewRepository(db)
+	attachmentService := attachmentsvc.NewService(attachmentrepo.NewRepository(db), accessRepo, store, attachmentsvc.Config{
+		MaxSize:      cfg.Attachments.MaxSize,
+		AllowedTypes: cfg.Attachments.AllowedTypes,
+	})
+
 	todoRepo := todorepo.NewRepository(db)
-	service := todosvc.NewService(todoRepo)
+	todoConfig := serviceConfig(cfg)
+	todoConfig.AfterPurge = attachmentService.RemoveOrphans
+	hub := watch.NewHub(db)
+	go hub.Run(ctx)
+	todoConfig.Notifier = hub
+	service := todosvc.NewService(todoRepo, accessRepo, todoConfig)
 	handler := todogrpc.NewHandler(service)
+	projectHandler := projectgrpc.NewHandler(projectsvc.NewService(projectrepo.NewRepository(db), accessRepo))
+	commentHandler := commentgrpc.NewHandler(commentsvc.NewService(commentrepo.NewRepository(db), accessRepo))
+	attachmentHandler := attachmentgrpc.NewHandler(attachmentService)
+
+	go service.RunTrashPurger(ctx, cfg.Trash.Retention, cfg.Trash.PurgeInterval)
+
+	apiKeyService := apikeysvc.NewService(apikeyrepo.NewRepository(db))
+	apiKeyHandler := apikeygrpc.NewHandler(apiKeyService)
+
+	webhookRepo := webhookrepo.NewRepository(db)
+	webhookAddresses := webhooksvc.AddressPolicy{AllowedNetworks: cfg.Webhooks.AllowedNetworks}
+	webhookHandler := webhookgrpc.NewHandler(webhooksvc.NewService(webhookRepo, webhookAddresses))
+	go webhooksvc.NewDispatcher(webhookRepo, webhooksvc.DispatcherConfig{
+		PollInterval: cfg.Webhooks.PollInterval,
+		Timeout:      cfg.Webhooks.Timeout,
+		MaxAttempts:  cfg.Webhooks.MaxAttempts,
+		BackoffBase:  cfg.Webhooks.BackoffBase,
+		BackoffMax:   cfg.Webhooks.BackoffMax,
+		Addresses:    webhookAddresses,
+	}).Run(ctx)
 
-	if err := server.Run(ctx, server.Config{Addr: cfg.GRPCAddr}, func(s *grpc.Server) {
+	idempotencyRepo := idempotency.NewRepository(db)
+	go idempotency.RunPurger(ctx, idempotencyRepo, cfg.Idempotency.PurgeInterval)
+
+	authn, err := authenticator(cfg.Auth)
+	if err != nil {
+		log.Fatalf("configure authentication: %v", err)
+	}
+	authn = auth.WithAPIKeys(apiKeyService, authn)
+
+	if err := server.Run(ctx, server.Config{
+		Addr: cfg.GRPCAddr,
+		UnaryInterceptors: []grpc.UnaryServerInterceptor{
+			auth.UnaryInterceptor(authn),
+			idempotency.UnaryInterceptor(idempotencyRepo, idempotency.Config{
+				TTL:   cfg.Idempotency.TTL,
+				Lease: cfg.Idempotency.Lease,
+			}),
+		},
+		StreamInterceptors: []grpc.StreamServerInterceptor{
+			auth.StreamInterceptor(authn),
+			idempotency.StreamInterceptor(),
+		},
+	}, func(s *grpc.Server) {
 		gen.RegisterTodoServiceServer(s, handler)
+		gen.RegisterProjectServiceServer(s, projectHandler)
+		gen.RegisterCommentServiceServer(s, commentHandler)
+		gen.RegisterAttachmentServiceServer(s, attachmentHandler)
+		gen.RegisterApiKeyServiceServer(s, apiKeyHandler)
+		gen.RegisterWebhookServiceServer(s, webhookHandler)
 	}); err != nil {
 		log.Fatalf("server error: %v", err)
 	}
@@ -63,6 +147,44 @@ func loadConfig() (config.Config, error) {
 	return cfg, nil
 }
 
+func serviceConfig(cfg config.Config) todosvc.Config {
+	policy := todosvc.CompleteSubtasks
+	if cfg.Subtasks.CompletionPolicy == config.CompletionRefuse {
+		policy = todosvc.RefuseOpenSubtasks
+	}
+	return todosvc.Config{
+		MaxDepth:          cfg.Subtasks.MaxDepth,
+		CompletionPolicy:  policy,
+		MaxBatchSize:      cfg.Batch.MaxSize,
+		WatchPollInterval: cfg.Watch.PollInterval,
+	}
+}
+
+func authenticator(cfg config.AuthConfig) (auth.Authenticator, error) {
+	if cfg.Disabled {
+		log.Printf("authentication is disabled: all calls run as tenant %q", auth.DefaultTenant)
+		return auth.Static(auth.DefaultPrincipal), nil
+	}
+	jwtCfg := jwt.Config{
+		HMACSecret: []byte(cfg.HMACSecret),
+		Issuer:     cfg.Issuer,
+		Audience:   cfg.Audience,
+		Leeway:     cfg.Leeway,
+	}
+	if cfg.JWKSFile != "" {
+		keys, err := jwt.LoadJWKS(cfg.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		jwtCfg.Keys = keys
+	}
+	verifier, err := jwt.NewVerifier(jwtCfg)
+	if err != nil {
+		return nil, err
+	}
+	return auth.NewJWTAuthenticator(verifier, cfg.TenantClaim), nil
+}
+
 func env(key, fallback string) string {
 	if v := os.Getenv(key); v != "" {
 		return v
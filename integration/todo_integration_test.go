@@ -4,25 +4,63 @@ package integration
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
 	"os"
 	"os/exec"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"todo/internal/access"
+	apikeyrepo "todo/internal/apikey"
+	attachmentrepo "todo/internal/attachment"
+	"todo/internal/auth"
+	"todo/internal/blob"
+	commentrepo "todo/internal/comment"
 	gen "todo/internal/gen/todo/v1"
+	apikeygrpc "todo/internal/handler/grpc/apikey"
+	attachmentgrpc "todo/internal/handler/grpc/attachment"
+	commentgrpc "todo/internal/handler/grpc/comment"
+	projectgrpc "todo/internal/handler/grpc/project"
 	todogrpc "todo/internal/handler/grpc/todo"
+	webhookgrpc "todo/internal/handler/grpc/webhook"
+	"todo/internal/idempotency"
+	"todo/internal/jwt"
+	projectrepo "todo/internal/project"
 	"todo/internal/server"
+	apikeysvc "todo/internal/service/apikey"
+	attachmentsvc "todo/internal/service/attachment"
+	commentsvc "todo/internal/service/comment"
+	projectsvc "todo/internal/service/project"
 	todosvc "todo/internal/service/todo"
+	webhooksvc "todo/internal/service/webhook"
 	"todo/internal/storage"
 	todorepo "todo/internal/todo"
+	"todo/internal/watch"
+	webhookrepo "todo/internal/webhook"
 
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 func TestTodoCRUD(t *testing.T) {
@@ -55,13 +93,63 @@ func TestTodoCRUD(t *testing.T) {
 	}
 
 	repo := todorepo.NewRepository(db)
-	service := todosvc.NewService(repo)
+	accessRepo := access.NewRepository(db)
+	hub := watch.NewHub(db)
+	go hub.Run(ctx)
+	service := todosvc.NewService(repo, accessRepo, todosvc.Config{MaxDepth: 5, Notifier: hub, MaxBatchSize: 3})
 	handler := todogrpc.NewHandler(service)
+	projectHandler := projectgrpc.NewHandler(projectsvc.NewService(projectrepo.NewRepository(db), accessRepo))
+	commentHandler := commentgrpc.NewHandler(commentsvc.NewService(commentrepo.NewRepository(db), accessRepo))
+	store, err := blob.NewFSStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("open blob store: %v", err)
+	}
+	attachmentHandler := attachmentgrpc.NewHandler(attachmentsvc.NewService(attachmentrepo.NewRepository(db), accessRepo, store, attachmentsvc.Config{
+		MaxSize:      1 << 20,
+		AllowedTypes: []string{"text/plain"},
+	}))
+
+	verifier, err := jwt.NewVerifier(jwt.Config{HMACSecret: []byte(jwtSecret), Audience: "todo"})
+	if err != nil {
+		t.Fatalf("create jwt verifier: %v", err)
+	}
+	apiKeyService := apikeysvc.NewService(apikeyrepo.NewRepository(db))
+	apiKeyHandler := apikeygrpc.NewHandler(apiKeyService)
+	authn := auth.WithAPIKeys(apiKeyService, auth.NewJWTAuthenticator(verifier, "tenant_id"))
+	webhookRepo := webhookrepo.NewRepository(db)
+	// Получатели теста слушают на loopback, который по умолчанию запрещён.
+	webhookAddresses := webhooksvc.AddressPolicy{AllowedNetworks: []netip.Prefix{
+		netip.MustParsePrefix("127.0.0.0/8"), netip.MustParsePrefix("::1/128"),
+	}}
+	webhookHandler := webhookgrpc.NewHandler(webhooksvc.NewService(webhookRepo, webhookAddresses))
+	go webhooksvc.NewDispatcher(webhookRepo, webhooksvc.DispatcherConfig{
+		PollInterval: 100 * time.Millisecond,
+		Timeout:      time.Second,
+		MaxAttempts:  2,
+		BackoffBase:  100 * time.Millisecond,
+		BackoffMax:   time.Second,
+		Addresses:    webhookAddresses,
+	}).Run(ctx)
 
 	srvErr := make(chan error, 1)
 	go func() {
-		srvErr <- server.Run(ctx, server.Config{Addr: addr}, func(s *grpc.Server) {
+		srvErr <- server.Run(ctx, server.Config{
+			Addr: addr,
+			UnaryInterceptors: []grpc.UnaryServerInterceptor{
+				auth.UnaryInterceptor(authn),
+				idempotency.UnaryInterceptor(idempotency.NewRepository(db), idempotency.Config{TTL: time.Hour, Lease: time.Minute}),
+			},
+			StreamInterceptors: []grpc.StreamServerInterceptor{
+				auth.StreamInterceptor(authn),
+				idempotency.StreamInterceptor(),
+			},
+		}, func(s *grpc.Server) {
 			gen.RegisterTodoServiceServer(s, handler)
+			gen.RegisterProjectServiceServer(s, projectHandler)
+			gen.RegisterCommentServiceServer(s, commentHandler)
+			gen.RegisterAttachmentServiceServer(s, attachmentHandler)
+			gen.RegisterApiKeyServiceServer(s, apiKeyHandler)
+			gen.RegisterWebhookServiceServer(s, webhookHandler)
 		})
 	}()
 
@@ -69,7 +157,23 @@ func TestTodoCRUD(t *testing.T) {
 		t.Fatalf("server did not start: %v", err)
 	}
 
-	conn, err := grpc.DialContext(ctx, addr, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+	anonymous, err := grpc.DialContext(ctx, addr, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+	if err != nil {
+		t.Fatalf("dial server: %v", err)
+	}
+	defer anonymous.Close()
+	if _, err := healthpb.NewHealthClient(anonymous).Check(ctx, &healthpb.HealthCheckRequest{}); err != nil {
+		t.Fatalf("expected health checks to skip authentication, got %v", err)
+	}
+	if _, err := gen.NewTodoServiceClient(anonymous).ListTodos(ctx, &gen.ListTodosRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected Unauthenticated without a token, got %v", err)
+	}
+
+	conn, err := grpc.DialContext(ctx, addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithPerRPCCredentials(bearerToken(t, "alice", "acme")),
+		grpc.WithBlock(),
+	)
 	if err != nil {
 		t.Fatalf("dial server: %v", err)
 	}
@@ -114,6 +218,47 @@ func TestTodoCRUD(t *testing.T) {
 		t.Fatalf("expected todo to be completed")
 	}
 
+	patchCtx, cancelPatch := context.WithTimeout(ctx, 5*time.Second)
+	defer cancelPatch()
+	patched, err := client.UpdateTodo(patchCtx, &gen.UpdateTodoRequest{
+		Id:         todoID,
+		Completed:  false,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"completed"}},
+	})
+	if err != nil {
+		t.Fatalf("patch todo: %v", err)
+	}
+	if patched.GetCompleted() || patched.GetTitle() != "Write integration tests" {
+		t.Fatalf("expected only completed to change, got %+v", patched)
+	}
+
+	_, err = client.UpdateTodo(patchCtx, &gen.UpdateTodoRequest{
+		Id:              todoID,
+		Completed:       true,
+		UpdateMask:      &fieldmaskpb.FieldMask{Paths: []string{"completed"}},
+		ExpectedVersion: created.GetTodo().GetVersion(),
+	})
+	if status.Code(err) != codes.Aborted {
+		t.Fatalf("expected Aborted for stale version, got %v", err)
+	}
+
+	dueCtx, cancelDue := context.WithTimeout(ctx, 5*time.Second)
+	defer cancelDue()
+	yesterday := time.Now().Add(-24 * time.Hour).Unix()
+	overdueTodo, err := client.CreateTodo(dueCtx, &gen.CreateTodoRequest{Title: "Overdue integration todo", DueAt: &yesterday})
+	if err != nil {
+		t.Fatalf("create overdue todo: %v", err)
+	}
+	overdue, err := client.ListTodos(dueCtx, &gen.ListTodosRequest{
+		Filter: &gen.TodoFilter{Overdue: true, TitlePrefix: "Overdue integration"},
+	})
+	if err != nil {
+		t.Fatalf("list overdue todos: %v", err)
+	}
+	if len(overdue.GetTodos()) != 1 || overdue.GetTodos()[0].GetId() != overdueTodo.GetTodo().GetId() {
+		t.Fatalf("expected the overdue todo to be listed")
+	}
+
 	listCtx, cancelList := context.WithTimeout(ctx, 5*time.Second)
 	defer cancelList()
 	listResp, err := client.ListTodos(listCtx, &gen.ListTodosRequest{})
@@ -124,11 +269,1111 @@ func TestTodoCRUD(t *testing.T) {
 		t.Fatalf("expected todos in list")
 	}
 
+	secondCtx, cancelSecond := context.WithTimeout(ctx, 5*time.Second)
+	defer cancelSecond()
+	second, err := client.CreateTodo(secondCtx, &gen.CreateTodoRequest{
+		Title: "Write integration docs",
+		Tags:  []string{"Docs", " work "},
+	})
+	if err != nil {
+		t.Fatalf("create second todo: %v", err)
+	}
+	if got := second.GetTodo().GetTags(); len(got) != 2 || got[0] != "docs" || got[1] != "work" {
+		t.Fatalf("expected normalized tags, got %v", got)
+	}
+	tagged, err := client.ListTodos(secondCtx, &gen.ListTodosRequest{
+		Filter: &gen.TodoFilter{Tags: []string{"WORK", "docs"}, TagMatch: gen.TagMatch_TAG_MATCH_ALL},
+	})
+	if err != nil {
+		t.Fatalf("list tagged todos: %v", err)
+	}
+	if len(tagged.GetTodos()) != 1 || tagged.GetTodos()[0].GetId() != second.GetTodo().GetId() {
+		t.Fatalf("expected the tagged todo to be listed")
+	}
+
+	pageCtx, cancelPage := context.WithTimeout(ctx, 5*time.Second)
+	defer cancelPage()
+	filter := &gen.TodoFilter{TitlePrefix: "Write integration"}
+	firstPage, err := client.ListTodos(pageCtx, &gen.ListTodosRequest{PageSize: 1, Filter: filter})
+	if err != nil {
+		t.Fatalf("list first page: %v", err)
+	}
+	if len(firstPage.GetTodos()) != 1 || firstPage.GetNextPageToken() == "" {
+		t.Fatalf("expected one todo and a next page token, got %d todos", len(firstPage.GetTodos()))
+	}
+	secondPage, err := client.ListTodos(pageCtx, &gen.ListTodosRequest{
+		PageSize:  1,
+		PageToken: firstPage.GetNextPageToken(),
+		Filter:    filter,
+	})
+	if err != nil {
+		t.Fatalf("list second page: %v", err)
+	}
+	if len(secondPage.GetTodos()) != 1 || secondPage.GetTodos()[0].GetId() == firstPage.GetTodos()[0].GetId() {
+		t.Fatalf("expected a different todo on the second page")
+	}
+	if _, err := client.ListTodos(pageCtx, &gen.ListTodosRequest{
+		PageSize:  1,
+		PageToken: firstPage.GetNextPageToken(),
+		Filter:    &gen.TodoFilter{TitlePrefix: "Write"},
+	}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for a page token reused with another filter, got %v", err)
+	}
+
+	priorityPrefix := fmt.Sprintf("Priority %d ", time.Now().UnixNano())
+	soon := time.Now().Add(time.Hour).Unix()
+	var priorityIDs []string
+	for _, p := range []struct {
+		title    string
+		priority gen.Priority
+		due      *int64
+	}{
+		{"none", gen.Priority_PRIORITY_NONE, nil},
+		{"low", gen.Priority_PRIORITY_LOW, nil},
+		{"low soon", gen.Priority_PRIORITY_LOW, &soon},
+		{"urgent", gen.Priority_PRIORITY_URGENT, nil},
+	} {
+		created, err := client.CreateTodo(pageCtx, &gen.CreateTodoRequest{Title: priorityPrefix + p.title, Priority: p.priority, DueAt: p.due})
+		if err != nil {
+			t.Fatalf("create %s priority todo: %v", p.title, err)
+		}
+		if created.GetTodo().GetPriority() != p.priority {
+			t.Fatalf("expected priority %v on create, got %v", p.priority, created.GetTodo().GetPriority())
+		}
+		priorityIDs = append(priorityIDs, created.GetTodo().GetId())
+	}
+	if got, err := client.GetTodo(pageCtx, &gen.GetTodoRequest{Id: priorityIDs[3]}); err != nil || got.GetPriority() != gen.Priority_PRIORITY_URGENT {
+		t.Fatalf("expected the urgent priority to round-trip, got %+v, %v", got, err)
+	}
+	byPriority, err := client.ListTodos(pageCtx, &gen.ListTodosRequest{Filter: &gen.TodoFilter{TitlePrefix: priorityPrefix}})
+	if err != nil {
+		t.Fatalf("list todos in default order: %v", err)
+	}
+	var priorityOrder []string
+	for _, todo := range byPriority.GetTodos() {
+		priorityOrder = append(priorityOrder, strings.TrimPrefix(todo.GetTitle(), priorityPrefix))
+	}
+	if strings.Join(priorityOrder, ",") != "urgent,low soon,low,none" {
+		t.Fatalf("expected the default order by priority, then due date, got %v", priorityOrder)
+	}
+
 	deleteCtx, cancelDelete := context.WithTimeout(ctx, 5*time.Second)
 	defer cancelDelete()
 	if _, err := client.DeleteTodo(deleteCtx, &gen.DeleteTodoRequest{Id: todoID}); err != nil {
 		t.Fatalf("delete todo: %v", err)
 	}
+	if _, err := client.GetTodo(deleteCtx, &gen.GetTodoRequest{Id: todoID}); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for trashed todo, got %v", err)
+	}
+	trash, err := client.ListTrash(deleteCtx, &gen.ListTrashRequest{})
+	if err != nil {
+		t.Fatalf("list trash: %v", err)
+	}
+	if len(trash.GetTodos()) == 0 || trash.GetTodos()[0].GetId() != todoID {
+		t.Fatalf("expected trashed todo first in trash")
+	}
+	if _, err := client.RestoreTodo(deleteCtx, &gen.RestoreTodoRequest{Id: todoID}); err != nil {
+		t.Fatalf("restore todo: %v", err)
+	}
+	if _, err := client.GetTodo(deleteCtx, &gen.GetTodoRequest{Id: todoID}); err != nil {
+		t.Fatalf("get restored todo: %v", err)
+	}
+	if _, err := client.DeleteTodo(deleteCtx, &gen.DeleteTodoRequest{Id: todoID}); err != nil {
+		t.Fatalf("delete restored todo: %v", err)
+	}
+	if _, err := client.PurgeTodo(deleteCtx, &gen.PurgeTodoRequest{Id: todoID}); err != nil {
+		t.Fatalf("purge todo: %v", err)
+	}
+	if _, err := client.RestoreTodo(deleteCtx, &gen.RestoreTodoRequest{Id: todoID}); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for purged todo, got %v", err)
+	}
+	purgedHistory, err := client.ListTodoHistory(deleteCtx, &gen.ListTodoHistoryRequest{Id: todoID})
+	if err != nil {
+		t.Fatalf("list purged todo history: %v", err)
+	}
+	if events := purgedHistory.GetEvents(); len(events) < 2 ||
+		events[0].GetOperation() != gen.TodoEventOperation_TODO_EVENT_OPERATION_PURGE ||
+		events[len(events)-1].GetOperation() != gen.TodoEventOperation_TODO_EVENT_OPERATION_CREATE {
+		t.Fatalf("expected the history of a purged todo to end with a purge, got %+v", events)
+	}
+
+	projects := gen.NewProjectServiceClient(conn)
+	projectCtx, cancelProject := context.WithTimeout(ctx, 5*time.Second)
+	defer cancelProject()
+	project, err := projects.CreateProject(projectCtx, &gen.CreateProjectRequest{Name: "Integration"})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	inProject, err := client.CreateTodo(projectCtx, &gen.CreateTodoRequest{Title: "Project todo", ProjectId: project.GetId()})
+	if err != nil {
+		t.Fatalf("create project todo: %v", err)
+	}
+	projectID := project.GetId()
+	projectList, err := client.ListTodos(projectCtx, &gen.ListTodosRequest{Filter: &gen.TodoFilter{ProjectId: &projectID}})
+	if err != nil {
+		t.Fatalf("list project todos: %v", err)
+	}
+	if len(projectList.GetTodos()) != 1 || projectList.GetTodos()[0].GetId() != inProject.GetTodo().GetId() {
+		t.Fatalf("expected only the project todo to be listed")
+	}
+	if _, err := projects.DeleteProject(projectCtx, &gen.DeleteProjectRequest{
+		Id:   projectID,
+		Mode: gen.DeleteProjectMode_DELETE_PROJECT_MODE_CASCADE,
+	}); err != nil {
+		t.Fatalf("delete project: %v", err)
+	}
+	if _, err := client.GetTodo(projectCtx, &gen.GetTodoRequest{Id: inProject.GetTodo().GetId()}); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected cascaded todo to be trashed, got %v", err)
+	}
+
+	treeCtx, cancelTree := context.WithTimeout(ctx, 5*time.Second)
+	defer cancelTree()
+	parent, err := client.CreateTodo(treeCtx, &gen.CreateTodoRequest{Title: "Parent"})
+	if err != nil {
+		t.Fatalf("create parent: %v", err)
+	}
+	parentID := parent.GetTodo().GetId()
+	child, err := client.CreateTodo(treeCtx, &gen.CreateTodoRequest{Title: "Child", ParentId: parentID})
+	if err != nil {
+		t.Fatalf("create child: %v", err)
+	}
+	if _, err := client.UpdateTodo(treeCtx, &gen.UpdateTodoRequest{
+		Id:         parentID,
+		ParentId:   child.GetTodo().GetId(),
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"parent_id"}},
+	}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for a parent cycle, got %v", err)
+	}
+	// Идентификатор в верхнем регистре — та же задача, и цикл через него
+	// тоже должен обнаруживаться.
+	if _, err := client.UpdateTodo(treeCtx, &gen.UpdateTodoRequest{
+		Id:         parentID,
+		ParentId:   strings.ToUpper(child.GetTodo().GetId()),
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"parent_id"}},
+	}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for a parent cycle with an uppercase id, got %v", err)
+	}
+	if _, err := client.UpdateTodo(treeCtx, &gen.UpdateTodoRequest{
+		Id:         strings.ToUpper(parentID),
+		ParentId:   parentID,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"parent_id"}},
+	}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for a self parent with an uppercase id, got %v", err)
+	}
+	if _, err := client.GetTodoTree(treeCtx, &gen.GetTodoTreeRequest{Id: "not-a-uuid"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for a malformed tree id, got %v", err)
+	}
+	tree, err := client.GetTodoTree(treeCtx, &gen.GetTodoTreeRequest{Id: strings.ToUpper(parentID)})
+	if err != nil {
+		t.Fatalf("get todo tree: %v", err)
+	}
+	if tree.GetTodo().GetId() != parentID {
+		t.Fatalf("expected canonical tree root id %s, got %s", parentID, tree.GetTodo().GetId())
+	}
+	if len(tree.GetChildren()) != 1 || tree.GetTotalCount() != 1 || tree.GetCompletedCount() != 0 {
+		t.Fatalf("unexpected tree: %d children, %d/%d completed",
+			len(tree.GetChildren()), tree.GetCompletedCount(), tree.GetTotalCount())
+	}
+
+	blocker, err := client.CreateTodo(treeCtx, &gen.CreateTodoRequest{Title: "Blocker"})
+	if err != nil {
+		t.Fatalf("create blocker: %v", err)
+	}
+	blockerID := blocker.GetTodo().GetId()
+	blocked, err := client.AddDependency(treeCtx, &gen.AddDependencyRequest{Id: parentID, BlockerId: blockerID})
+	if err != nil {
+		t.Fatalf("add dependency: %v", err)
+	}
+	if !blocked.GetBlocked() {
+		t.Fatalf("expected todo to be blocked")
+	}
+	if _, err := client.AddDependency(treeCtx, &gen.AddDependencyRequest{Id: blockerID, BlockerId: parentID}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for a dependency cycle, got %v", err)
+	}
+	if _, err := client.UpdateTodo(treeCtx, &gen.UpdateTodoRequest{
+		Id:         parentID,
+		Completed:  true,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"completed"}},
+	}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition for a blocked todo, got %v", err)
+	}
+	if _, err := client.UpdateTodo(treeCtx, &gen.UpdateTodoRequest{
+		Id:         blockerID,
+		Completed:  true,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"completed"}},
+	}); err != nil {
+		t.Fatalf("complete blocker: %v", err)
+	}
+	completed, err := client.UpdateTodo(treeCtx, &gen.UpdateTodoRequest{
+		Id:         parentID,
+		Completed:  true,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"completed"}},
+	})
+	if err != nil {
+		t.Fatalf("complete unblocked todo: %v", err)
+	}
+	if completed.GetBlocked() {
+		t.Fatalf("expected todo to be unblocked")
+	}
+	tree, err = client.GetTodoTree(treeCtx, &gen.GetTodoTreeRequest{Id: parentID})
+	if err != nil {
+		t.Fatalf("get completed tree: %v", err)
+	}
+	if tree.GetCompletedCount() != 1 {
+		t.Fatalf("expected subtasks to be completed with the parent")
+	}
+
+	// Подзадачи: дерево с прогрессом, ограничение глубины и политика завершения.
+	chain := []string{""}
+	for level := 1; level <= 5; level++ {
+		created, err := client.CreateTodo(treeCtx, &gen.CreateTodoRequest{
+			Title: fmt.Sprintf("Subtask level %d", level), ParentId: chain[len(chain)-1],
+		})
+		if err != nil {
+			t.Fatalf("create subtask level %d: %v", level, err)
+		}
+		chain = append(chain, created.GetTodo().GetId())
+	}
+	chain = chain[1:]
+	if _, err := client.CreateTodo(treeCtx, &gen.CreateTodoRequest{Title: "Too deep", ParentId: chain[4]}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for a subtask beyond the depth limit, got %v", err)
+	}
+	if _, err := client.UpdateTodo(treeCtx, &gen.UpdateTodoRequest{
+		Id: chain[2], Completed: true, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"completed"}},
+	}); err != nil {
+		t.Fatalf("complete middle subtask: %v", err)
+	}
+	chainTree, err := client.GetTodoTree(treeCtx, &gen.GetTodoTreeRequest{Id: chain[0]})
+	if err != nil {
+		t.Fatalf("get subtask chain tree: %v", err)
+	}
+	if len(chainTree.GetChildren()) != 1 || len(chainTree.GetChildren()[0].GetChildren()) != 1 ||
+		chainTree.GetTotalCount() != 4 || chainTree.GetCompletedCount() != 3 {
+		t.Fatalf("expected a nested tree with 3 of 4 subtasks completed, got %d/%d",
+			chainTree.GetCompletedCount(), chainTree.GetTotalCount())
+	}
+	recurringDue := time.Now().Add(24 * time.Hour).Unix()
+	recurringSubtask, err := client.CreateTodo(treeCtx, &gen.CreateTodoRequest{
+		Title: "Recurring subtask", ParentId: chain[1], DueAt: &recurringDue, Recurrence: "FREQ=DAILY",
+	})
+	if err != nil {
+		t.Fatalf("create recurring subtask: %v", err)
+	}
+	if _, err := client.UpdateTodo(treeCtx, &gen.UpdateTodoRequest{
+		Id: chain[0], Completed: true, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"completed"}},
+	}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition for a cascade over a recurring subtask, got %v", err)
+	}
+	if _, err := client.DeleteTodo(treeCtx, &gen.DeleteTodoRequest{Id: recurringSubtask.GetTodo().GetId()}); err != nil {
+		t.Fatalf("delete recurring subtask: %v", err)
+	}
+	if _, err := client.UpdateTodo(treeCtx, &gen.UpdateTodoRequest{
+		Id: chain[0], Completed: true, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"completed"}},
+	}); err != nil {
+		t.Fatalf("complete subtask chain root: %v", err)
+	}
+	if chainTree, err = client.GetTodoTree(treeCtx, &gen.GetTodoTreeRequest{Id: chain[0]}); err != nil ||
+		chainTree.GetCompletedCount() != 4 {
+		t.Fatalf("expected every subtask to be completed with the root, got %+v, %v", chainTree, err)
+	}
+
+	dueAt := time.Date(2030, time.January, 7, 9, 0, 0, 0, time.UTC).Unix()
+	recurring, err := client.CreateTodo(treeCtx, &gen.CreateTodoRequest{
+		Title:      "Weekly review",
+		DueAt:      &dueAt,
+		Recurrence: "FREQ=WEEKLY;BYDAY=MO",
+		Tags:       []string{"review"},
+	})
+	if err != nil {
+		t.Fatalf("create recurring todo: %v", err)
+	}
+	// Метки, изменённые вместе с завершением, получает и следующее вхождение.
+	if _, err := client.UpdateTodo(treeCtx, &gen.UpdateTodoRequest{
+		Id:         recurring.GetTodo().GetId(),
+		Completed:  true,
+		Tags:       []string{"weekly"},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"completed", "tags"}},
+	}); err != nil {
+		t.Fatalf("complete recurring todo: %v", err)
+	}
+	series, err := client.ListTodos(treeCtx, &gen.ListTodosRequest{
+		Filter:  &gen.TodoFilter{SeriesId: recurring.GetTodo().GetSeriesId()},
+		OrderBy: gen.TodoOrder_TODO_ORDER_CREATED_AT_ASC,
+	})
+	if err != nil {
+		t.Fatalf("list series: %v", err)
+	}
+	if len(series.GetTodos()) != 2 || series.GetTodos()[1].GetDueAt() != dueAt+7*24*60*60 {
+		t.Fatalf("expected the next weekly occurrence to be spawned")
+	}
+	if tags := series.GetTodos()[1].GetTags(); len(tags) != 1 || tags[0] != "weekly" {
+		t.Fatalf("expected the next occurrence to get the updated tags, got %v", tags)
+	}
+
+	comments := gen.NewCommentServiceClient(conn)
+	discussed := recurring.GetTodo().GetId()
+	for _, body := range []string{"First", "Second"} {
+		if _, err := comments.AddComment(treeCtx, &gen.AddCommentRequest{TodoId: discussed, Author: "alice", Body: body}); err != nil {
+			t.Fatalf("add comment: %v", err)
+		}
+	}
+	commentPage, err := comments.ListComments(treeCtx, &gen.ListCommentsRequest{TodoId: discussed, PageSize: 1})
+	if err != nil {
+		t.Fatalf("list comments: %v", err)
+	}
+	if len(commentPage.GetComments()) != 1 || commentPage.GetComments()[0].GetBody() != "First" || commentPage.GetNextPageToken() == "" {
+		t.Fatalf("expected the oldest comment and a next page token")
+	}
+	if _, err := client.DeleteTodo(treeCtx, &gen.DeleteTodoRequest{Id: discussed}); err != nil {
+		t.Fatalf("delete discussed todo: %v", err)
+	}
+	if _, err := comments.ListComments(treeCtx, &gen.ListCommentsRequest{TodoId: discussed}); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for comments of a trashed todo, got %v", err)
+	}
+	restoredTodo, err := client.RestoreTodo(treeCtx, &gen.RestoreTodoRequest{Id: discussed})
+	if err != nil {
+		t.Fatalf("restore discussed todo: %v", err)
+	}
+	if restoredTodo.GetCommentCount() != 2 {
+		t.Fatalf("expected comments to be restored with the todo, got %d", restoredTodo.GetCommentCount())
+	}
+
+	attachments := gen.NewAttachmentServiceClient(conn)
+	upload, err := attachments.UploadAttachment(treeCtx)
+	if err != nil {
+		t.Fatalf("open upload stream: %v", err)
+	}
+	for _, msg := range []*gen.UploadAttachmentRequest{
+		{Payload: &gen.UploadAttachmentRequest_Metadata{Metadata: &gen.UploadAttachmentMetadata{TodoId: discussed, Filename: "notes/readme.txt"}}},
+		{Payload: &gen.UploadAttachmentRequest_Chunk{Chunk: []byte("hello, ")}},
+		{Payload: &gen.UploadAttachmentRequest_Chunk{Chunk: []byte("world")}},
+	} {
+		if err := upload.Send(msg); err != nil {
+			t.Fatalf("send upload message: %v", err)
+		}
+	}
+	attached, err := upload.CloseAndRecv()
+	if err != nil {
+		t.Fatalf("upload attachment: %v", err)
+	}
+	if attached.GetFilename() != "readme.txt" || attached.GetSize() != 12 || !strings.HasPrefix(attached.GetContentType(), "text/plain") {
+		t.Fatalf("unexpected attachment metadata: %+v", attached)
+	}
+	download, err := attachments.DownloadAttachment(treeCtx, &gen.DownloadAttachmentRequest{Id: attached.GetId()})
+	if err != nil {
+		t.Fatalf("open download stream: %v", err)
+	}
+	var content []byte
+	for {
+		msg, err := download.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("download attachment: %v", err)
+		}
+		content = append(content, msg.GetChunk()...)
+	}
+	if string(content) != "hello, world" {
+		t.Fatalf("expected downloaded content to match, got %q", content)
+	}
+
+	if restoredTodo.GetOwnerId() != "alice" {
+		t.Fatalf("expected the caller to own the todo, got %q", restoredTodo.GetOwnerId())
+	}
+	otherTenant := auth.NewContext(ctx, auth.Principal{Subject: "mallory", TenantID: "other"})
+	if _, err := repo.Get(otherTenant, discussed); !errors.Is(err, todorepo.ErrNotFound) {
+		t.Fatalf("expected another tenant to get ErrNotFound, got %v", err)
+	}
+	stolen := "Stolen"
+	if _, err := repo.Update(otherTenant, discussed, 0, todorepo.Patch{Title: &stolen}); !errors.Is(err, todorepo.ErrNotFound) {
+		t.Fatalf("expected another tenant's update to get ErrNotFound, got %v", err)
+	}
+	if err := repo.Delete(otherTenant, discussed, 0); !errors.Is(err, todorepo.ErrNotFound) {
+		t.Fatalf("expected another tenant's delete to get ErrNotFound, got %v", err)
+	}
+	foreign, err := repo.List(otherTenant, todorepo.ListParams{})
+	if err != nil {
+		t.Fatalf("list as another tenant: %v", err)
+	}
+	if len(foreign) != 0 {
+		t.Fatalf("expected another tenant to see no todos, got %d", len(foreign))
+	}
+
+	bobConn, err := grpc.DialContext(ctx, addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithPerRPCCredentials(bearerToken(t, "bob", "acme")),
+		grpc.WithBlock(),
+	)
+	if err != nil {
+		t.Fatalf("dial server: %v", err)
+	}
+	defer bobConn.Close()
+	bob := gen.NewTodoServiceClient(bobConn)
+	if _, err := bob.GetTodo(treeCtx, &gen.GetTodoRequest{Id: discussed}); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for a todo that is not shared, got %v", err)
+	}
+	// Журнал окончательно удалённой задачи не открывается другим
+	// пользователям арендатора, у которых не было к ней доступа.
+	if _, err := bob.ListTodoHistory(treeCtx, &gen.ListTodoHistoryRequest{Id: todoID}); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for the history of someone else's purged todo, got %v", err)
+	}
+	if _, err := bob.ShareTodo(treeCtx, &gen.ShareTodoRequest{Id: discussed, UserId: "bob", Role: gen.Role_ROLE_OWNER}); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound when sharing someone else's todo, got %v", err)
+	}
+	if _, err := client.ShareTodo(treeCtx, &gen.ShareTodoRequest{Id: discussed, UserId: "bob", Role: gen.Role_ROLE_VIEWER}); err != nil {
+		t.Fatalf("share todo: %v", err)
+	}
+	if _, err := bob.GetTodo(treeCtx, &gen.GetTodoRequest{Id: discussed}); err != nil {
+		t.Fatalf("get shared todo: %v", err)
+	}
+	if _, err := bob.UpdateTodo(treeCtx, &gen.UpdateTodoRequest{
+		Id:         discussed,
+		Title:      "Edited by a viewer",
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"title"}},
+	}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied for a viewer's update, got %v", err)
+	}
+	if _, err := client.ShareTodo(treeCtx, &gen.ShareTodoRequest{Id: discussed, UserId: "bob", Role: gen.Role_ROLE_EDITOR}); err != nil {
+		t.Fatalf("promote collaborator: %v", err)
+	}
+	if _, err := bob.UpdateTodo(treeCtx, &gen.UpdateTodoRequest{
+		Id:         discussed,
+		Title:      "Edited by an editor",
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"title"}},
+	}); err != nil {
+		t.Fatalf("update as an editor: %v", err)
+	}
+	if _, err := bob.PurgeTodo(treeCtx, &gen.PurgeTodoRequest{Id: discussed}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied for an editor's purge, got %v", err)
+	}
+	shared, err := bob.ListTodos(treeCtx, &gen.ListTodosRequest{
+		Filter: &gen.TodoFilter{Ownership: gen.Ownership_OWNERSHIP_SHARED},
+	})
+	if err != nil {
+		t.Fatalf("list shared todos: %v", err)
+	}
+	if len(shared.GetTodos()) != 1 || shared.GetTodos()[0].GetId() != discussed {
+		t.Fatalf("expected the shared todo in bob's list, got %+v", shared.GetTodos())
+	}
+	collaborators, err := bob.ListCollaborators(treeCtx, &gen.ListCollaboratorsRequest{Id: discussed})
+	if err != nil {
+		t.Fatalf("list collaborators: %v", err)
+	}
+	if got := collaborators.GetCollaborators(); len(got) != 2 || got[0].GetUserId() != "alice" || got[1].GetRole() != gen.Role_ROLE_EDITOR {
+		t.Fatalf("unexpected collaborators: %+v", got)
+	}
+	if _, err := bob.UnshareTodo(treeCtx, &gen.UnshareTodoRequest{Id: discussed, UserId: "bob"}); err != nil {
+		t.Fatalf("leave shared todo: %v", err)
+	}
+	if _, err := bob.GetTodo(treeCtx, &gen.GetTodoRequest{Id: discussed}); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound after leaving a todo, got %v", err)
+	}
+
+	history, err := client.ListTodoHistory(treeCtx, &gen.ListTodoHistoryRequest{Id: discussed, PageSize: 1})
+	if err != nil {
+		t.Fatalf("list todo history: %v", err)
+	}
+	if len(history.GetEvents()) != 1 || history.GetNextPageToken() == "" {
+		t.Fatalf("expected one event and a next page token, got %+v", history)
+	}
+	latest := history.GetEvents()[0]
+	if latest.GetActor() != "bob" || latest.GetOperation() != gen.TodoEventOperation_TODO_EVENT_OPERATION_UPDATE ||
+		len(latest.GetChanges()) != 1 || latest.GetChanges()[0].GetAfter().GetStringValue() != "Edited by an editor" {
+		t.Fatalf("expected bob's title change as the latest event, got %+v", latest)
+	}
+	fullHistory, err := client.ListTodoHistory(treeCtx, &gen.ListTodoHistoryRequest{Id: discussed})
+	if err != nil {
+		t.Fatalf("list todo history: %v", err)
+	}
+	events := fullHistory.GetEvents()
+	if first := events[len(events)-1]; first.GetOperation() != gen.TodoEventOperation_TODO_EVENT_OPERATION_CREATE || first.GetActor() != "alice" {
+		t.Fatalf("expected the history to start with alice creating the todo, got %+v", first)
+	}
+
+	tooBig := &gen.BatchCreateTodosRequest{Requests: []*gen.CreateTodoRequest{{Title: "1"}, {Title: "2"}, {Title: "3"}, {Title: "4"}}}
+	if _, err := client.BatchCreateTodos(treeCtx, tooBig); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for an oversized batch, got %v", err)
+	}
+	mixed := []*gen.CreateTodoRequest{{Title: "Batch one", Tags: []string{"batch"}}, {Title: ""}, {Title: "Batch two", Tags: []string{"batch"}}}
+	_, err = client.BatchCreateTodos(treeCtx, &gen.BatchCreateTodosRequest{Requests: mixed, AllOrNothing: true})
+	if status.Code(err) != codes.InvalidArgument || !strings.HasPrefix(status.Convert(err).Message(), "item 1:") {
+		t.Fatalf("expected the atomic batch to fail on item 1, got %v", err)
+	}
+	batchTagged, err := client.ListTodos(treeCtx, &gen.ListTodosRequest{Filter: &gen.TodoFilter{Tags: []string{"batch"}}})
+	if err != nil {
+		t.Fatalf("list batch todos: %v", err)
+	}
+	if len(batchTagged.GetTodos()) != 0 {
+		t.Fatalf("expected a failed atomic batch to create nothing, got %d todos", len(batchTagged.GetTodos()))
+	}
+	partial, err := client.BatchCreateTodos(treeCtx, &gen.BatchCreateTodosRequest{Requests: mixed})
+	if err != nil {
+		t.Fatalf("best-effort batch create: %v", err)
+	}
+	results := partial.GetResults()
+	if len(results) != 3 || results[0].GetCode() != int32(codes.OK) || results[1].GetCode() != int32(codes.InvalidArgument) ||
+		results[2].GetTodo().GetTitle() != "Batch two" {
+		t.Fatalf("unexpected best-effort results: %+v", results)
+	}
+	batchUpdated, err := client.BatchUpdateTodos(treeCtx, &gen.BatchUpdateTodosRequest{
+		Requests: []*gen.UpdateTodoRequest{
+			{Id: results[0].GetTodo().GetId(), Completed: true, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"completed"}}},
+			{Id: results[2].GetTodo().GetId(), Completed: true, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"completed"}}},
+		},
+		AllOrNothing: true,
+	})
+	if err != nil {
+		t.Fatalf("atomic batch update: %v", err)
+	}
+	for _, res := range batchUpdated.GetResults() {
+		if !res.GetTodo().GetCompleted() {
+			t.Fatalf("expected every todo in the batch to be completed, got %+v", res)
+		}
+	}
+	batchBlocker, err := client.CreateTodo(treeCtx, &gen.CreateTodoRequest{Title: "Batch blocker"})
+	if err != nil {
+		t.Fatalf("create batch blocker: %v", err)
+	}
+	batchBlocked, err := client.CreateTodo(treeCtx, &gen.CreateTodoRequest{Title: "Batch blocked"})
+	if err != nil {
+		t.Fatalf("create batch blocked todo: %v", err)
+	}
+	if _, err := client.AddDependency(treeCtx, &gen.AddDependencyRequest{
+		Id: batchBlocked.GetTodo().GetId(), BlockerId: batchBlocker.GetTodo().GetId(),
+	}); err != nil {
+		t.Fatalf("add batch dependency: %v", err)
+	}
+	if _, err := client.BatchUpdateTodos(treeCtx, &gen.BatchUpdateTodosRequest{
+		Requests: []*gen.UpdateTodoRequest{
+			{Id: batchBlocker.GetTodo().GetId(), Completed: true, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"completed"}}},
+			{Id: batchBlocked.GetTodo().GetId(), Completed: true, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"completed"}}},
+		},
+		AllOrNothing: true,
+	}); err != nil {
+		t.Fatalf("expected an atomic batch to complete a blocker and then the todo it blocks: %v", err)
+	}
+	_, err = client.BatchDeleteTodos(treeCtx, &gen.BatchDeleteTodosRequest{
+		Requests: []*gen.DeleteTodoRequest{
+			{Id: results[0].GetTodo().GetId()},
+			{Id: results[2].GetTodo().GetId(), ExpectedVersion: 1},
+		},
+		AllOrNothing: true,
+	})
+	if status.Code(err) != codes.Aborted {
+		t.Fatalf("expected a stale version to abort the atomic delete, got %v", err)
+	}
+	if _, err := client.GetTodo(treeCtx, &gen.GetTodoRequest{Id: results[0].GetTodo().GetId()}); err != nil {
+		t.Fatalf("expected the aborted batch to keep the first todo: %v", err)
+	}
+
+	retryKey := fmt.Sprintf("create-%d", time.Now().UnixNano())
+	retryCtx := metadata.AppendToOutgoingContext(treeCtx, idempotency.Header, retryKey)
+	first, err := client.CreateTodo(retryCtx, &gen.CreateTodoRequest{Title: "Retried create"})
+	if err != nil {
+		t.Fatalf("create todo with idempotency key: %v", err)
+	}
+	retried, err := client.CreateTodo(retryCtx, &gen.CreateTodoRequest{Title: "Retried create"})
+	if err != nil {
+		t.Fatalf("retry create todo with idempotency key: %v", err)
+	}
+	if retried.GetTodo().GetId() != first.GetTodo().GetId() {
+		t.Fatalf("expected a retry to replay todo %s, got %s", first.GetTodo().GetId(), retried.GetTodo().GetId())
+	}
+	if _, err := client.CreateTodo(retryCtx, &gen.CreateTodoRequest{Title: "Different create"}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition for a reused idempotency key, got %v", err)
+	}
+	if other, err := bob.CreateTodo(retryCtx, &gen.CreateTodoRequest{Title: "Retried create"}); err != nil || other.GetTodo().GetId() == first.GetTodo().GetId() {
+		t.Fatalf("expected idempotency keys to be per caller, got %+v, %v", other, err)
+	}
+	keyedImport, err := client.ImportTodos(retryCtx)
+	if err != nil {
+		t.Fatalf("open import stream with idempotency key: %v", err)
+	}
+	if _, err := keyedImport.CloseAndRecv(); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for an idempotency key on a streaming call, got %v", err)
+	}
+
+	var transferIDs []string
+	for _, title := range []string{"Export one", "Export, two"} {
+		created, err := client.CreateTodo(treeCtx, &gen.CreateTodoRequest{Title: title, Tags: []string{"transfer"}})
+		if err != nil {
+			t.Fatalf("create todo for export: %v", err)
+		}
+		transferIDs = append(transferIDs, created.GetTodo().GetId())
+	}
+	exportStream, err := client.ExportTodos(treeCtx, &gen.ExportTodosRequest{
+		Filter: &gen.TodoFilter{Tags: []string{"transfer"}},
+		Format: gen.TransferFormat_TRANSFER_FORMAT_CSV,
+	})
+	if err != nil {
+		t.Fatalf("export todos: %v", err)
+	}
+	var exported strings.Builder
+	for {
+		msg, err := exportStream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("receive export chunk: %v", err)
+		}
+		exported.Write(msg.GetChunk())
+	}
+	csvRows, err := csv.NewReader(strings.NewReader(exported.String())).ReadAll()
+	if err != nil {
+		t.Fatalf("parse exported csv: %v", err)
+	}
+	if len(csvRows) != 3 || csvRows[0][0] != "id" || csvRows[1][0] != transferIDs[0] || csvRows[2][1] != "Export, two" {
+		t.Fatalf("unexpected csv export: %q", csvRows)
+	}
+
+	importTodos := func(mode gen.ImportMode, lines ...string) *gen.ImportTodosResponse {
+		t.Helper()
+		stream, err := client.ImportTodos(treeCtx)
+		if err != nil {
+			t.Fatalf("open import stream: %v", err)
+		}
+		if err := stream.Send(&gen.ImportTodosRequest{Payload: &gen.ImportTodosRequest_Options{Options: &gen.ImportOptions{
+			Format: gen.TransferFormat_TRANSFER_FORMAT_NDJSON,
+			Mode:   mode,
+		}}}); err != nil {
+			t.Fatalf("send import options: %v", err)
+		}
+		for _, line := range lines {
+			if err := stream.Send(&gen.ImportTodosRequest{Payload: &gen.ImportTodosRequest_Chunk{Chunk: []byte(line + "\n")}}); err != nil {
+				t.Fatalf("send import chunk: %v", err)
+			}
+		}
+		res, err := stream.CloseAndRecv()
+		if err != nil {
+			t.Fatalf("import todos: %v", err)
+		}
+		return res
+	}
+	imported := importTodos(gen.ImportMode_IMPORT_MODE_CREATE,
+		`{"id":"`+transferIDs[0]+`","title":"Imported copy","tags":["transfer"]}`,
+		`{"title":""}`,
+		`{"title":"Imported done","completed":true,"priority":"high"}`,
+	)
+	if imported.GetCreated() != 2 || imported.GetFailed() != 1 || len(imported.GetErrors()) != 1 ||
+		imported.GetErrors()[0].GetRow() != 2 || codes.Code(imported.GetErrors()[0].GetCode()) != codes.InvalidArgument {
+		t.Fatalf("unexpected create import result: %+v", imported)
+	}
+
+	importedID := fmt.Sprintf("8d5a0c3e-6a51-4b0f-9f0e-%012x", time.Now().UnixNano()&0xffffffffffff)
+	upserted := importTodos(gen.ImportMode_IMPORT_MODE_UPSERT,
+		`{"id":"`+transferIDs[1]+`","title":"Export two, renamed","tags":["transfer","moved"]}`,
+		`{"id":"`+importedID+`","title":"Imported with id","completed":true}`,
+	)
+	if upserted.GetCreated() != 1 || upserted.GetUpdated() != 1 || upserted.GetFailed() != 0 {
+		t.Fatalf("unexpected upsert import result: %+v", upserted)
+	}
+	renamed, err := client.GetTodo(treeCtx, &gen.GetTodoRequest{Id: transferIDs[1]})
+	if err != nil {
+		t.Fatalf("get upserted todo: %v", err)
+	}
+	if renamed.GetTitle() != "Export two, renamed" || strings.Join(renamed.GetTags(), ",") != "moved,transfer" {
+		t.Fatalf("expected upsert to rename and retag the todo, got %+v", renamed)
+	}
+	if withID, err := client.GetTodo(treeCtx, &gen.GetTodoRequest{Id: importedID}); err != nil || !withID.GetCompleted() {
+		t.Fatalf("expected upsert to create a completed todo with the given id, got %+v, %v", withID, err)
+	}
+	upsertHistory, err := client.ListTodoHistory(treeCtx, &gen.ListTodoHistoryRequest{Id: transferIDs[1], PageSize: 1})
+	if err != nil || len(upsertHistory.GetEvents()) != 1 {
+		t.Fatalf("list upserted todo history: %+v, %v", upsertHistory, err)
+	}
+	upsertFields := map[string]bool{}
+	for _, change := range upsertHistory.GetEvents()[0].GetChanges() {
+		upsertFields[change.GetField()] = true
+	}
+	if !upsertFields["title"] || !upsertFields["tags"] {
+		t.Fatalf("expected the upsert to rename and retag in one event, got %+v", upsertHistory.GetEvents()[0])
+	}
+	trashedTransfer, err := client.CreateTodo(treeCtx, &gen.CreateTodoRequest{Title: "Trashed transfer"})
+	if err != nil {
+		t.Fatalf("create todo to trash before upsert: %v", err)
+	}
+	if _, err := client.DeleteTodo(treeCtx, &gen.DeleteTodoRequest{Id: trashedTransfer.GetTodo().GetId()}); err != nil {
+		t.Fatalf("trash todo before upsert: %v", err)
+	}
+	hidden := importTodos(gen.ImportMode_IMPORT_MODE_UPSERT, `{"id":"`+trashedTransfer.GetTodo().GetId()+`","title":"Over the trash"}`)
+	if hidden.GetFailed() != 1 || codes.Code(hidden.GetErrors()[0].GetCode()) != codes.NotFound {
+		t.Fatalf("expected NotFound for an upsert onto a trashed todo, got %+v", hidden)
+	}
+
+	calTodo, err := client.CreateTodo(treeCtx, &gen.CreateTodoRequest{Title: "Calendar todo", Tags: []string{"icalendar"}})
+	if err != nil {
+		t.Fatalf("create todo for icalendar export: %v", err)
+	}
+	calStream, err := client.ExportICalendar(treeCtx, &gen.ExportICalendarRequest{Filter: &gen.TodoFilter{Tags: []string{"icalendar"}}})
+	if err != nil {
+		t.Fatalf("export icalendar: %v", err)
+	}
+	var calendar strings.Builder
+	for {
+		msg, err := calStream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("receive icalendar chunk: %v", err)
+		}
+		calendar.Write(msg.GetChunk())
+	}
+	if !strings.Contains(calendar.String(), "BEGIN:VTODO\r\n") || !strings.Contains(calendar.String(), "UID:"+calTodo.GetTodo().GetId()+"\r\n") {
+		t.Fatalf("unexpected icalendar export:\n%s", calendar.String())
+	}
+
+	importCalendar := func(cal string) *gen.ImportTodosResponse {
+		t.Helper()
+		stream, err := client.ImportICalendar(treeCtx)
+		if err != nil {
+			t.Fatalf("open icalendar import stream: %v", err)
+		}
+		if err := stream.Send(&gen.ImportICalendarRequest{Chunk: []byte(cal)}); err != nil {
+			t.Fatalf("send icalendar chunk: %v", err)
+		}
+		res, err := stream.CloseAndRecv()
+		if err != nil {
+			t.Fatalf("import icalendar: %v", err)
+		}
+		return res
+	}
+	reimported := importCalendar(strings.Replace(calendar.String(), "SUMMARY:Calendar todo", "SUMMARY:Calendar todo, edited", 1))
+	if reimported.GetCreated() != 0 || reimported.GetUpdated() != 1 || reimported.GetFailed() != 0 {
+		t.Fatalf("expected a re-imported calendar to update the todo, got %+v", reimported)
+	}
+	if edited, err := client.GetTodo(treeCtx, &gen.GetTodoRequest{Id: calTodo.GetTodo().GetId()}); err != nil ||
+		edited.GetTitle() != "Calendar todo, edited" || strings.Join(edited.GetTags(), ",") != "icalendar" {
+		t.Fatalf("expected the icalendar import to rename the todo, got %+v, %v", edited, err)
+	}
+	foreignCal := "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//Other//EN\r\nBEGIN:VTODO\r\n" +
+		fmt.Sprintf("UID:%d@example.com\r\n", time.Now().UnixNano()) +
+		"SUMMARY:Foreign todo\r\nDUE;VALUE=DATE:20261020\r\nPRIORITY:1\r\nEND:VTODO\r\nEND:VCALENDAR\r\n"
+	if res := importCalendar(foreignCal); res.GetCreated() != 1 || res.GetUpdated() != 0 {
+		t.Fatalf("expected a foreign todo to be created, got %+v", res)
+	}
+	if res := importCalendar(foreignCal); res.GetCreated() != 0 || res.GetUpdated() != 1 {
+		t.Fatalf("expected a repeated import to update the foreign todo, got %+v", res)
+	}
+	if _, err := client.DeleteTodo(treeCtx, &gen.DeleteTodoRequest{Id: calTodo.GetTodo().GetId()}); err != nil {
+		t.Fatalf("trash calendar todo: %v", err)
+	}
+	if res := importCalendar(calendar.String()); res.GetCreated() != 1 || res.GetFailed() != 0 {
+		t.Fatalf("expected a calendar import to recreate a trashed todo, got %+v", res)
+	}
+	if _, err := client.RestoreTodo(treeCtx, &gen.RestoreTodoRequest{Id: calTodo.GetTodo().GetId()}); status.Code(err) != codes.AlreadyExists {
+		t.Fatalf("expected AlreadyExists when restoring a todo whose UID was reused, got %v", err)
+	}
+
+	var bulkIDs []string
+	for _, title := range []string{"Bulk one", "Bulk two", "Bulk three"} {
+		created, err := client.CreateTodo(treeCtx, &gen.CreateTodoRequest{Title: title, Tags: []string{"bulk"}})
+		if err != nil {
+			t.Fatalf("create bulk todo: %v", err)
+		}
+		bulkIDs = append(bulkIDs, created.GetTodo().GetId())
+	}
+	bulkFilter := &gen.TodoFilter{Tags: []string{"bulk"}}
+	done := true
+	preview, err := client.BulkUpdateByFilter(treeCtx, &gen.BulkUpdateByFilterRequest{
+		Filter: bulkFilter, Completed: &done, DryRun: true, SampleSize: 2,
+	})
+	if err != nil {
+		t.Fatalf("preview bulk update: %v", err)
+	}
+	if preview.GetAffectedCount() != 3 || len(preview.GetSample()) != 2 {
+		t.Fatalf("expected a dry run over three todos with a sample of two, got %+v", preview)
+	}
+	if unchanged, err := client.GetTodo(treeCtx, &gen.GetTodoRequest{Id: bulkIDs[0]}); err != nil || unchanged.GetCompleted() {
+		t.Fatalf("expected a dry run to change nothing, got %+v, %v", unchanged, err)
+	}
+	bulkDone, err := client.BulkUpdateByFilter(treeCtx, &gen.BulkUpdateByFilterRequest{Filter: bulkFilter, Completed: &done})
+	if err != nil {
+		t.Fatalf("bulk update: %v", err)
+	}
+	if bulkDone.GetAffectedCount() != 3 {
+		t.Fatalf("expected three completed todos, got %d", bulkDone.GetAffectedCount())
+	}
+	again, err := client.BulkUpdateByFilter(treeCtx, &gen.BulkUpdateByFilterRequest{Filter: bulkFilter, Completed: &done})
+	if err != nil || again.GetAffectedCount() != 0 {
+		t.Fatalf("expected a repeated bulk update to change nothing, got %+v, %v", again, err)
+	}
+	bulkHistory, err := client.ListTodoHistory(treeCtx, &gen.ListTodoHistoryRequest{Id: bulkIDs[1], PageSize: 1})
+	if err != nil {
+		t.Fatalf("list bulk todo history: %v", err)
+	}
+	if ev := bulkHistory.GetEvents()[0]; ev.GetOperation() != gen.TodoEventOperation_TODO_EVENT_OPERATION_UPDATE ||
+		len(ev.GetChanges()) != 1 || ev.GetChanges()[0].GetField() != "completed" {
+		t.Fatalf("expected a completion audit entry for the bulk update, got %+v", ev)
+	}
+	bulkDeleted, err := client.BulkDeleteByFilter(treeCtx, &gen.BulkDeleteByFilterRequest{
+		Filter: &gen.TodoFilter{Tags: []string{"bulk"}, Completed: &done},
+	})
+	if err != nil {
+		t.Fatalf("bulk delete: %v", err)
+	}
+	if bulkDeleted.GetAffectedCount() != 3 {
+		t.Fatalf("expected three deleted todos, got %d", bulkDeleted.GetAffectedCount())
+	}
+	if _, err := client.GetTodo(treeCtx, &gen.GetTodoRequest{Id: bulkIDs[2]}); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected a bulk-deleted todo to be in the trash, got %v", err)
+	}
+	cascadeParent, err := client.CreateTodo(treeCtx, &gen.CreateTodoRequest{Title: "Bulk cascade parent", Tags: []string{"bulk-cascade"}})
+	if err != nil {
+		t.Fatalf("create bulk cascade parent: %v", err)
+	}
+	cascadeDue := time.Now().Add(24 * time.Hour).Unix()
+	recurringChild, err := client.CreateTodo(treeCtx, &gen.CreateTodoRequest{
+		Title: "Bulk recurring child", ParentId: cascadeParent.GetTodo().GetId(), DueAt: &cascadeDue, Recurrence: "FREQ=DAILY",
+	})
+	if err != nil {
+		t.Fatalf("create recurring subtask: %v", err)
+	}
+	cascaded, err := client.BulkUpdateByFilter(treeCtx, &gen.BulkUpdateByFilterRequest{
+		Filter: &gen.TodoFilter{Tags: []string{"bulk-cascade"}}, Completed: &done,
+	})
+	if err != nil || cascaded.GetAffectedCount() != 0 {
+		t.Fatalf("expected a bulk update to skip a todo with a recurring subtask, got %+v, %v", cascaded, err)
+	}
+	if child, err := client.GetTodo(treeCtx, &gen.GetTodoRequest{Id: recurringChild.GetTodo().GetId()}); err != nil || child.GetCompleted() {
+		t.Fatalf("expected the recurring subtask to stay open, got %+v, %v", child, err)
+	}
+
+	// С политикой RefuseOpenSubtasks массовое завершение пропускает задачу,
+	// у которой открыт потомок на любой глубине, а не только прямая подзадача.
+	refusing := todosvc.NewService(repo, accessRepo, todosvc.Config{MaxDepth: 5, CompletionPolicy: todosvc.RefuseOpenSubtasks})
+	refuseCtx := auth.NewContext(treeCtx, auth.Principal{Subject: "refuser", TenantID: "acme"})
+	refuseTop, err := refusing.Create(refuseCtx, todosvc.CreateParams{Title: "Refuse top", Tags: []string{"bulk-refuse"}})
+	if err != nil {
+		t.Fatalf("create refuse top: %v", err)
+	}
+	refuseMiddle, err := refusing.Create(refuseCtx, todosvc.CreateParams{Title: "Refuse middle", ParentID: refuseTop.ID})
+	if err != nil {
+		t.Fatalf("create refuse middle: %v", err)
+	}
+	refuseLeaf, err := refusing.Create(refuseCtx, todosvc.CreateParams{Title: "Refuse leaf", ParentID: refuseMiddle.ID})
+	if err != nil {
+		t.Fatalf("create refuse leaf: %v", err)
+	}
+	for _, step := range []struct {
+		id        string
+		completed bool
+	}{{refuseLeaf.ID, true}, {refuseMiddle.ID, true}, {refuseLeaf.ID, false}} {
+		if _, err := refusing.Update(refuseCtx, todosvc.UpdateParams{
+			ID: step.id, Completed: step.completed, Paths: []string{todosvc.FieldCompleted},
+		}); err != nil {
+			t.Fatalf("set completed=%v on %s: %v", step.completed, step.id, err)
+		}
+	}
+	refused, err := refusing.BulkUpdate(refuseCtx, todosvc.BulkUpdateParams{
+		BulkParams: todosvc.BulkParams{Filter: todorepo.ListFilter{Tags: []string{"bulk-refuse"}}},
+		Completed:  &done,
+	})
+	if err != nil || refused.Count != 0 {
+		t.Fatalf("expected a bulk update to skip a todo with an open grandchild, got %+v, %v", refused, err)
+	}
+	if top, err := refusing.Get(refuseCtx, refuseTop.ID); err != nil || top.Completed {
+		t.Fatalf("expected the todo with an open grandchild to stay open, got %+v, %v", top, err)
+	}
+
+	watchCtx, stopWatch := context.WithTimeout(treeCtx, 10*time.Second)
+	defer stopWatch()
+	feed, err := client.WatchTodos(watchCtx, &gen.WatchTodosRequest{Filter: &gen.TodoFilter{Tags: []string{"watched"}}})
+	if err != nil {
+		t.Fatalf("watch todos: %v", err)
+	}
+	// Дожидаемся, пока подписка начнёт читать журнал, прежде чем менять задачи.
+	time.Sleep(500 * time.Millisecond)
+	if _, err := client.CreateTodo(treeCtx, &gen.CreateTodoRequest{Title: "Unwatched"}); err != nil {
+		t.Fatalf("create unwatched todo: %v", err)
+	}
+	watched, err := client.CreateTodo(treeCtx, &gen.CreateTodoRequest{Title: "Watched", Tags: []string{"watched"}})
+	if err != nil {
+		t.Fatalf("create watched todo: %v", err)
+	}
+	change, err := feed.Recv()
+	if err != nil {
+		t.Fatalf("receive todo change: %v", err)
+	}
+	if change.GetOperation() != gen.TodoEventOperation_TODO_EVENT_OPERATION_CREATE || change.GetTodo().GetId() != watched.GetTodo().GetId() {
+		t.Fatalf("expected the watched todo to be created, got %+v", change)
+	}
+	if changes := change.GetEvent().GetChanges(); len(changes) == 0 || change.GetEvent().GetSeq() != change.GetSeq() {
+		t.Fatalf("expected the change to carry the event with its fields, got %+v", change.GetEvent())
+	}
+	stopWatch()
+
+	resumeCtx, stopResume := context.WithTimeout(treeCtx, 10*time.Second)
+	defer stopResume()
+	resumed, err := client.WatchTodos(resumeCtx, &gen.WatchTodosRequest{
+		Filter:   &gen.TodoFilter{Tags: []string{"watched"}},
+		AfterSeq: change.GetSeq() - 1,
+	})
+	if err != nil {
+		t.Fatalf("resume watch: %v", err)
+	}
+	replayed, err := resumed.Recv()
+	if err != nil {
+		t.Fatalf("receive replayed change: %v", err)
+	}
+	if replayed.GetSeq() != change.GetSeq() || replayed.GetTodo().GetId() != watched.GetTodo().GetId() {
+		t.Fatalf("expected the change to be replayed after reconnecting, got %+v", replayed)
+	}
+	if _, err := client.RemoveTags(treeCtx, &gen.RemoveTagsRequest{Id: watched.GetTodo().GetId(), Tags: []string{"watched"}}); err != nil {
+		t.Fatalf("untag watched todo: %v", err)
+	}
+	left, err := resumed.Recv()
+	if err != nil {
+		t.Fatalf("receive left filter change: %v", err)
+	}
+	if !left.GetLeftFilter() || left.GetEvent().GetTodoId() != watched.GetTodo().GetId() {
+		t.Fatalf("expected the untagged todo to leave the filter, got %+v", left)
+	}
+	if left.GetTodo() != nil || len(left.GetEvent().GetChanges()) != 0 || left.GetEvent().GetActor() != "" {
+		t.Fatalf("expected a left filter change to carry only its id and seq, got %+v", left)
+	}
+	stopResume()
+
+	type received struct {
+		event string
+		body  []byte
+	}
+	deliveries := make(chan received, 16)
+	// Секрет становится известен после создания вебхука, уже при работающем получателе.
+	var receiverSecret atomic.Value
+	receiverSecret.Store("")
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		timestamp, _ := strconv.ParseInt(r.Header.Get(webhooksvc.TimestampHeader), 10, 64)
+		if r.Header.Get(webhooksvc.SignatureHeader) != webhooksvc.Sign(receiverSecret.Load().(string), timestamp, body) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		select {
+		case deliveries <- received{event: r.Header.Get(webhooksvc.EventHeader), body: body}:
+		default:
+		}
+	}))
+	defer receiver.Close()
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	webhooks := gen.NewWebhookServiceClient(conn)
+	if _, err := webhooks.CreateWebhook(treeCtx, &gen.CreateWebhookRequest{Url: "ftp://example.com"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for a non-http url, got %v", err)
+	}
+	if _, err := webhooks.CreateWebhook(treeCtx, &gen.CreateWebhookRequest{Url: "http://169.254.169.254/latest"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for a link-local url, got %v", err)
+	}
+	hook, err := webhooks.CreateWebhook(treeCtx, &gen.CreateWebhookRequest{Url: receiver.URL})
+	if err != nil {
+		t.Fatalf("create webhook: %v", err)
+	}
+	receiverSecret.Store(hook.GetSecret())
+	deadHook, err := webhooks.CreateWebhook(treeCtx, &gen.CreateWebhookRequest{Url: failing.URL})
+	if err != nil {
+		t.Fatalf("create failing webhook: %v", err)
+	}
+	hooked, err := client.CreateTodo(treeCtx, &gen.CreateTodoRequest{Title: "Hooked"})
+	if err != nil {
+		t.Fatalf("create hooked todo: %v", err)
+	}
+	select {
+	case got := <-deliveries:
+		var payload struct {
+			Type   string `json:"type"`
+			TodoID string `json:"todo_id"`
+		}
+		if err := json.Unmarshal(got.body, &payload); err != nil {
+			t.Fatalf("decode webhook payload: %v", err)
+		}
+		if got.event != "todo.create" || payload.Type != "todo.create" || payload.TodoID != hooked.GetTodo().GetId() {
+			t.Fatalf("unexpected webhook delivery: %s %s", got.event, got.body)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("webhook was not delivered")
+	}
+
+	// Задача удаляется окончательно раньше, чем диспетчер разошлёт её события.
+	if _, err := client.DeleteTodo(treeCtx, &gen.DeleteTodoRequest{Id: hooked.GetTodo().GetId()}); err != nil {
+		t.Fatalf("delete hooked todo: %v", err)
+	}
+	if _, err := client.PurgeTodo(treeCtx, &gen.PurgeTodoRequest{Id: hooked.GetTodo().GetId()}); err != nil {
+		t.Fatalf("purge hooked todo: %v", err)
+	}
+	purgeEvents := map[string]bool{}
+	for len(purgeEvents) < 2 {
+		select {
+		case got := <-deliveries:
+			purgeEvents[got.event] = true
+		case <-time.After(10 * time.Second):
+			t.Fatalf("expected events of a purged todo to be delivered, got %v", purgeEvents)
+		}
+	}
+	if !purgeEvents["todo.delete"] || !purgeEvents["todo.purge"] {
+		t.Fatalf("expected delete and purge deliveries, got %v", purgeEvents)
+	}
+
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		res, err := webhooks.ListDeliveries(treeCtx, &gen.ListDeliveriesRequest{WebhookId: deadHook.GetWebhook().GetId()})
+		if err != nil {
+			t.Fatalf("list deliveries: %v", err)
+		}
+		if len(res.GetDeliveries()) > 0 && res.GetDeliveries()[0].GetStatus() == gen.DeliveryStatus_DELIVERY_STATUS_DEAD {
+			if d := res.GetDeliveries()[0]; d.GetAttempts() != 2 || d.GetLastStatusCode() != http.StatusInternalServerError {
+				t.Fatalf("expected two failed attempts before dead-lettering, got %+v", d)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the failing delivery to be dead-lettered, got %+v", res.GetDeliveries())
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if _, err := webhooks.DeleteWebhook(treeCtx, &gen.DeleteWebhookRequest{Id: deadHook.GetWebhook().GetId()}); err != nil {
+		t.Fatalf("delete webhook: %v", err)
+	}
+	listed, err := webhooks.ListWebhooks(treeCtx, &gen.ListWebhooksRequest{})
+	if err != nil {
+		t.Fatalf("list webhooks: %v", err)
+	}
+	if len(listed.GetWebhooks()) != 1 || listed.GetWebhooks()[0].GetId() != hook.GetWebhook().GetId() {
+		t.Fatalf("expected only the working webhook to remain, got %+v", listed.GetWebhooks())
+	}
+
+	apiKeys := gen.NewApiKeyServiceClient(conn)
+	createdKey, err := apiKeys.CreateApiKey(treeCtx, &gen.CreateApiKeyRequest{
+		Name:  "ci",
+		Scope: gen.ApiKeyScope_API_KEY_SCOPE_READ_ONLY,
+	})
+	if err != nil {
+		t.Fatalf("create api key: %v", err)
+	}
+	if !strings.HasPrefix(createdKey.GetSecret(), createdKey.GetApiKey().GetPrefix()) {
+		t.Fatalf("expected the key prefix to identify the secret")
+	}
+	keyCtx := metadata.AppendToOutgoingContext(treeCtx, auth.APIKeyHeader, createdKey.GetSecret())
+	keyClient := gen.NewTodoServiceClient(anonymous)
+	if _, err := keyClient.GetTodo(keyCtx, &gen.GetTodoRequest{Id: discussed}); err != nil {
+		t.Fatalf("read with an api key: %v", err)
+	}
+	if _, err := keyClient.CreateTodo(keyCtx, &gen.CreateTodoRequest{Title: "From CI"}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied for a write with a read-only key, got %v", err)
+	}
+	if _, err := gen.NewApiKeyServiceClient(anonymous).ListApiKeys(keyCtx, &gen.ListApiKeysRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected api keys to be unable to manage api keys, got %v", err)
+	}
+	if _, err := apiKeys.RevokeApiKey(treeCtx, &gen.RevokeApiKeyRequest{Id: createdKey.GetApiKey().GetId()}); err != nil {
+		t.Fatalf("revoke api key: %v", err)
+	}
+	if _, err := keyClient.GetTodo(keyCtx, &gen.GetTodoRequest{Id: discussed}); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected Unauthenticated for a revoked key, got %v", err)
+	}
 
 	cancel()
 	if err := <-srvErr; err != nil && !errors.Is(err, context.Canceled) {
@@ -177,6 +1422,39 @@ func waitForServer(ctx context.Context, addr string) error {
 	return err
 }
 
+// jwtSecret подписывает токены клиента в интеграционном тесте.
+const jwtSecret = "integration-secret"
+
+// bearerCredentials передаёт bearer-токен в каждом вызове.
+type bearerCredentials string
+
+// bearerToken выпускает HS256-токен пользователя subject в арендаторе tenant.
+func bearerToken(t *testing.T, subject, tenant string) bearerCredentials {
+	t.Helper()
+	enc := base64.RawURLEncoding
+	claims, err := json.Marshal(map[string]any{
+		"sub":       subject,
+		"aud":       "todo",
+		"tenant_id": tenant,
+		"exp":       time.Now().Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		t.Fatalf("marshal claims: %v", err)
+	}
+	signed := enc.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`)) + "." + enc.EncodeToString(claims)
+	mac := hmac.New(sha256.New, []byte(jwtSecret))
+	mac.Write([]byte(signed))
+	return bearerCredentials(signed + "." + enc.EncodeToString(mac.Sum(nil)))
+}
+
+func (c bearerCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(c)}, nil
+}
+
+func (c bearerCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
 func randomAddr() string {
 	lis, err := net.Listen("tcp", "127.0.0.1:0")
 	if err != nil {
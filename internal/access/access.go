@@ -0,0 +1,182 @@
+// Package access содержит роли совместного доступа к задачам и проектам
+// и репозиторий выданных ролей.
+package access
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"todo/internal/auth"
+)
+
+var (
+	// ErrPermissionDenied возвращается, если роли вызывающего недостаточно.
+	ErrPermissionDenied = errors.New("permission denied")
+	// ErrTodoNotFound возвращается, если задача не существует или не видна вызывающему.
+	ErrTodoNotFound = errors.New("todo not found")
+	// ErrProjectNotFound возвращается, если проект не существует или не виден вызывающему.
+	ErrProjectNotFound = errors.New("project not found")
+	// ErrGrantNotFound возвращается, если у пользователя нет выданной роли.
+	ErrGrantNotFound = errors.New("collaborator not found")
+)
+
+// Role — роль пользователя; каждая следующая включает права предыдущих.
+type Role int
+
+// Поддерживаемые роли.
+const (
+	RoleNone Role = iota
+	// RoleViewer разрешает читать и комментировать.
+	RoleViewer
+	// RoleEditor разрешает изменять и удалять в корзину.
+	RoleEditor
+	// RoleOwner разрешает также делиться и удалять окончательно.
+	RoleOwner
+)
+
+// Valid сообщает, можно ли выдать роль.
+func (r Role) Valid() bool {
+	return r >= RoleViewer && r <= RoleOwner
+}
+
+// TodoVisible возвращает SQL-условие «задача alias видна пользователю»:
+// он её владелец, ему выдана роль на задачу либо на её проект, или он
+// владелец проекта. tenant и user — плейсхолдеры параметров.
+func TodoVisible(alias, tenant, user string) string {
+	return alias + `.tenant_id = ` + tenant + ` and (
+    ` + alias + `.owner_id = ` + user + `
+    or exists (select 1 from todo_grants g where g.todo_id = ` + alias + `.id and g.grantee_id = ` + user + `)
+    or exists (
+        select 1 from projects p
+        where p.id = ` + alias + `.project_id
+          and (p.owner_id = ` + user + ` or exists (
+              select 1 from project_grants pg where pg.project_id = p.id and pg.grantee_id = ` + user + `))
+    )
+)`
+}
+
+// TodoEditable возвращает SQL-условие «пользователь может изменять задачу
+// alias»: он владелец задачи или её проекта либо ему выдана роль не ниже
+// редактора на задачу или её проект. tenant и user — плейсхолдеры параметров.
+func TodoEditable(alias, tenant, user string) string {
+	editor := strconv.Itoa(int(RoleEditor))
+	return alias + `.tenant_id = ` + tenant + ` and (
+    ` + alias + `.owner_id = ` + user + `
+    or exists (select 1 from todo_grants g where g.todo_id = ` + alias + `.id and g.grantee_id = ` + user + ` and g.role >= ` + editor + `)
+    or exists (
+        select 1 from projects p
+        where p.id = ` + alias + `.project_id
+          and (p.owner_id = ` + user + ` or exists (
+              select 1 from project_grants pg
+              where pg.project_id = p.id and pg.grantee_id = ` + user + ` and pg.role >= ` + editor + `))
+    )
+)`
+}
+
+// ProjectVisible возвращает SQL-условие «проект alias виден пользователю»:
+// он владелец проекта или ему выдана роль на проект.
+func ProjectVisible(alias, tenant, user string) string {
+	return alias + `.tenant_id = ` + tenant + ` and (
+    ` + alias + `.owner_id = ` + user + `
+    or exists (select 1 from project_grants pg where pg.project_id = ` + alias + `.id and pg.grantee_id = ` + user + `)
+)`
+}
+
+// Grant — роль, выданная пользователю.
+type Grant struct {
+	UserID    string
+	Role      Role
+	CreatedAt time.Time
+}
+
+// Repository инкапсулирует доступ к выданным ролям.
+type Repository struct {
+	db *sql.DB
+}
+
+// NewRepository создает новый репозиторий ролей.
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// TodoRole возвращает роль вызывающего на задачу, в том числе находящуюся
+// в корзине: наибольшую из владения и ролей на задачу и её проект.
+// Для невидимой задачи возвращается ErrTodoNotFound.
+func (r *Repository) TodoRole(ctx context.Context, id string) (Role, error) {
+	principal, err := auth.Require(ctx)
+	if err != nil {
+		return RoleNone, err
+	}
+	query := `
+select greatest(
+    case when t.owner_id = $3 then 3 else 0 end,
+    (select coalesce(max(g.role), 0) from todo_grants g where g.todo_id = t.id and g.grantee_id = $3),
+    (select coalesce(max(case when p.owner_id = $3 then 3 else 0 end), 0) from projects p where p.id = t.project_id),
+    (select coalesce(max(pg.role), 0) from project_grants pg where pg.project_id = t.project_id and pg.grantee_id = $3)
+)
+from todos t
+where t.id = $1 and t.tenant_id = $2`
+
+	return r.role(ctx, query, ErrTodoNotFound, id, principal)
+}
+
+// ProjectRole возвращает роль вызывающего на проект. Для невидимого
+// проекта возвращается ErrProjectNotFound.
+func (r *Repository) ProjectRole(ctx context.Context, id string) (Role, error) {
+	principal, err := auth.Require(ctx)
+	if err != nil {
+		return RoleNone, err
+	}
+	query := `
+select greatest(
+    case when p.owner_id = $3 then 3 else 0 end,
+    (select coalesce(max(pg.role), 0) from project_grants pg where pg.project_id = p.id and pg.grantee_id = $3)
+)
+from projects p
+where p.id = $1 and p.tenant_id = $2`
+
+	return r.role(ctx, query, ErrProjectNotFound, id, principal)
+}
+
+func (r *Repository) role(ctx context.Context, query string, notFound error, id string, principal auth.Principal) (Role, error) {
+	var role Role
+	err := r.db.QueryRowContext(ctx, query, id, principal.TenantID, principal.Subject).Scan(&role)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return RoleNone, notFound
+		}
+		return RoleNone, err
+	}
+	if role == RoleNone {
+		// Не раскрываем существование чужих записей.
+		return RoleNone, notFound
+	}
+	return role, nil
+}
+
+// RequireTodo проверяет, что роль вызывающего на задачу не ниже min.
+func (r *Repository) RequireTodo(ctx context.Context, id string, min Role) error {
+	role, err := r.TodoRole(ctx, id)
+	if err != nil {
+		return err
+	}
+	if role < min {
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
+// RequireProject проверяет, что роль вызывающего на проект не ниже min.
+func (r *Repository) RequireProject(ctx context.Context, id string, min Role) error {
+	role, err := r.ProjectRole(ctx, id)
+	if err != nil {
+		return err
+	}
+	if role < min {
+		return ErrPermissionDenied
+	}
+	return nil
+}
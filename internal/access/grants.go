@@ -0,0 +1,129 @@
+package access
+
+import (
+	"context"
+	"time"
+)
+
+// grantTable описывает таблицу ролей одного вида записей.
+type grantTable struct {
+	table  string
+	column string
+	// parent — таблица самих записей с колонками owner_id и created_at.
+	parent string
+}
+
+var (
+	todoGrants    = grantTable{table: "todo_grants", column: "todo_id", parent: "todos"}
+	projectGrants = grantTable{table: "project_grants", column: "project_id", parent: "projects"}
+)
+
+// ShareTodo выдаёт пользователю роль на задачу или меняет выданную.
+func (r *Repository) ShareTodo(ctx context.Context, todoID, userID string, role Role) (Grant, error) {
+	return r.share(ctx, todoGrants, todoID, userID, role)
+}
+
+// UnshareTodo отзывает роль пользователя на задачу.
+func (r *Repository) UnshareTodo(ctx context.Context, todoID, userID string) error {
+	return r.unshare(ctx, todoGrants, todoID, userID)
+}
+
+// TodoCollaborators возвращает владельца задачи и пользователей с ролями на неё.
+func (r *Repository) TodoCollaborators(ctx context.Context, todoID string) ([]Grant, error) {
+	return r.collaborators(ctx, todoGrants, todoID)
+}
+
+// TodoOwner возвращает владельца задачи.
+func (r *Repository) TodoOwner(ctx context.Context, todoID string) (string, error) {
+	return r.owner(ctx, todoGrants, todoID)
+}
+
+// ShareProject выдаёт пользователю роль на проект или меняет выданную.
+func (r *Repository) ShareProject(ctx context.Context, projectID, userID string, role Role) (Grant, error) {
+	return r.share(ctx, projectGrants, projectID, userID, role)
+}
+
+// UnshareProject отзывает роль пользователя на проект.
+func (r *Repository) UnshareProject(ctx context.Context, projectID, userID string) error {
+	return r.unshare(ctx, projectGrants, projectID, userID)
+}
+
+// ProjectCollaborators возвращает владельца проекта и пользователей с ролями на него.
+func (r *Repository) ProjectCollaborators(ctx context.Context, projectID string) ([]Grant, error) {
+	return r.collaborators(ctx, projectGrants, projectID)
+}
+
+// ProjectOwner возвращает владельца проекта.
+func (r *Repository) ProjectOwner(ctx context.Context, projectID string) (string, error) {
+	return r.owner(ctx, projectGrants, projectID)
+}
+
+// Права вызывающего проверяет сервис; методы ниже только меняют данные.
+
+func (r *Repository) share(ctx context.Context, t grantTable, id, userID string, role Role) (Grant, error) {
+	query := `
+insert into ` + t.table + ` (` + t.column + `, grantee_id, role, created_at)
+values ($1, $2, $3, $4)
+on conflict (` + t.column + `, grantee_id) do update set role = excluded.role
+returning grantee_id, role, created_at`
+
+	var g Grant
+	err := r.db.QueryRowContext(ctx, query, id, userID, role, time.Now().UTC()).Scan(&g.UserID, &g.Role, &g.CreatedAt)
+	return g, err
+}
+
+func (r *Repository) unshare(ctx context.Context, t grantTable, id, userID string) error {
+	res, err := r.db.ExecContext(ctx,
+		`delete from `+t.table+` where `+t.column+` = $1 and grantee_id = $2`, id, userID)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrGrantNotFound
+	}
+	return nil
+}
+
+func (r *Repository) collaborators(ctx context.Context, t grantTable, id string) ([]Grant, error) {
+	// Владелец идёт первым, остальные — в порядке выдачи ролей.
+	query := `
+select user_id, role, created_at from (
+    select owner_id as user_id, 3 as role, created_at, 0 as rank from ` + t.parent + ` where id = $1
+    union all
+    select grantee_id, role, created_at, 1 from ` + t.table + ` where ` + t.column + ` = $1
+) c
+order by rank, created_at, user_id`
+
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var items []Grant
+	for rows.Next() {
+		var g Grant
+		if err := rows.Scan(&g.UserID, &g.Role, &g.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, g)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *Repository) owner(ctx context.Context, t grantTable, id string) (string, error) {
+	var owner string
+	if err := r.db.QueryRowContext(ctx, `select owner_id from `+t.parent+` where id = $1`, id).Scan(&owner); err != nil {
+		return "", err
+	}
+	return owner, nil
+}
@@ -0,0 +1,172 @@
+// Package apikey содержит репозиторий ключей API.
+package apikey
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"todo/internal/auth"
+)
+
+// ErrNotFound возвращается, если ключ не найден или не принадлежит вызывающему.
+var ErrNotFound = errors.New("api key not found")
+
+// lastUsedPrecision ограничивает частоту записи времени последнего
+// использования, чтобы каждый вызов не обновлял строку ключа.
+const lastUsedPrecision = time.Minute
+
+// Scope — права, которые даёт ключ.
+type Scope int
+
+// Поддерживаемые права ключей.
+const (
+	ScopeReadOnly Scope = iota + 1
+	ScopeReadWrite
+)
+
+// Valid сообщает, поддерживаются ли права.
+func (s Scope) Valid() bool {
+	return s == ScopeReadOnly || s == ScopeReadWrite
+}
+
+// Repository инкапсулирует доступ к таблице ключей API.
+type Repository struct {
+	db *sql.DB
+}
+
+// Record представляет ключ без секрета.
+type Record struct {
+	ID       string
+	TenantID string
+	OwnerID  string
+	Name     string
+	// Prefix — начало секрета, по которому владелец узнаёт ключ.
+	Prefix     string
+	Scope      Scope
+	CreatedAt  time.Time
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
+
+// CreateParams описывает новый ключ.
+type CreateParams struct {
+	Name   string
+	Prefix string
+	// SecretHash — SHA-256 секрета в hex.
+	SecretHash string
+	Scope      Scope
+	ExpiresAt  *time.Time
+}
+
+// recordColumns перечисляет колонки, из которых собирается Record.
+const recordColumns = `id, tenant_id, owner_id, name, prefix, scope, created_at, expires_at, last_used_at, revoked_at`
+
+func scanRecord(row interface{ Scan(dest ...any) error }) (Record, error) {
+	var rec Record
+	err := row.Scan(&rec.ID, &rec.TenantID, &rec.OwnerID, &rec.Name, &rec.Prefix, &rec.Scope, &rec.CreatedAt,
+		&rec.ExpiresAt, &rec.LastUsedAt, &rec.RevokedAt)
+	return rec, err
+}
+
+// NewRepository создает новый репозиторий ключей API.
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Create сохраняет ключ, принадлежащий вызывающему.
+func (r *Repository) Create(ctx context.Context, params CreateParams) (Record, error) {
+	principal, err := auth.Require(ctx)
+	if err != nil {
+		return Record{}, err
+	}
+	query := `
+insert into api_keys (tenant_id, owner_id, name, prefix, secret_hash, scope, created_at, expires_at)
+values ($1, $2, $3, $4, $5, $6, $7, $8)
+returning ` + recordColumns
+
+	return scanRecord(r.db.QueryRowContext(ctx, query, principal.TenantID, principal.Subject,
+		params.Name, params.Prefix, params.SecretHash, params.Scope, time.Now().UTC(), params.ExpiresAt))
+}
+
+// List возвращает ключи вызывающего, начиная с новых.
+func (r *Repository) List(ctx context.Context) ([]Record, error) {
+	principal, err := auth.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.db.QueryContext(ctx, `
+select `+recordColumns+`
+from api_keys
+where tenant_id = $1 and owner_id = $2
+order by created_at desc, id desc`, principal.TenantID, principal.Subject)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var items []Record
+	for rows.Next() {
+		rec, err := scanRecord(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, rec)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// Revoke отзывает ключ вызывающего. Повторный отзыв сохраняет исходное время.
+func (r *Repository) Revoke(ctx context.Context, id string) (Record, error) {
+	principal, err := auth.Require(ctx)
+	if err != nil {
+		return Record{}, err
+	}
+	query := `
+update api_keys
+set revoked_at = coalesce(revoked_at, $4)
+where id = $1 and tenant_id = $2 and owner_id = $3
+returning ` + recordColumns
+
+	rec, err := scanRecord(r.db.QueryRowContext(ctx, query, id, principal.TenantID, principal.Subject, time.Now().UTC()))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Record{}, ErrNotFound
+		}
+		return Record{}, err
+	}
+	return rec, nil
+}
+
+// Use находит действующий ключ по хешу секрета среди всех арендаторов
+// и отмечает его использование.
+func (r *Repository) Use(ctx context.Context, secretHash string) (Record, error) {
+	now := time.Now().UTC()
+	query := `
+select ` + recordColumns + `
+from api_keys
+where secret_hash = $1 and revoked_at is null and (expires_at is null or expires_at > $2)`
+
+	rec, err := scanRecord(r.db.QueryRowContext(ctx, query, secretHash, now))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Record{}, ErrNotFound
+		}
+		return Record{}, err
+	}
+	if rec.LastUsedAt == nil || now.Sub(*rec.LastUsedAt) >= lastUsedPrecision {
+		if _, err := r.db.ExecContext(ctx,
+			`update api_keys set last_used_at = $2 where id = $1`, rec.ID, now); err != nil {
+			return Record{}, err
+		}
+		rec.LastUsedAt = &now
+	}
+	return rec, nil
+}
@@ -0,0 +1,238 @@
+// Package attachment содержит репозиторий метаданных вложений задач.
+package attachment
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"todo/internal/access"
+	"todo/internal/auth"
+)
+
+var (
+	// ErrNotFound возвращается, если вложение не найдено.
+	ErrNotFound = errors.New("attachment not found")
+	// ErrTodoNotFound возвращается, если задача не найдена или находится в корзине.
+	ErrTodoNotFound = errors.New("todo not found")
+)
+
+// Repository инкапсулирует доступ к таблицам вложений и blob-ов.
+type Repository struct {
+	db *sql.DB
+}
+
+// Record представляет метаданные вложения.
+type Record struct {
+	ID          string
+	TodoID      string
+	Filename    string
+	ContentType string
+	Size        int64
+	// SHA256 — хеш содержимого и ключ в blob-хранилище.
+	SHA256    string
+	CreatedAt time.Time
+}
+
+// CreateParams описывает новое вложение, содержимое которого уже сохранено.
+type CreateParams struct {
+	TodoID      string
+	Filename    string
+	ContentType string
+	Size        int64
+	SHA256      string
+}
+
+// recordColumns перечисляет колонки, из которых собирается Record.
+const recordColumns = `id, todo_id, filename, content_type, size, sha256, created_at`
+
+func scanRecord(row interface{ Scan(dest ...any) error }) (Record, error) {
+	var rec Record
+	err := row.Scan(&rec.ID, &rec.TodoID, &rec.Filename, &rec.ContentType, &rec.Size, &rec.SHA256, &rec.CreatedAt)
+	return rec, err
+}
+
+// activeTodoCondition отбирает вложения задач вне корзины, видимых
+// пользователю user арендатора tenant; оба аргумента — плейсхолдеры параметров.
+func activeTodoCondition(tenant, user string) string {
+	return `exists (
+    select 1 from todos t
+    where t.id = attachments.todo_id and t.deleted_at is null and ` + access.TodoVisible("t", tenant, user) + `
+)`
+}
+
+// NewRepository создает новый репозиторий вложений.
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// UseBlob учитывает blob и отмечает его использованным. Вызывается до того,
+// как содержимое окажется в хранилище или будет найдено там: если очистка
+// уже удаляет этот blob, запрос дождётся её, и содержимое будет сохранено
+// заново. Если задача исчезнет, содержимое станет сиротой и будет удалено
+// очисткой, а недавно использованное она не тронет.
+func (r *Repository) UseBlob(ctx context.Context, hash string, size int64) error {
+	_, err := r.db.ExecContext(ctx, `
+insert into blobs (sha256, size, created_at, last_used_at)
+values ($1, $2, $3, $3)
+on conflict (sha256) do update set last_used_at = excluded.last_used_at`, hash, size, time.Now().UTC())
+	return err
+}
+
+// Create регистрирует вложение; его blob уже учтён UseBlob.
+func (r *Repository) Create(ctx context.Context, params CreateParams) (Record, error) {
+	principal, err := auth.Require(ctx)
+	if err != nil {
+		return Record{}, err
+	}
+	now := time.Now().UTC()
+	query := `
+insert into attachments (todo_id, filename, content_type, size, sha256, created_at)
+select id, $2, $3, $4, $5, $6 from todos t
+where id = $1 and deleted_at is null and ` + access.TodoVisible("t", "$7", "$8") + `
+returning ` + recordColumns
+
+	rec, err := scanRecord(r.db.QueryRowContext(ctx, query,
+		params.TodoID, params.Filename, params.ContentType, params.Size, params.SHA256, now,
+		principal.TenantID, principal.Subject))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Record{}, ErrTodoNotFound
+		}
+		return Record{}, err
+	}
+	return rec, nil
+}
+
+// Get возвращает вложение задачи вне корзины.
+func (r *Repository) Get(ctx context.Context, id string) (Record, error) {
+	principal, err := auth.Require(ctx)
+	if err != nil {
+		return Record{}, err
+	}
+	query := `
+select ` + recordColumns + `
+from attachments
+where id = $1 and ` + activeTodoCondition("$2", "$3")
+
+	rec, err := scanRecord(r.db.QueryRowContext(ctx, query, id, principal.TenantID, principal.Subject))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Record{}, ErrNotFound
+		}
+		return Record{}, err
+	}
+	return rec, nil
+}
+
+// List возвращает вложения задачи в порядке загрузки.
+func (r *Repository) List(ctx context.Context, todoID string) ([]Record, error) {
+	principal, err := auth.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var exists bool
+	err = r.db.QueryRowContext(ctx,
+		`select exists(select 1 from todos t where id = $1 and deleted_at is null and `+
+			access.TodoVisible("t", "$2", "$3")+`)`,
+		todoID, principal.TenantID, principal.Subject).Scan(&exists)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrTodoNotFound
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+select `+recordColumns+`
+from attachments
+where todo_id = $1
+order by created_at, id`, todoID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var items []Record
+	for rows.Next() {
+		rec, err := scanRecord(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, rec)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// Delete удаляет вложение задачи вне корзины. Содержимое остаётся
+// до очистки сирот.
+func (r *Repository) Delete(ctx context.Context, id string) error {
+	principal, err := auth.Require(ctx)
+	if err != nil {
+		return err
+	}
+	res, err := r.db.ExecContext(ctx, `delete from attachments where id = $1 and `+activeTodoCondition("$2", "$3"),
+		id, principal.TenantID, principal.Subject)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// DeleteOrphanBlobs удаляет учёт blob-ов всех арендаторов, на которые не ссылается ни одно
+// вложение и которые не использовались с момента unusedSince, и для каждого
+// вызывает remove, чтобы удалить содержимое из хранилища. Учёт удаляется
+// в транзакции, которая фиксируется после remove: UseBlob для того же хеша
+// ждёт её и не застанет содержимое, которое вот-вот будет удалено.
+// Возвращает число удалённых blob-ов.
+func (r *Repository) DeleteOrphanBlobs(ctx context.Context, unusedSince time.Time, remove func(hash string)) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	rows, err := tx.QueryContext(ctx, `
+delete from blobs b
+where b.last_used_at < $1
+  and not exists (select 1 from attachments a where a.sha256 = b.sha256)
+returning b.sha256`, unusedSince)
+	if err != nil {
+		return 0, err
+	}
+	var hashes []string
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			_ = rows.Close()
+			return 0, err
+		}
+		hashes = append(hashes, hash)
+	}
+	if err := rows.Close(); err != nil {
+		return 0, err
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for _, hash := range hashes {
+		remove(hash)
+	}
+	return len(hashes), tx.Commit()
+}
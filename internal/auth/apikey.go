@@ -0,0 +1,40 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// APIKeyHeader — метаданные, в которых передаётся ключ API.
+const APIKeyHeader = "x-api-key"
+
+// APIKeyVerifier находит владельца ключа API по его секрету.
+type APIKeyVerifier interface {
+	// VerifyAPIKey возвращает ошибку, оборачивающую ErrUnauthenticated,
+	// если ключ неизвестен, отозван или истёк.
+	VerifyAPIKey(ctx context.Context, secret string) (Principal, error)
+}
+
+// WithAPIKeys возвращает Authenticator, который принимает ключи API
+// из метаданных x-api-key, а вызовы без них передаёт next.
+func WithAPIKeys(keys APIKeyVerifier, next Authenticator) Authenticator {
+	return &apiKeyAuthenticator{keys: keys, next: next}
+}
+
+type apiKeyAuthenticator struct {
+	keys APIKeyVerifier
+	next Authenticator
+}
+
+func (a *apiKeyAuthenticator) Authenticate(ctx context.Context, md metadata.MD) (Principal, error) {
+	values := md.Get(APIKeyHeader)
+	if len(values) == 0 {
+		return a.next.Authenticate(ctx, md)
+	}
+	if len(values) > 1 || len(md.Get("authorization")) > 0 {
+		return Principal{}, fmt.Errorf("%w: exactly one credential is allowed", ErrUnauthenticated)
+	}
+	return a.keys.VerifyAPIKey(ctx, values[0])
+}
@@ -0,0 +1,62 @@
+// Package auth описывает вызывающего и передаёт его через контекст запроса.
+package auth
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrUnauthenticated возвращается, если в контексте нет вызывающего.
+var ErrUnauthenticated = errors.New("unauthenticated")
+
+// DefaultTenant — арендатор, которому принадлежат данные, созданные до
+// появления арендаторов, и вызовы без аутентификации.
+const DefaultTenant = "default"
+
+// Principal — аутентифицированный вызывающий.
+type Principal struct {
+	// Subject идентифицирует пользователя и становится владельцем созданных им записей.
+	Subject string
+	// TenantID ограничивает видимые вызывающему данные.
+	TenantID string
+	// ReadOnly разрешает только методы чтения.
+	ReadOnly bool
+	// APIKeyID задан, если вызывающий предъявил ключ API.
+	APIKeyID string
+}
+
+// DefaultPrincipal представляет вызывающего без аутентификации.
+var DefaultPrincipal = Principal{Subject: DefaultTenant, TenantID: DefaultTenant}
+
+type principalKey struct{}
+
+// NewContext возвращает контекст с вызывающим p.
+func NewContext(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext возвращает вызывающего из контекста.
+func FromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
+
+// Require возвращает вызывающего из контекста или ErrUnauthenticated,
+// если его нет. Репозитории используют его, чтобы не выполнять запросы
+// без привязки к арендатору.
+func Require(ctx context.Context) (Principal, error) {
+	p, ok := FromContext(ctx)
+	if !ok || p.TenantID == "" {
+		return Principal{}, ErrUnauthenticated
+	}
+	return p, nil
+}
+
+// TenantID возвращает арендатора вызывающего из контекста.
+func TenantID(ctx context.Context) (string, error) {
+	p, err := Require(ctx)
+	if err != nil {
+		return "", err
+	}
+	return p.TenantID, nil
+}
@@ -0,0 +1,115 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Authenticator определяет вызывающего по метаданным запроса.
+type Authenticator interface {
+	// Authenticate возвращает ошибку, оборачивающую ErrUnauthenticated,
+	// если учётные данные отсутствуют или недействительны.
+	Authenticate(ctx context.Context, md metadata.MD) (Principal, error)
+}
+
+// Static возвращает Authenticator, который считает любой вызов вызовом p.
+func Static(p Principal) Authenticator {
+	return staticAuthenticator(p)
+}
+
+type staticAuthenticator Principal
+
+func (a staticAuthenticator) Authenticate(context.Context, metadata.MD) (Principal, error) {
+	return Principal(a), nil
+}
+
+// ExemptServices перечисляет служебные сервисы, доступные без аутентификации.
+var ExemptServices = []string{
+	"grpc.health.v1.Health",
+	"grpc.reflection.v1.ServerReflection",
+	"grpc.reflection.v1alpha.ServerReflection",
+}
+
+// UnaryInterceptor аутентифицирует unary-вызовы и кладёт вызывающего в контекст.
+func UnaryInterceptor(a Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if exempt(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		ctx, err := authenticate(ctx, a, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor аутентифицирует потоковые вызовы и кладёт вызывающего в контекст.
+func StreamInterceptor(a Authenticator) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if exempt(info.FullMethod) {
+			return handler(srv, ss)
+		}
+		ctx, err := authenticate(ss.Context(), a, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &principalStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func authenticate(ctx context.Context, a Authenticator, fullMethod string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	p, err := a.Authenticate(ctx, md)
+	if err != nil {
+		if errors.Is(err, ErrUnauthenticated) {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "authenticate: %v", err)
+	}
+	if p.ReadOnly && !ReadMethod(fullMethod) {
+		return nil, status.Errorf(codes.PermissionDenied, "read-only credentials cannot call %s", fullMethod)
+	}
+	return NewContext(ctx, p), nil
+}
+
+// readMethodPrefixes — префиксы имён методов, которые не изменяют данные.
+var readMethodPrefixes = []string{"Get", "List", "Download", "Watch", "Export"}
+
+// ReadMethod сообщает, только ли читает данные метод вида /package.Service/Method.
+func ReadMethod(fullMethod string) bool {
+	_, method, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	for _, prefix := range readMethodPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// exempt сообщает, относится ли метод вида /package.Service/Method к служебным сервисам.
+func exempt(fullMethod string) bool {
+	service, _, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	for _, s := range ExemptServices {
+		if service == s {
+			return true
+		}
+	}
+	return false
+}
+
+// principalStream подменяет контекст потока контекстом с вызывающим.
+type principalStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *principalStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"todo/internal/jwt"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// JWTAuthenticator принимает bearer-токены JWT из метаданных authorization.
+type JWTAuthenticator struct {
+	verifier    *jwt.Verifier
+	tenantClaim string
+}
+
+// NewJWTAuthenticator создаёт Authenticator для JWT. Арендатор берётся
+// из утверждения tenantClaim, пользователь — из sub.
+func NewJWTAuthenticator(verifier *jwt.Verifier, tenantClaim string) *JWTAuthenticator {
+	return &JWTAuthenticator{verifier: verifier, tenantClaim: tenantClaim}
+}
+
+// Authenticate проверяет токен из заголовка authorization.
+func (a *JWTAuthenticator) Authenticate(_ context.Context, md metadata.MD) (Principal, error) {
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return Principal{}, fmt.Errorf("%w: bearer token is required", ErrUnauthenticated)
+	}
+	scheme, token, ok := strings.Cut(values[0], " ")
+	if !ok || !strings.EqualFold(scheme, "bearer") || token == "" {
+		return Principal{}, fmt.Errorf("%w: authorization must be a bearer token", ErrUnauthenticated)
+	}
+	claims, err := a.verifier.Verify(strings.TrimSpace(token), time.Now())
+	if err != nil {
+		return Principal{}, fmt.Errorf("%w: %v", ErrUnauthenticated, err)
+	}
+	tenant := claims.String(a.tenantClaim)
+	if tenant == "" {
+		return Principal{}, fmt.Errorf("%w: token has no %s claim", ErrUnauthenticated, a.tenantClaim)
+	}
+	return Principal{Subject: claims.Subject, TenantID: tenant}, nil
+}
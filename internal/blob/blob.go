@@ -0,0 +1,40 @@
+// Package blob описывает хранилище содержимого вложений, адресуемое хешем.
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"regexp"
+)
+
+// ErrNotFound возвращается, если содержимого с таким хешем нет.
+var ErrNotFound = errors.New("blob not found")
+
+// Info описывает сохранённое содержимое.
+type Info struct {
+	// Hash — SHA-256 содержимого в hex; служит ключом в хранилище.
+	Hash string
+	Size int64
+}
+
+// Store хранит содержимое по его SHA-256. Одинаковое содержимое хранится
+// один раз, поэтому удалять его можно, только когда на хеш никто не ссылается.
+type Store interface {
+	// Put сохраняет всё содержимое r. Ошибка чтения r возвращается обёрнутой,
+	// и в хранилище ничего не остаётся. Если reserve задана, она вызывается
+	// с хешем содержимого до того, как оно окажется на месте или будет найдено
+	// уже сохранённым; её ошибка прерывает Put.
+	Put(ctx context.Context, r io.Reader, reserve func(Info) error) (Info, error)
+	// Open открывает содержимое на чтение.
+	Open(ctx context.Context, hash string) (io.ReadCloser, error)
+	// Delete удаляет содержимое; отсутствие содержимого ошибкой не считается.
+	Delete(ctx context.Context, hash string) error
+}
+
+var hashPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// ValidHash сообщает, похожа ли строка на SHA-256 в hex.
+func ValidHash(hash string) bool {
+	return hashPattern.MatchString(hash)
+}
@@ -0,0 +1,101 @@
+package blob
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// FSStore хранит содержимое в файлах локальной файловой системы:
+// root/ab/cd/<hash>. Незавершённые загрузки пишутся во временные файлы
+// в root и переименовываются только целиком.
+type FSStore struct {
+	root string
+}
+
+var _ Store = (*FSStore)(nil)
+
+// NewFSStore создаёт хранилище в каталоге root, создавая его при необходимости.
+func NewFSStore(root string) (*FSStore, error) {
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, fmt.Errorf("create blob root: %w", err)
+	}
+	return &FSStore{root: root}, nil
+}
+
+// Put сохраняет содержимое r и возвращает его хеш и размер.
+func (s *FSStore) Put(_ context.Context, r io.Reader, reserve func(Info) error) (Info, error) {
+	tmp, err := os.CreateTemp(s.root, ".upload-*")
+	if err != nil {
+		return Info{}, err
+	}
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+
+	h := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, h), r)
+	if err != nil {
+		return Info{}, fmt.Errorf("write blob: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		return Info{}, err
+	}
+	if err := tmp.Close(); err != nil {
+		return Info{}, err
+	}
+
+	info := Info{Hash: hex.EncodeToString(h.Sum(nil)), Size: size}
+	if reserve != nil {
+		if err := reserve(info); err != nil {
+			return Info{}, err
+		}
+	}
+	path := s.path(info.Hash)
+	if _, err := os.Stat(path); err == nil {
+		return info, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return Info{}, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return Info{}, err
+	}
+	return info, nil
+}
+
+// Open открывает содержимое по хешу.
+func (s *FSStore) Open(_ context.Context, hash string) (io.ReadCloser, error) {
+	if !ValidHash(hash) {
+		return nil, ErrNotFound
+	}
+	f, err := os.Open(s.path(hash))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return f, nil
+}
+
+// Delete удаляет содержимое по хешу.
+func (s *FSStore) Delete(_ context.Context, hash string) error {
+	if !ValidHash(hash) {
+		return nil
+	}
+	if err := os.Remove(s.path(hash)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *FSStore) path(hash string) string {
+	return filepath.Join(s.root, hash[:2], hash[2:4], hash)
+}
@@ -0,0 +1,111 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestFSStoreDeduplicates(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewFSStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := store.Put(ctx, strings.NewReader("hello"), nil)
+	if err != nil {
+		t.Fatalf("put: %v", err)
+	}
+	second, err := store.Put(ctx, strings.NewReader("hello"), nil)
+	if err != nil {
+		t.Fatalf("put again: %v", err)
+	}
+	if first != second || first.Size != 5 {
+		t.Fatalf("expected identical info for identical content, got %+v and %+v", first, second)
+	}
+
+	rc, err := store.Open(ctx, first.Hash)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	data, err := io.ReadAll(rc)
+	_ = rc.Close()
+	if err != nil || string(data) != "hello" {
+		t.Fatalf("read back %q, %v", data, err)
+	}
+
+	if err := store.Delete(ctx, first.Hash); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, err := store.Open(ctx, first.Hash); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound after delete, got %v", err)
+	}
+	if err := store.Delete(ctx, first.Hash); err != nil {
+		t.Fatalf("delete missing blob: %v", err)
+	}
+}
+
+func TestFSStoreDiscardsFailedUpload(t *testing.T) {
+	root := t.TempDir()
+	store, err := NewFSStore(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	errBroken := errors.New("broken stream")
+	r := io.MultiReader(strings.NewReader("partial"), &failingReader{err: errBroken})
+	if _, err := store.Put(context.Background(), r, nil); !errors.Is(err, errBroken) {
+		t.Fatalf("expected the read error, got %v", err)
+	}
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected no leftovers, found %d entries", len(entries))
+	}
+}
+
+func TestFSStoreStopsWhenReserveFails(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewFSStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	errReserve := errors.New("reserve failed")
+	var reserved Info
+	_, err = store.Put(ctx, strings.NewReader("hello"), func(info Info) error {
+		reserved = info
+		return errReserve
+	})
+	if !errors.Is(err, errReserve) {
+		t.Fatalf("expected the reserve error, got %v", err)
+	}
+	if reserved.Size != 5 || !ValidHash(reserved.Hash) {
+		t.Fatalf("expected reserve to get the content hash, got %+v", reserved)
+	}
+	if _, err := store.Open(ctx, reserved.Hash); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected nothing stored after a failed reserve, got %v", err)
+	}
+}
+
+func TestFSStoreRejectsMalformedHash(t *testing.T) {
+	store, err := NewFSStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Open(context.Background(), "../../etc/passwd"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+type failingReader struct {
+	err error
+}
+
+func (r *failingReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
@@ -0,0 +1,207 @@
+// Package comment содержит репозиторий для работы с комментариями к задачам.
+package comment
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"todo/internal/access"
+	"todo/internal/auth"
+)
+
+var (
+	// ErrNotFound возвращается, если комментарий не найден.
+	ErrNotFound = errors.New("comment not found")
+	// ErrTodoNotFound возвращается, если задача не найдена или находится в корзине.
+	ErrTodoNotFound = errors.New("todo not found")
+)
+
+// Repository инкапсулирует доступ к таблице комментариев.
+type Repository struct {
+	db *sql.DB
+}
+
+// Record представляет запись комментария.
+type Record struct {
+	ID        string
+	TodoID    string
+	Author    string
+	Body      string
+	CreatedAt time.Time
+	// EditedAt задан, если комментарий редактировали.
+	EditedAt *time.Time
+}
+
+// Cursor указывает на последний выданный комментарий для keyset-пагинации.
+type Cursor struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        string    `json:"id"`
+}
+
+// recordColumns перечисляет колонки, из которых собирается Record.
+const recordColumns = `id, todo_id, author, body, created_at, edited_at`
+
+func scanRecord(row interface{ Scan(dest ...any) error }) (Record, error) {
+	var rec Record
+	err := row.Scan(&rec.ID, &rec.TodoID, &rec.Author, &rec.Body, &rec.CreatedAt, &rec.EditedAt)
+	return rec, err
+}
+
+// visibleCondition отбирает комментарии к задачам, видимым пользователю
+// user арендатора tenant; оба аргумента — плейсхолдеры параметров.
+func visibleCondition(tenant, user string) string {
+	return `exists (select 1 from todos t where t.id = comments.todo_id and ` + access.TodoVisible("t", tenant, user) + `)`
+}
+
+// NewRepository создает новый репозиторий комментариев.
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Create добавляет комментарий к задаче вне корзины.
+func (r *Repository) Create(ctx context.Context, todoID, author, body string) (Record, error) {
+	principal, err := auth.Require(ctx)
+	if err != nil {
+		return Record{}, err
+	}
+	query := `
+insert into comments (todo_id, author, body, created_at)
+select id, $2, $3, $4 from todos t where id = $1 and deleted_at is null and ` + access.TodoVisible("t", "$5", "$6") + `
+returning ` + recordColumns
+
+	rec, err := scanRecord(r.db.QueryRowContext(ctx, query,
+		todoID, author, body, time.Now().UTC(), principal.TenantID, principal.Subject))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Record{}, ErrTodoNotFound
+		}
+		return Record{}, err
+	}
+	return rec, nil
+}
+
+// List возвращает комментарии задачи от старых к новым. Если after задан,
+// возвращаются комментарии строго после курсора; limit 0 означает без ограничения.
+func (r *Repository) List(ctx context.Context, todoID string, limit int, after *Cursor) ([]Record, error) {
+	principal, err := auth.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var exists bool
+	err = r.db.QueryRowContext(ctx,
+		`select exists(select 1 from todos t where id = $1 and deleted_at is null and `+
+			access.TodoVisible("t", "$2", "$3")+`)`,
+		todoID, principal.TenantID, principal.Subject).Scan(&exists)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrTodoNotFound
+	}
+
+	query := `
+select ` + recordColumns + `
+from comments
+where todo_id = $1 and deleted_at is null`
+	args := []any{todoID}
+	if after != nil {
+		query += ` and (created_at, id) > ($2, $3)`
+		args = append(args, after.CreatedAt, after.ID)
+	}
+	query += `
+order by created_at, id`
+	if limit > 0 {
+		args = append(args, limit)
+		query += ` limit $` + strconv.Itoa(len(args))
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var items []Record
+	for rows.Next() {
+		rec, err := scanRecord(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, rec)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// Get возвращает комментарий к видимой вызывающему задаче.
+func (r *Repository) Get(ctx context.Context, id string) (Record, error) {
+	principal, err := auth.Require(ctx)
+	if err != nil {
+		return Record{}, err
+	}
+	query := `
+select ` + recordColumns + `
+from comments
+where id = $1 and deleted_at is null and ` + visibleCondition("$2", "$3")
+
+	rec, err := scanRecord(r.db.QueryRowContext(ctx, query, id, principal.TenantID, principal.Subject))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Record{}, ErrNotFound
+		}
+		return Record{}, err
+	}
+	return rec, nil
+}
+
+// Update заменяет текст комментария и отмечает время правки.
+func (r *Repository) Update(ctx context.Context, id, body string) (Record, error) {
+	principal, err := auth.Require(ctx)
+	if err != nil {
+		return Record{}, err
+	}
+	query := `
+update comments
+set body = $2, edited_at = $3
+where id = $1 and deleted_at is null and ` + visibleCondition("$4", "$5") + `
+returning ` + recordColumns
+
+	rec, err := scanRecord(r.db.QueryRowContext(ctx, query,
+		id, body, time.Now().UTC(), principal.TenantID, principal.Subject))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Record{}, ErrNotFound
+		}
+		return Record{}, err
+	}
+	return rec, nil
+}
+
+// Delete удаляет комментарий. Комментарии задач из корзины не удаляются.
+func (r *Repository) Delete(ctx context.Context, id string) error {
+	principal, err := auth.Require(ctx)
+	if err != nil {
+		return err
+	}
+	res, err := r.db.ExecContext(ctx,
+		`delete from comments where id = $1 and deleted_at is null and `+visibleCondition("$2", "$3"),
+		id, principal.TenantID, principal.Subject)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
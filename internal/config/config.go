@@ -3,26 +3,238 @@ package config
 
 import (
 	"fmt"
+	"net/netip"
 	"os"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
 // Config описывает конфигурацию сервиса.
 type Config struct {
-	GRPCAddr    string `yaml:"grpc_addr"`
-	PostgresDSN string `yaml:"postgres_dsn"`
+	GRPCAddr    string            `yaml:"grpc_addr"`
+	PostgresDSN string            `yaml:"postgres_dsn"`
+	Trash       TrashConfig       `yaml:"trash"`
+	Subtasks    SubtasksConfig    `yaml:"subtasks"`
+	Batch       BatchConfig       `yaml:"batch"`
+	Attachments AttachmentsConfig `yaml:"attachments"`
+	Auth        AuthConfig        `yaml:"auth"`
+	Webhooks    WebhooksConfig    `yaml:"webhooks"`
+	Idempotency IdempotencyConfig `yaml:"idempotency"`
+	Watch       WatchConfig       `yaml:"watch"`
 }
 
-// Load читает YAML-конфигурацию по указанному пути.
+// TrashConfig описывает хранение задач в корзине.
+type TrashConfig struct {
+	// Retention — сколько задача хранится в корзине до окончательного удаления.
+	Retention time.Duration `yaml:"retention"`
+	// PurgeInterval — как часто запускается очистка корзины.
+	PurgeInterval time.Duration `yaml:"purge_interval"`
+}
+
+// Политики завершения задачи с открытыми подзадачами.
+const (
+	// CompletionCascade завершает подзадачи вместе с родительской задачей.
+	CompletionCascade = "cascade"
+	// CompletionRefuse запрещает завершать задачу с открытыми подзадачами.
+	CompletionRefuse = "refuse"
+)
+
+// SubtasksConfig описывает правила иерархии задач.
+type SubtasksConfig struct {
+	// MaxDepth — максимальная вложенность; задача верхнего уровня имеет глубину 1.
+	MaxDepth int `yaml:"max_depth"`
+	// CompletionPolicy — cascade или refuse.
+	CompletionPolicy string `yaml:"completion_policy"`
+}
+
+// BatchConfig описывает пакетные операции над задачами.
+type BatchConfig struct {
+	// MaxSize — максимальное число элементов в одном пакете.
+	MaxSize int `yaml:"max_size"`
+}
+
+// AttachmentsConfig описывает хранение вложений задач.
+type AttachmentsConfig struct {
+	// Root — каталог локального хранилища содержимого вложений.
+	Root string `yaml:"root"`
+	// MaxSize — максимальный размер вложения в байтах.
+	MaxSize int64 `yaml:"max_size"`
+	// AllowedTypes перечисляет допустимые MIME-типы; "image/*" разрешает все подтипы.
+	AllowedTypes []string `yaml:"allowed_types"`
+}
+
+// AuthConfig описывает проверку JWT. Нужен хотя бы один источник ключей:
+// общий секрет HS256 или файл JWKS с ключами RS256/ES256.
+type AuthConfig struct {
+	// Disabled отключает аутентификацию: все вызовы выполняются от имени
+	// арендатора по умолчанию. Только для локальной разработки.
+	Disabled bool `yaml:"disabled"`
+	// Issuer и Audience, если заданы, сверяются с iss и aud токена.
+	Issuer   string `yaml:"issuer"`
+	Audience string `yaml:"audience"`
+	// HMACSecret — общий секрет для HS256.
+	HMACSecret string `yaml:"hmac_secret"`
+	// JWKSFile — путь к локальному файлу JWKS.
+	JWKSFile string `yaml:"jwks_file"`
+	// TenantClaim — утверждение токена с идентификатором арендатора.
+	TenantClaim string `yaml:"tenant_claim"`
+	// Leeway — допустимое расхождение часов при проверке сроков токена.
+	Leeway time.Duration `yaml:"leeway"`
+}
+
+// WebhooksConfig описывает доставку событий на вебхуки.
+type WebhooksConfig struct {
+	// PollInterval — как часто проверяются новые события и повторы доставок.
+	PollInterval time.Duration `yaml:"poll_interval"`
+	// Timeout ограничивает одну попытку доставки.
+	Timeout time.Duration `yaml:"timeout"`
+	// MaxAttempts — после стольких неудачных попыток событие не доставляется.
+	MaxAttempts int `yaml:"max_attempts"`
+	// BackoffBase — пауза после первой неудачи; дальше она удваивается до BackoffMax.
+	BackoffBase time.Duration `yaml:"backoff_base"`
+	BackoffMax  time.Duration `yaml:"backoff_max"`
+	// AllowedNetworks перечисляет сети в нотации CIDR, куда разрешена
+	// доставка, хотя они внутренние: loopback, link-local или частные.
+	AllowedNetworks []netip.Prefix `yaml:"allowed_networks"`
+}
+
+// IdempotencyConfig описывает хранение ключей идемпотентности.
+type IdempotencyConfig struct {
+	// TTL — сколько хранится ответ на вызов с ключом идемпотентности.
+	TTL time.Duration `yaml:"ttl"`
+	// Lease — на сколько ключ занимается выполняющимся вызовом без срока
+	// или со сроком короче; затем ключ можно занять снова.
+	Lease time.Duration `yaml:"lease"`
+	// PurgeInterval — как часто удаляются истёкшие ключи.
+	PurgeInterval time.Duration `yaml:"purge_interval"`
+}
+
+// WatchConfig описывает подписки на изменения задач.
+type WatchConfig struct {
+	// PollInterval — как часто подписка перечитывает журнал, если сигнал
+	// о новых событиях потерялся.
+	PollInterval time.Duration `yaml:"poll_interval"`
+}
+
+// Default возвращает конфигурацию со значениями по умолчанию.
+func Default() Config {
+	return Config{
+		Trash: TrashConfig{
+			Retention:     30 * 24 * time.Hour,
+			PurgeInterval: time.Hour,
+		},
+		Subtasks: SubtasksConfig{
+			MaxDepth:         5,
+			CompletionPolicy: CompletionCascade,
+		},
+		Batch: BatchConfig{
+			MaxSize: 1000,
+		},
+		Attachments: AttachmentsConfig{
+			Root:         "data/attachments",
+			MaxSize:      10 << 20,
+			AllowedTypes: []string{"image/*", "application/pdf", "text/plain"},
+		},
+		Auth: AuthConfig{
+			TenantClaim: "tenant_id",
+			Leeway:      30 * time.Second,
+		},
+		Webhooks: WebhooksConfig{
+			PollInterval: 5 * time.Second,
+			Timeout:      10 * time.Second,
+			MaxAttempts:  10,
+			BackoffBase:  10 * time.Second,
+			BackoffMax:   time.Hour,
+		},
+		Idempotency: IdempotencyConfig{
+			TTL:           24 * time.Hour,
+			Lease:         time.Minute,
+			PurgeInterval: time.Hour,
+		},
+		Watch: WatchConfig{
+			PollInterval: 30 * time.Second,
+		},
+	}
+}
+
+// Load читает YAML-конфигурацию по указанному пути поверх значений по умолчанию.
 func Load(path string) (Config, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return Config{}, fmt.Errorf("read config: %w", err)
 	}
-	var cfg Config
+	cfg := Default()
 	if err := yaml.Unmarshal(data, &cfg); err != nil {
 		return Config{}, fmt.Errorf("parse config: %w", err)
 	}
+	if err := cfg.validate(); err != nil {
+		return Config{}, fmt.Errorf("validate config: %w", err)
+	}
 	return cfg, nil
 }
+
+func (c Config) validate() error {
+	if c.Trash.Retention <= 0 {
+		return fmt.Errorf("trash.retention must be positive")
+	}
+	if c.Trash.PurgeInterval <= 0 {
+		return fmt.Errorf("trash.purge_interval must be positive")
+	}
+	if c.Subtasks.MaxDepth < 1 {
+		return fmt.Errorf("subtasks.max_depth must be at least 1")
+	}
+	switch c.Subtasks.CompletionPolicy {
+	case CompletionCascade, CompletionRefuse:
+	default:
+		return fmt.Errorf("subtasks.completion_policy must be %q or %q", CompletionCascade, CompletionRefuse)
+	}
+	if c.Batch.MaxSize < 1 {
+		return fmt.Errorf("batch.max_size must be at least 1")
+	}
+	if c.Attachments.Root == "" {
+		return fmt.Errorf("attachments.root is required")
+	}
+	if c.Attachments.MaxSize <= 0 {
+		return fmt.Errorf("attachments.max_size must be positive")
+	}
+	if len(c.Attachments.AllowedTypes) == 0 {
+		return fmt.Errorf("attachments.allowed_types must not be empty")
+	}
+	if c.Webhooks.PollInterval <= 0 {
+		return fmt.Errorf("webhooks.poll_interval must be positive")
+	}
+	if c.Webhooks.Timeout <= 0 {
+		return fmt.Errorf("webhooks.timeout must be positive")
+	}
+	if c.Webhooks.MaxAttempts < 1 {
+		return fmt.Errorf("webhooks.max_attempts must be at least 1")
+	}
+	if c.Webhooks.BackoffBase <= 0 || c.Webhooks.BackoffMax < c.Webhooks.BackoffBase {
+		return fmt.Errorf("webhooks.backoff_base must be positive and not exceed webhooks.backoff_max")
+	}
+	if c.Idempotency.TTL <= 0 {
+		return fmt.Errorf("idempotency.ttl must be positive")
+	}
+	if c.Idempotency.Lease <= 0 {
+		return fmt.Errorf("idempotency.lease must be positive")
+	}
+	if c.Idempotency.PurgeInterval <= 0 {
+		return fmt.Errorf("idempotency.purge_interval must be positive")
+	}
+	if c.Watch.PollInterval <= 0 {
+		return fmt.Errorf("watch.poll_interval must be positive")
+	}
+	if !c.Auth.Disabled {
+		if c.Auth.HMACSecret == "" && c.Auth.JWKSFile == "" {
+			return fmt.Errorf("auth.hmac_secret or auth.jwks_file is required unless auth.disabled is set")
+		}
+		if c.Auth.TenantClaim == "" {
+			return fmt.Errorf("auth.tenant_claim is required")
+		}
+		if c.Auth.Leeway < 0 {
+			return fmt.Errorf("auth.leeway must not be negative")
+		}
+	}
+	return nil
+}
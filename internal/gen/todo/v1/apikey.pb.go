@@ -0,0 +1,544 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.2
+// source: todo/v1/apikey.proto
+
+package todo
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Права, которые даёт ключ.
+type ApiKeyScope int32
+
+const (
+	// Не задано; при создании ключа недопустимо.
+	ApiKeyScope_API_KEY_SCOPE_UNSPECIFIED ApiKeyScope = 0
+	// Только чтение: методы Get*, List* и Download*.
+	ApiKeyScope_API_KEY_SCOPE_READ_ONLY ApiKeyScope = 1
+	// Чтение и изменение данных.
+	ApiKeyScope_API_KEY_SCOPE_READ_WRITE ApiKeyScope = 2
+)
+
+// Enum value maps for ApiKeyScope.
+var (
+	ApiKeyScope_name = map[int32]string{
+		0: "API_KEY_SCOPE_UNSPECIFIED",
+		1: "API_KEY_SCOPE_READ_ONLY",
+		2: "API_KEY_SCOPE_READ_WRITE",
+	}
+	ApiKeyScope_value = map[string]int32{
+		"API_KEY_SCOPE_UNSPECIFIED": 0,
+		"API_KEY_SCOPE_READ_ONLY":   1,
+		"API_KEY_SCOPE_READ_WRITE":  2,
+	}
+)
+
+func (x ApiKeyScope) Enum() *ApiKeyScope {
+	p := new(ApiKeyScope)
+	*p = x
+	return p
+}
+
+func (x ApiKeyScope) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ApiKeyScope) Descriptor() protoreflect.EnumDescriptor {
+	return file_todo_v1_apikey_proto_enumTypes[0].Descriptor()
+}
+
+func (ApiKeyScope) Type() protoreflect.EnumType {
+	return &file_todo_v1_apikey_proto_enumTypes[0]
+}
+
+func (x ApiKeyScope) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ApiKeyScope.Descriptor instead.
+func (ApiKeyScope) EnumDescriptor() ([]byte, []int) {
+	return file_todo_v1_apikey_proto_rawDescGZIP(), []int{0}
+}
+
+// Ключ API без секрета.
+type ApiKey struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Уникальный идентификатор.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Название, по которому владелец узнаёт ключ.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Начало секрета для опознания ключа.
+	Prefix string `protobuf:"bytes,3,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// Права ключа.
+	Scope ApiKeyScope `protobuf:"varint,4,opt,name=scope,proto3,enum=todo.v1.ApiKeyScope" json:"scope,omitempty"`
+	// Время создания в unix timestamp.
+	CreatedAt int64 `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Срок действия в unix timestamp; не задан у бессрочных ключей.
+	ExpiresAt *int64 `protobuf:"varint,6,opt,name=expires_at,json=expiresAt,proto3,oneof" json:"expires_at,omitempty"`
+	// Время последнего использования в unix timestamp.
+	LastUsedAt *int64 `protobuf:"varint,7,opt,name=last_used_at,json=lastUsedAt,proto3,oneof" json:"last_used_at,omitempty"`
+	// Время отзыва в unix timestamp.
+	RevokedAt     *int64 `protobuf:"varint,8,opt,name=revoked_at,json=revokedAt,proto3,oneof" json:"revoked_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApiKey) Reset() {
+	*x = ApiKey{}
+	mi := &file_todo_v1_apikey_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApiKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApiKey) ProtoMessage() {}
+
+func (x *ApiKey) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_apikey_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApiKey.ProtoReflect.Descriptor instead.
+func (*ApiKey) Descriptor() ([]byte, []int) {
+	return file_todo_v1_apikey_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ApiKey) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ApiKey) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ApiKey) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *ApiKey) GetScope() ApiKeyScope {
+	if x != nil {
+		return x.Scope
+	}
+	return ApiKeyScope_API_KEY_SCOPE_UNSPECIFIED
+}
+
+func (x *ApiKey) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *ApiKey) GetExpiresAt() int64 {
+	if x != nil && x.ExpiresAt != nil {
+		return *x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *ApiKey) GetLastUsedAt() int64 {
+	if x != nil && x.LastUsedAt != nil {
+		return *x.LastUsedAt
+	}
+	return 0
+}
+
+func (x *ApiKey) GetRevokedAt() int64 {
+	if x != nil && x.RevokedAt != nil {
+		return *x.RevokedAt
+	}
+	return 0
+}
+
+// Запрос на создание ключа.
+type CreateApiKeyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Название ключа.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Права ключа.
+	Scope ApiKeyScope `protobuf:"varint,2,opt,name=scope,proto3,enum=todo.v1.ApiKeyScope" json:"scope,omitempty"`
+	// Срок действия в unix timestamp; без него ключ бессрочный.
+	ExpiresAt     *int64 `protobuf:"varint,3,opt,name=expires_at,json=expiresAt,proto3,oneof" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateApiKeyRequest) Reset() {
+	*x = CreateApiKeyRequest{}
+	mi := &file_todo_v1_apikey_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateApiKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateApiKeyRequest) ProtoMessage() {}
+
+func (x *CreateApiKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_apikey_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateApiKeyRequest.ProtoReflect.Descriptor instead.
+func (*CreateApiKeyRequest) Descriptor() ([]byte, []int) {
+	return file_todo_v1_apikey_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateApiKeyRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateApiKeyRequest) GetScope() ApiKeyScope {
+	if x != nil {
+		return x.Scope
+	}
+	return ApiKeyScope_API_KEY_SCOPE_UNSPECIFIED
+}
+
+func (x *CreateApiKeyRequest) GetExpiresAt() int64 {
+	if x != nil && x.ExpiresAt != nil {
+		return *x.ExpiresAt
+	}
+	return 0
+}
+
+// Ответ с созданным ключом.
+type CreateApiKeyResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Созданный ключ.
+	ApiKey *ApiKey `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	// Секрет для метаданных x-api-key; повторно его получить нельзя.
+	Secret        string `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateApiKeyResponse) Reset() {
+	*x = CreateApiKeyResponse{}
+	mi := &file_todo_v1_apikey_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateApiKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateApiKeyResponse) ProtoMessage() {}
+
+func (x *CreateApiKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_apikey_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateApiKeyResponse.ProtoReflect.Descriptor instead.
+func (*CreateApiKeyResponse) Descriptor() ([]byte, []int) {
+	return file_todo_v1_apikey_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateApiKeyResponse) GetApiKey() *ApiKey {
+	if x != nil {
+		return x.ApiKey
+	}
+	return nil
+}
+
+func (x *CreateApiKeyResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+// Запрос списка ключей.
+type ListApiKeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListApiKeysRequest) Reset() {
+	*x = ListApiKeysRequest{}
+	mi := &file_todo_v1_apikey_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListApiKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListApiKeysRequest) ProtoMessage() {}
+
+func (x *ListApiKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_apikey_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListApiKeysRequest.ProtoReflect.Descriptor instead.
+func (*ListApiKeysRequest) Descriptor() ([]byte, []int) {
+	return file_todo_v1_apikey_proto_rawDescGZIP(), []int{3}
+}
+
+// Ответ со списком ключей.
+type ListApiKeysResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Ключи вызывающего, начиная с новых.
+	ApiKeys       []*ApiKey `protobuf:"bytes,1,rep,name=api_keys,json=apiKeys,proto3" json:"api_keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListApiKeysResponse) Reset() {
+	*x = ListApiKeysResponse{}
+	mi := &file_todo_v1_apikey_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListApiKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListApiKeysResponse) ProtoMessage() {}
+
+func (x *ListApiKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_apikey_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListApiKeysResponse.ProtoReflect.Descriptor instead.
+func (*ListApiKeysResponse) Descriptor() ([]byte, []int) {
+	return file_todo_v1_apikey_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListApiKeysResponse) GetApiKeys() []*ApiKey {
+	if x != nil {
+		return x.ApiKeys
+	}
+	return nil
+}
+
+// Запрос на отзыв ключа.
+type RevokeApiKeyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Уникальный идентификатор ключа.
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeApiKeyRequest) Reset() {
+	*x = RevokeApiKeyRequest{}
+	mi := &file_todo_v1_apikey_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeApiKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeApiKeyRequest) ProtoMessage() {}
+
+func (x *RevokeApiKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_apikey_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeApiKeyRequest.ProtoReflect.Descriptor instead.
+func (*RevokeApiKeyRequest) Descriptor() ([]byte, []int) {
+	return file_todo_v1_apikey_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RevokeApiKeyRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+var File_todo_v1_apikey_proto protoreflect.FileDescriptor
+
+const file_todo_v1_apikey_proto_rawDesc = "" +
+	"\n" +
+	"\x14todo/v1/apikey.proto\x12\atodo.v1\"\xad\x02\n" +
+	"\x06ApiKey\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06prefix\x18\x03 \x01(\tR\x06prefix\x12*\n" +
+	"\x05scope\x18\x04 \x01(\x0e2\x14.todo.v1.ApiKeyScopeR\x05scope\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\x03R\tcreatedAt\x12\"\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\x03H\x00R\texpiresAt\x88\x01\x01\x12%\n" +
+	"\flast_used_at\x18\a \x01(\x03H\x01R\n" +
+	"lastUsedAt\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"revoked_at\x18\b \x01(\x03H\x02R\trevokedAt\x88\x01\x01B\r\n" +
+	"\v_expires_atB\x0f\n" +
+	"\r_last_used_atB\r\n" +
+	"\v_revoked_at\"\x88\x01\n" +
+	"\x13CreateApiKeyRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12*\n" +
+	"\x05scope\x18\x02 \x01(\x0e2\x14.todo.v1.ApiKeyScopeR\x05scope\x12\"\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\x03H\x00R\texpiresAt\x88\x01\x01B\r\n" +
+	"\v_expires_at\"X\n" +
+	"\x14CreateApiKeyResponse\x12(\n" +
+	"\aapi_key\x18\x01 \x01(\v2\x0f.todo.v1.ApiKeyR\x06apiKey\x12\x16\n" +
+	"\x06secret\x18\x02 \x01(\tR\x06secret\"\x14\n" +
+	"\x12ListApiKeysRequest\"A\n" +
+	"\x13ListApiKeysResponse\x12*\n" +
+	"\bapi_keys\x18\x01 \x03(\v2\x0f.todo.v1.ApiKeyR\aapiKeys\"%\n" +
+	"\x13RevokeApiKeyRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id*g\n" +
+	"\vApiKeyScope\x12\x1d\n" +
+	"\x19API_KEY_SCOPE_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17API_KEY_SCOPE_READ_ONLY\x10\x01\x12\x1c\n" +
+	"\x18API_KEY_SCOPE_READ_WRITE\x10\x022\xe5\x01\n" +
+	"\rApiKeyService\x12K\n" +
+	"\fCreateApiKey\x12\x1c.todo.v1.CreateApiKeyRequest\x1a\x1d.todo.v1.CreateApiKeyResponse\x12H\n" +
+	"\vListApiKeys\x12\x1b.todo.v1.ListApiKeysRequest\x1a\x1c.todo.v1.ListApiKeysResponse\x12=\n" +
+	"\fRevokeApiKey\x12\x1c.todo.v1.RevokeApiKeyRequest\x1a\x0f.todo.v1.ApiKeyB Z\x1etodo/internal/gen/todo/v1;todob\x06proto3"
+
+var (
+	file_todo_v1_apikey_proto_rawDescOnce sync.Once
+	file_todo_v1_apikey_proto_rawDescData []byte
+)
+
+func file_todo_v1_apikey_proto_rawDescGZIP() []byte {
+	file_todo_v1_apikey_proto_rawDescOnce.Do(func() {
+		file_todo_v1_apikey_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_todo_v1_apikey_proto_rawDesc), len(file_todo_v1_apikey_proto_rawDesc)))
+	})
+	return file_todo_v1_apikey_proto_rawDescData
+}
+
+var file_todo_v1_apikey_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_todo_v1_apikey_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_todo_v1_apikey_proto_goTypes = []any{
+	(ApiKeyScope)(0),             // 0: todo.v1.ApiKeyScope
+	(*ApiKey)(nil),               // 1: todo.v1.ApiKey
+	(*CreateApiKeyRequest)(nil),  // 2: todo.v1.CreateApiKeyRequest
+	(*CreateApiKeyResponse)(nil), // 3: todo.v1.CreateApiKeyResponse
+	(*ListApiKeysRequest)(nil),   // 4: todo.v1.ListApiKeysRequest
+	(*ListApiKeysResponse)(nil),  // 5: todo.v1.ListApiKeysResponse
+	(*RevokeApiKeyRequest)(nil),  // 6: todo.v1.RevokeApiKeyRequest
+}
+var file_todo_v1_apikey_proto_depIdxs = []int32{
+	0, // 0: todo.v1.ApiKey.scope:type_name -> todo.v1.ApiKeyScope
+	0, // 1: todo.v1.CreateApiKeyRequest.scope:type_name -> todo.v1.ApiKeyScope
+	1, // 2: todo.v1.CreateApiKeyResponse.api_key:type_name -> todo.v1.ApiKey
+	1, // 3: todo.v1.ListApiKeysResponse.api_keys:type_name -> todo.v1.ApiKey
+	2, // 4: todo.v1.ApiKeyService.CreateApiKey:input_type -> todo.v1.CreateApiKeyRequest
+	4, // 5: todo.v1.ApiKeyService.ListApiKeys:input_type -> todo.v1.ListApiKeysRequest
+	6, // 6: todo.v1.ApiKeyService.RevokeApiKey:input_type -> todo.v1.RevokeApiKeyRequest
+	3, // 7: todo.v1.ApiKeyService.CreateApiKey:output_type -> todo.v1.CreateApiKeyResponse
+	5, // 8: todo.v1.ApiKeyService.ListApiKeys:output_type -> todo.v1.ListApiKeysResponse
+	1, // 9: todo.v1.ApiKeyService.RevokeApiKey:output_type -> todo.v1.ApiKey
+	7, // [7:10] is the sub-list for method output_type
+	4, // [4:7] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_todo_v1_apikey_proto_init() }
+func file_todo_v1_apikey_proto_init() {
+	if File_todo_v1_apikey_proto != nil {
+		return
+	}
+	file_todo_v1_apikey_proto_msgTypes[0].OneofWrappers = []any{}
+	file_todo_v1_apikey_proto_msgTypes[1].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_todo_v1_apikey_proto_rawDesc), len(file_todo_v1_apikey_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_todo_v1_apikey_proto_goTypes,
+		DependencyIndexes: file_todo_v1_apikey_proto_depIdxs,
+		EnumInfos:         file_todo_v1_apikey_proto_enumTypes,
+		MessageInfos:      file_todo_v1_apikey_proto_msgTypes,
+	}.Build()
+	File_todo_v1_apikey_proto = out.File
+	file_todo_v1_apikey_proto_goTypes = nil
+	file_todo_v1_apikey_proto_depIdxs = nil
+}
@@ -0,0 +1,917 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.2
+// source: todo/v1/project.proto
+
+package todo
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Что делать с задачами удаляемого проекта.
+type DeleteProjectMode int32
+
+const (
+	// Перенести задачи во «Входящие» (без проекта).
+	DeleteProjectMode_DELETE_PROJECT_MODE_MOVE_TO_INBOX DeleteProjectMode = 0
+	// Переместить задачи проекта в корзину.
+	DeleteProjectMode_DELETE_PROJECT_MODE_CASCADE DeleteProjectMode = 1
+)
+
+// Enum value maps for DeleteProjectMode.
+var (
+	DeleteProjectMode_name = map[int32]string{
+		0: "DELETE_PROJECT_MODE_MOVE_TO_INBOX",
+		1: "DELETE_PROJECT_MODE_CASCADE",
+	}
+	DeleteProjectMode_value = map[string]int32{
+		"DELETE_PROJECT_MODE_MOVE_TO_INBOX": 0,
+		"DELETE_PROJECT_MODE_CASCADE":       1,
+	}
+)
+
+func (x DeleteProjectMode) Enum() *DeleteProjectMode {
+	p := new(DeleteProjectMode)
+	*p = x
+	return p
+}
+
+func (x DeleteProjectMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DeleteProjectMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_todo_v1_project_proto_enumTypes[0].Descriptor()
+}
+
+func (DeleteProjectMode) Type() protoreflect.EnumType {
+	return &file_todo_v1_project_proto_enumTypes[0]
+}
+
+func (x DeleteProjectMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DeleteProjectMode.Descriptor instead.
+func (DeleteProjectMode) EnumDescriptor() ([]byte, []int) {
+	return file_todo_v1_project_proto_rawDescGZIP(), []int{0}
+}
+
+// Проект — именованный список задач.
+type Project struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Уникальный идентификатор.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Название проекта.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Подробное описание.
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Время создания в unix timestamp.
+	CreatedAt int64 `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Время обновления в unix timestamp.
+	UpdatedAt int64 `protobuf:"varint,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Число задач проекта вне корзины.
+	TodoCount int64 `protobuf:"varint,6,opt,name=todo_count,json=todoCount,proto3" json:"todo_count,omitempty"`
+	// Пользователь, создавший проект.
+	OwnerId       string `protobuf:"bytes,7,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Project) Reset() {
+	*x = Project{}
+	mi := &file_todo_v1_project_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Project) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Project) ProtoMessage() {}
+
+func (x *Project) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_project_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Project.ProtoReflect.Descriptor instead.
+func (*Project) Descriptor() ([]byte, []int) {
+	return file_todo_v1_project_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Project) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Project) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Project) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Project) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Project) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *Project) GetTodoCount() int64 {
+	if x != nil {
+		return x.TodoCount
+	}
+	return 0
+}
+
+func (x *Project) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+// Запрос на создание проекта.
+type CreateProjectRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Название проекта.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Подробное описание.
+	Description   string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProjectRequest) Reset() {
+	*x = CreateProjectRequest{}
+	mi := &file_todo_v1_project_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProjectRequest) ProtoMessage() {}
+
+func (x *CreateProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_project_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProjectRequest.ProtoReflect.Descriptor instead.
+func (*CreateProjectRequest) Descriptor() ([]byte, []int) {
+	return file_todo_v1_project_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateProjectRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateProjectRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+// Запрос на получение проекта по идентификатору.
+type GetProjectRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Уникальный идентификатор проекта.
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProjectRequest) Reset() {
+	*x = GetProjectRequest{}
+	mi := &file_todo_v1_project_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProjectRequest) ProtoMessage() {}
+
+func (x *GetProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_project_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProjectRequest.ProtoReflect.Descriptor instead.
+func (*GetProjectRequest) Descriptor() ([]byte, []int) {
+	return file_todo_v1_project_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetProjectRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Запрос списка проектов.
+type ListProjectsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProjectsRequest) Reset() {
+	*x = ListProjectsRequest{}
+	mi := &file_todo_v1_project_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProjectsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProjectsRequest) ProtoMessage() {}
+
+func (x *ListProjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_project_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProjectsRequest.ProtoReflect.Descriptor instead.
+func (*ListProjectsRequest) Descriptor() ([]byte, []int) {
+	return file_todo_v1_project_proto_rawDescGZIP(), []int{3}
+}
+
+// Ответ со списком проектов.
+type ListProjectsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Проекты в алфавитном порядке.
+	Projects      []*Project `protobuf:"bytes,1,rep,name=projects,proto3" json:"projects,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProjectsResponse) Reset() {
+	*x = ListProjectsResponse{}
+	mi := &file_todo_v1_project_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProjectsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProjectsResponse) ProtoMessage() {}
+
+func (x *ListProjectsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_project_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProjectsResponse.ProtoReflect.Descriptor instead.
+func (*ListProjectsResponse) Descriptor() ([]byte, []int) {
+	return file_todo_v1_project_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListProjectsResponse) GetProjects() []*Project {
+	if x != nil {
+		return x.Projects
+	}
+	return nil
+}
+
+// Запрос на обновление проекта.
+type UpdateProjectRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Уникальный идентификатор проекта.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Новое название.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Новое описание.
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Изменяемые поля: name, description. Пустая маска означает все поля.
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,4,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProjectRequest) Reset() {
+	*x = UpdateProjectRequest{}
+	mi := &file_todo_v1_project_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProjectRequest) ProtoMessage() {}
+
+func (x *UpdateProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_project_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProjectRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProjectRequest) Descriptor() ([]byte, []int) {
+	return file_todo_v1_project_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateProjectRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateProjectRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateProjectRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateProjectRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+// Запрос на удаление проекта.
+type DeleteProjectRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Уникальный идентификатор проекта.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Судьба задач проекта.
+	Mode          DeleteProjectMode `protobuf:"varint,2,opt,name=mode,proto3,enum=todo.v1.DeleteProjectMode" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProjectRequest) Reset() {
+	*x = DeleteProjectRequest{}
+	mi := &file_todo_v1_project_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProjectRequest) ProtoMessage() {}
+
+func (x *DeleteProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_project_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProjectRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProjectRequest) Descriptor() ([]byte, []int) {
+	return file_todo_v1_project_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeleteProjectRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteProjectRequest) GetMode() DeleteProjectMode {
+	if x != nil {
+		return x.Mode
+	}
+	return DeleteProjectMode_DELETE_PROJECT_MODE_MOVE_TO_INBOX
+}
+
+// Ответ на удаление проекта (пустой).
+type DeleteProjectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProjectResponse) Reset() {
+	*x = DeleteProjectResponse{}
+	mi := &file_todo_v1_project_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProjectResponse) ProtoMessage() {}
+
+func (x *DeleteProjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_project_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProjectResponse.ProtoReflect.Descriptor instead.
+func (*DeleteProjectResponse) Descriptor() ([]byte, []int) {
+	return file_todo_v1_project_proto_rawDescGZIP(), []int{7}
+}
+
+// Запрос на выдачу роли на проект.
+type ShareProjectRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Уникальный идентификатор проекта.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Пользователь, которому выдаётся роль.
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Выдаваемая роль.
+	Role          Role `protobuf:"varint,3,opt,name=role,proto3,enum=todo.v1.Role" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareProjectRequest) Reset() {
+	*x = ShareProjectRequest{}
+	mi := &file_todo_v1_project_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareProjectRequest) ProtoMessage() {}
+
+func (x *ShareProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_project_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareProjectRequest.ProtoReflect.Descriptor instead.
+func (*ShareProjectRequest) Descriptor() ([]byte, []int) {
+	return file_todo_v1_project_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ShareProjectRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ShareProjectRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ShareProjectRequest) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+// Запрос на отзыв роли на проект.
+type UnshareProjectRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Уникальный идентификатор проекта.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Пользователь, роль которого отзывается.
+	UserId        string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnshareProjectRequest) Reset() {
+	*x = UnshareProjectRequest{}
+	mi := &file_todo_v1_project_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnshareProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnshareProjectRequest) ProtoMessage() {}
+
+func (x *UnshareProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_project_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnshareProjectRequest.ProtoReflect.Descriptor instead.
+func (*UnshareProjectRequest) Descriptor() ([]byte, []int) {
+	return file_todo_v1_project_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UnshareProjectRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UnshareProjectRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// Ответ на отзыв роли (пустой).
+type UnshareProjectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnshareProjectResponse) Reset() {
+	*x = UnshareProjectResponse{}
+	mi := &file_todo_v1_project_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnshareProjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnshareProjectResponse) ProtoMessage() {}
+
+func (x *UnshareProjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_project_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnshareProjectResponse.ProtoReflect.Descriptor instead.
+func (*UnshareProjectResponse) Descriptor() ([]byte, []int) {
+	return file_todo_v1_project_proto_rawDescGZIP(), []int{10}
+}
+
+// Запрос участников проекта.
+type ListProjectCollaboratorsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Уникальный идентификатор проекта.
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProjectCollaboratorsRequest) Reset() {
+	*x = ListProjectCollaboratorsRequest{}
+	mi := &file_todo_v1_project_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProjectCollaboratorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProjectCollaboratorsRequest) ProtoMessage() {}
+
+func (x *ListProjectCollaboratorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_project_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProjectCollaboratorsRequest.ProtoReflect.Descriptor instead.
+func (*ListProjectCollaboratorsRequest) Descriptor() ([]byte, []int) {
+	return file_todo_v1_project_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListProjectCollaboratorsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Ответ со списком участников проекта.
+type ListProjectCollaboratorsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Владелец, затем пользователи в порядке выдачи ролей.
+	Collaborators []*Collaborator `protobuf:"bytes,1,rep,name=collaborators,proto3" json:"collaborators,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProjectCollaboratorsResponse) Reset() {
+	*x = ListProjectCollaboratorsResponse{}
+	mi := &file_todo_v1_project_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProjectCollaboratorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProjectCollaboratorsResponse) ProtoMessage() {}
+
+func (x *ListProjectCollaboratorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_project_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProjectCollaboratorsResponse.ProtoReflect.Descriptor instead.
+func (*ListProjectCollaboratorsResponse) Descriptor() ([]byte, []int) {
+	return file_todo_v1_project_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListProjectCollaboratorsResponse) GetCollaborators() []*Collaborator {
+	if x != nil {
+		return x.Collaborators
+	}
+	return nil
+}
+
+var File_todo_v1_project_proto protoreflect.FileDescriptor
+
+const file_todo_v1_project_proto_rawDesc = "" +
+	"\n" +
+	"\x15todo/v1/project.proto\x12\atodo.v1\x1a google/protobuf/field_mask.proto\x1a\x15todo/v1/sharing.proto\"\xc7\x01\n" +
+	"\aProject\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\x03R\tupdatedAt\x12\x1d\n" +
+	"\n" +
+	"todo_count\x18\x06 \x01(\x03R\ttodoCount\x12\x19\n" +
+	"\bowner_id\x18\a \x01(\tR\aownerId\"L\n" +
+	"\x14CreateProjectRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\"#\n" +
+	"\x11GetProjectRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x15\n" +
+	"\x13ListProjectsRequest\"D\n" +
+	"\x14ListProjectsResponse\x12,\n" +
+	"\bprojects\x18\x01 \x03(\v2\x10.todo.v1.ProjectR\bprojects\"\x99\x01\n" +
+	"\x14UpdateProjectRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12;\n" +
+	"\vupdate_mask\x18\x04 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"V\n" +
+	"\x14DeleteProjectRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12.\n" +
+	"\x04mode\x18\x02 \x01(\x0e2\x1a.todo.v1.DeleteProjectModeR\x04mode\"\x17\n" +
+	"\x15DeleteProjectResponse\"a\n" +
+	"\x13ShareProjectRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12!\n" +
+	"\x04role\x18\x03 \x01(\x0e2\r.todo.v1.RoleR\x04role\"@\n" +
+	"\x15UnshareProjectRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x18\n" +
+	"\x16UnshareProjectResponse\"1\n" +
+	"\x1fListProjectCollaboratorsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"_\n" +
+	" ListProjectCollaboratorsResponse\x12;\n" +
+	"\rcollaborators\x18\x01 \x03(\v2\x15.todo.v1.CollaboratorR\rcollaborators*[\n" +
+	"\x11DeleteProjectMode\x12%\n" +
+	"!DELETE_PROJECT_MODE_MOVE_TO_INBOX\x10\x00\x12\x1f\n" +
+	"\x1bDELETE_PROJECT_MODE_CASCADE\x10\x012\xf6\x04\n" +
+	"\x0eProjectService\x12@\n" +
+	"\rCreateProject\x12\x1d.todo.v1.CreateProjectRequest\x1a\x10.todo.v1.Project\x12:\n" +
+	"\n" +
+	"GetProject\x12\x1a.todo.v1.GetProjectRequest\x1a\x10.todo.v1.Project\x12K\n" +
+	"\fListProjects\x12\x1c.todo.v1.ListProjectsRequest\x1a\x1d.todo.v1.ListProjectsResponse\x12@\n" +
+	"\rUpdateProject\x12\x1d.todo.v1.UpdateProjectRequest\x1a\x10.todo.v1.Project\x12N\n" +
+	"\rDeleteProject\x12\x1d.todo.v1.DeleteProjectRequest\x1a\x1e.todo.v1.DeleteProjectResponse\x12C\n" +
+	"\fShareProject\x12\x1c.todo.v1.ShareProjectRequest\x1a\x15.todo.v1.Collaborator\x12Q\n" +
+	"\x0eUnshareProject\x12\x1e.todo.v1.UnshareProjectRequest\x1a\x1f.todo.v1.UnshareProjectResponse\x12o\n" +
+	"\x18ListProjectCollaborators\x12(.todo.v1.ListProjectCollaboratorsRequest\x1a).todo.v1.ListProjectCollaboratorsResponseB Z\x1etodo/internal/gen/todo/v1;todob\x06proto3"
+
+var (
+	file_todo_v1_project_proto_rawDescOnce sync.Once
+	file_todo_v1_project_proto_rawDescData []byte
+)
+
+func file_todo_v1_project_proto_rawDescGZIP() []byte {
+	file_todo_v1_project_proto_rawDescOnce.Do(func() {
+		file_todo_v1_project_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_todo_v1_project_proto_rawDesc), len(file_todo_v1_project_proto_rawDesc)))
+	})
+	return file_todo_v1_project_proto_rawDescData
+}
+
+var file_todo_v1_project_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_todo_v1_project_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_todo_v1_project_proto_goTypes = []any{
+	(DeleteProjectMode)(0),                   // 0: todo.v1.DeleteProjectMode
+	(*Project)(nil),                          // 1: todo.v1.Project
+	(*CreateProjectRequest)(nil),             // 2: todo.v1.CreateProjectRequest
+	(*GetProjectRequest)(nil),                // 3: todo.v1.GetProjectRequest
+	(*ListProjectsRequest)(nil),              // 4: todo.v1.ListProjectsRequest
+	(*ListProjectsResponse)(nil),             // 5: todo.v1.ListProjectsResponse
+	(*UpdateProjectRequest)(nil),             // 6: todo.v1.UpdateProjectRequest
+	(*DeleteProjectRequest)(nil),             // 7: todo.v1.DeleteProjectRequest
+	(*DeleteProjectResponse)(nil),            // 8: todo.v1.DeleteProjectResponse
+	(*ShareProjectRequest)(nil),              // 9: todo.v1.ShareProjectRequest
+	(*UnshareProjectRequest)(nil),            // 10: todo.v1.UnshareProjectRequest
+	(*UnshareProjectResponse)(nil),           // 11: todo.v1.UnshareProjectResponse
+	(*ListProjectCollaboratorsRequest)(nil),  // 12: todo.v1.ListProjectCollaboratorsRequest
+	(*ListProjectCollaboratorsResponse)(nil), // 13: todo.v1.ListProjectCollaboratorsResponse
+	(*fieldmaskpb.FieldMask)(nil),            // 14: google.protobuf.FieldMask
+	(Role)(0),                                // 15: todo.v1.Role
+	(*Collaborator)(nil),                     // 16: todo.v1.Collaborator
+}
+var file_todo_v1_project_proto_depIdxs = []int32{
+	1,  // 0: todo.v1.ListProjectsResponse.projects:type_name -> todo.v1.Project
+	14, // 1: todo.v1.UpdateProjectRequest.update_mask:type_name -> google.protobuf.FieldMask
+	0,  // 2: todo.v1.DeleteProjectRequest.mode:type_name -> todo.v1.DeleteProjectMode
+	15, // 3: todo.v1.ShareProjectRequest.role:type_name -> todo.v1.Role
+	16, // 4: todo.v1.ListProjectCollaboratorsResponse.collaborators:type_name -> todo.v1.Collaborator
+	2,  // 5: todo.v1.ProjectService.CreateProject:input_type -> todo.v1.CreateProjectRequest
+	3,  // 6: todo.v1.ProjectService.GetProject:input_type -> todo.v1.GetProjectRequest
+	4,  // 7: todo.v1.ProjectService.ListProjects:input_type -> todo.v1.ListProjectsRequest
+	6,  // 8: todo.v1.ProjectService.UpdateProject:input_type -> todo.v1.UpdateProjectRequest
+	7,  // 9: todo.v1.ProjectService.DeleteProject:input_type -> todo.v1.DeleteProjectRequest
+	9,  // 10: todo.v1.ProjectService.ShareProject:input_type -> todo.v1.ShareProjectRequest
+	10, // 11: todo.v1.ProjectService.UnshareProject:input_type -> todo.v1.UnshareProjectRequest
+	12, // 12: todo.v1.ProjectService.ListProjectCollaborators:input_type -> todo.v1.ListProjectCollaboratorsRequest
+	1,  // 13: todo.v1.ProjectService.CreateProject:output_type -> todo.v1.Project
+	1,  // 14: todo.v1.ProjectService.GetProject:output_type -> todo.v1.Project
+	5,  // 15: todo.v1.ProjectService.ListProjects:output_type -> todo.v1.ListProjectsResponse
+	1,  // 16: todo.v1.ProjectService.UpdateProject:output_type -> todo.v1.Project
+	8,  // 17: todo.v1.ProjectService.DeleteProject:output_type -> todo.v1.DeleteProjectResponse
+	16, // 18: todo.v1.ProjectService.ShareProject:output_type -> todo.v1.Collaborator
+	11, // 19: todo.v1.ProjectService.UnshareProject:output_type -> todo.v1.UnshareProjectResponse
+	13, // 20: todo.v1.ProjectService.ListProjectCollaborators:output_type -> todo.v1.ListProjectCollaboratorsResponse
+	13, // [13:21] is the sub-list for method output_type
+	5,  // [5:13] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_todo_v1_project_proto_init() }
+func file_todo_v1_project_proto_init() {
+	if File_todo_v1_project_proto != nil {
+		return
+	}
+	file_todo_v1_sharing_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_todo_v1_project_proto_rawDesc), len(file_todo_v1_project_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_todo_v1_project_proto_goTypes,
+		DependencyIndexes: file_todo_v1_project_proto_depIdxs,
+		EnumInfos:         file_todo_v1_project_proto_enumTypes,
+		MessageInfos:      file_todo_v1_project_proto_msgTypes,
+	}.Build()
+	File_todo_v1_project_proto = out.File
+	file_todo_v1_project_proto_goTypes = nil
+	file_todo_v1_project_proto_depIdxs = nil
+}
@@ -0,0 +1,212 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.2
+// source: todo/v1/sharing.proto
+
+package todo
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Роль пользователя на задачу или проект; каждая следующая включает права предыдущих.
+type Role int32
+
+const (
+	// Роль не задана.
+	Role_ROLE_UNSPECIFIED Role = 0
+	// Чтение и комментирование.
+	Role_ROLE_VIEWER Role = 1
+	// Изменение и удаление в корзину.
+	Role_ROLE_EDITOR Role = 2
+	// Все права, включая совместный доступ и окончательное удаление.
+	Role_ROLE_OWNER Role = 3
+)
+
+// Enum value maps for Role.
+var (
+	Role_name = map[int32]string{
+		0: "ROLE_UNSPECIFIED",
+		1: "ROLE_VIEWER",
+		2: "ROLE_EDITOR",
+		3: "ROLE_OWNER",
+	}
+	Role_value = map[string]int32{
+		"ROLE_UNSPECIFIED": 0,
+		"ROLE_VIEWER":      1,
+		"ROLE_EDITOR":      2,
+		"ROLE_OWNER":       3,
+	}
+)
+
+func (x Role) Enum() *Role {
+	p := new(Role)
+	*p = x
+	return p
+}
+
+func (x Role) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Role) Descriptor() protoreflect.EnumDescriptor {
+	return file_todo_v1_sharing_proto_enumTypes[0].Descriptor()
+}
+
+func (Role) Type() protoreflect.EnumType {
+	return &file_todo_v1_sharing_proto_enumTypes[0]
+}
+
+func (x Role) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Role.Descriptor instead.
+func (Role) EnumDescriptor() ([]byte, []int) {
+	return file_todo_v1_sharing_proto_rawDescGZIP(), []int{0}
+}
+
+// Участник — пользователь с ролью на задачу или проект.
+type Collaborator struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Идентификатор пользователя.
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Роль пользователя.
+	Role Role `protobuf:"varint,2,opt,name=role,proto3,enum=todo.v1.Role" json:"role,omitempty"`
+	// Время выдачи роли в unix timestamp; для владельца — время создания записи.
+	CreatedAt     int64 `protobuf:"varint,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Collaborator) Reset() {
+	*x = Collaborator{}
+	mi := &file_todo_v1_sharing_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Collaborator) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Collaborator) ProtoMessage() {}
+
+func (x *Collaborator) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_sharing_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Collaborator.ProtoReflect.Descriptor instead.
+func (*Collaborator) Descriptor() ([]byte, []int) {
+	return file_todo_v1_sharing_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Collaborator) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Collaborator) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+func (x *Collaborator) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+var File_todo_v1_sharing_proto protoreflect.FileDescriptor
+
+const file_todo_v1_sharing_proto_rawDesc = "" +
+	"\n" +
+	"\x15todo/v1/sharing.proto\x12\atodo.v1\"i\n" +
+	"\fCollaborator\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12!\n" +
+	"\x04role\x18\x02 \x01(\x0e2\r.todo.v1.RoleR\x04role\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\x03R\tcreatedAt*N\n" +
+	"\x04Role\x12\x14\n" +
+	"\x10ROLE_UNSPECIFIED\x10\x00\x12\x0f\n" +
+	"\vROLE_VIEWER\x10\x01\x12\x0f\n" +
+	"\vROLE_EDITOR\x10\x02\x12\x0e\n" +
+	"\n" +
+	"ROLE_OWNER\x10\x03B Z\x1etodo/internal/gen/todo/v1;todob\x06proto3"
+
+var (
+	file_todo_v1_sharing_proto_rawDescOnce sync.Once
+	file_todo_v1_sharing_proto_rawDescData []byte
+)
+
+func file_todo_v1_sharing_proto_rawDescGZIP() []byte {
+	file_todo_v1_sharing_proto_rawDescOnce.Do(func() {
+		file_todo_v1_sharing_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_todo_v1_sharing_proto_rawDesc), len(file_todo_v1_sharing_proto_rawDesc)))
+	})
+	return file_todo_v1_sharing_proto_rawDescData
+}
+
+var file_todo_v1_sharing_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_todo_v1_sharing_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_todo_v1_sharing_proto_goTypes = []any{
+	(Role)(0),            // 0: todo.v1.Role
+	(*Collaborator)(nil), // 1: todo.v1.Collaborator
+}
+var file_todo_v1_sharing_proto_depIdxs = []int32{
+	0, // 0: todo.v1.Collaborator.role:type_name -> todo.v1.Role
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_todo_v1_sharing_proto_init() }
+func file_todo_v1_sharing_proto_init() {
+	if File_todo_v1_sharing_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_todo_v1_sharing_proto_rawDesc), len(file_todo_v1_sharing_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_todo_v1_sharing_proto_goTypes,
+		DependencyIndexes: file_todo_v1_sharing_proto_depIdxs,
+		EnumInfos:         file_todo_v1_sharing_proto_enumTypes,
+		MessageInfos:      file_todo_v1_sharing_proto_msgTypes,
+	}.Build()
+	File_todo_v1_sharing_proto = out.File
+	file_todo_v1_sharing_proto_goTypes = nil
+	file_todo_v1_sharing_proto_depIdxs = nil
+}
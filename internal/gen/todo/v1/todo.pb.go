@@ -9,6 +9,8 @@ package todo
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -21,6 +23,461 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Приоритет задачи.
+type Priority int32
+
+const (
+	// Приоритет не задан.
+	Priority_PRIORITY_NONE Priority = 0
+	// Низкий приоритет.
+	Priority_PRIORITY_LOW Priority = 1
+	// Средний приоритет.
+	Priority_PRIORITY_MEDIUM Priority = 2
+	// Высокий приоритет.
+	Priority_PRIORITY_HIGH Priority = 3
+	// Срочная задача.
+	Priority_PRIORITY_URGENT Priority = 4
+)
+
+// Enum value maps for Priority.
+var (
+	Priority_name = map[int32]string{
+		0: "PRIORITY_NONE",
+		1: "PRIORITY_LOW",
+		2: "PRIORITY_MEDIUM",
+		3: "PRIORITY_HIGH",
+		4: "PRIORITY_URGENT",
+	}
+	Priority_value = map[string]int32{
+		"PRIORITY_NONE":   0,
+		"PRIORITY_LOW":    1,
+		"PRIORITY_MEDIUM": 2,
+		"PRIORITY_HIGH":   3,
+		"PRIORITY_URGENT": 4,
+	}
+)
+
+func (x Priority) Enum() *Priority {
+	p := new(Priority)
+	*p = x
+	return p
+}
+
+func (x Priority) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Priority) Descriptor() protoreflect.EnumDescriptor {
+	return file_todo_v1_todo_proto_enumTypes[0].Descriptor()
+}
+
+func (Priority) Type() protoreflect.EnumType {
+	return &file_todo_v1_todo_proto_enumTypes[0]
+}
+
+func (x Priority) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Priority.Descriptor instead.
+func (Priority) EnumDescriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{0}
+}
+
+// Поведение повторяющейся задачи при завершении.
+type RecurrenceMode int32
+
+const (
+	// Задача завершается, следующее вхождение создаётся новой задачей.
+	RecurrenceMode_RECURRENCE_MODE_SPAWN RecurrenceMode = 0
+	// Задача остаётся открытой, её сроки переносятся на следующее вхождение.
+	RecurrenceMode_RECURRENCE_MODE_ADVANCE RecurrenceMode = 1
+)
+
+// Enum value maps for RecurrenceMode.
+var (
+	RecurrenceMode_name = map[int32]string{
+		0: "RECURRENCE_MODE_SPAWN",
+		1: "RECURRENCE_MODE_ADVANCE",
+	}
+	RecurrenceMode_value = map[string]int32{
+		"RECURRENCE_MODE_SPAWN":   0,
+		"RECURRENCE_MODE_ADVANCE": 1,
+	}
+)
+
+func (x RecurrenceMode) Enum() *RecurrenceMode {
+	p := new(RecurrenceMode)
+	*p = x
+	return p
+}
+
+func (x RecurrenceMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RecurrenceMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_todo_v1_todo_proto_enumTypes[1].Descriptor()
+}
+
+func (RecurrenceMode) Type() protoreflect.EnumType {
+	return &file_todo_v1_todo_proto_enumTypes[1]
+}
+
+func (x RecurrenceMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RecurrenceMode.Descriptor instead.
+func (RecurrenceMode) EnumDescriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{1}
+}
+
+// Порядок сортировки списка задач.
+type TodoOrder int32
+
+const (
+	// Порядок по умолчанию, совпадает с TODO_ORDER_PRIORITY.
+	TodoOrder_TODO_ORDER_UNSPECIFIED TodoOrder = 0
+	// По времени создания, сначала новые.
+	TodoOrder_TODO_ORDER_CREATED_AT_DESC TodoOrder = 1
+	// По времени создания, сначала старые.
+	TodoOrder_TODO_ORDER_CREATED_AT_ASC TodoOrder = 2
+	// По времени обновления, сначала недавно изменённые.
+	TodoOrder_TODO_ORDER_UPDATED_AT_DESC TodoOrder = 3
+	// По времени обновления, сначала давно изменённые.
+	TodoOrder_TODO_ORDER_UPDATED_AT_ASC TodoOrder = 4
+	// По заголовку в алфавитном порядке.
+	TodoOrder_TODO_ORDER_TITLE_ASC TodoOrder = 5
+	// По заголовку в обратном алфавитном порядке.
+	TodoOrder_TODO_ORDER_TITLE_DESC TodoOrder = 6
+	// Сначала более приоритетные, затем с ближайшим сроком, затем новые.
+	TodoOrder_TODO_ORDER_PRIORITY TodoOrder = 7
+)
+
+// Enum value maps for TodoOrder.
+var (
+	TodoOrder_name = map[int32]string{
+		0: "TODO_ORDER_UNSPECIFIED",
+		1: "TODO_ORDER_CREATED_AT_DESC",
+		2: "TODO_ORDER_CREATED_AT_ASC",
+		3: "TODO_ORDER_UPDATED_AT_DESC",
+		4: "TODO_ORDER_UPDATED_AT_ASC",
+		5: "TODO_ORDER_TITLE_ASC",
+		6: "TODO_ORDER_TITLE_DESC",
+		7: "TODO_ORDER_PRIORITY",
+	}
+	TodoOrder_value = map[string]int32{
+		"TODO_ORDER_UNSPECIFIED":     0,
+		"TODO_ORDER_CREATED_AT_DESC": 1,
+		"TODO_ORDER_CREATED_AT_ASC":  2,
+		"TODO_ORDER_UPDATED_AT_DESC": 3,
+		"TODO_ORDER_UPDATED_AT_ASC":  4,
+		"TODO_ORDER_TITLE_ASC":       5,
+		"TODO_ORDER_TITLE_DESC":      6,
+		"TODO_ORDER_PRIORITY":        7,
+	}
+)
+
+func (x TodoOrder) Enum() *TodoOrder {
+	p := new(TodoOrder)
+	*p = x
+	return p
+}
+
+func (x TodoOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TodoOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_todo_v1_todo_proto_enumTypes[2].Descriptor()
+}
+
+func (TodoOrder) Type() protoreflect.EnumType {
+	return &file_todo_v1_todo_proto_enumTypes[2]
+}
+
+func (x TodoOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TodoOrder.Descriptor instead.
+func (TodoOrder) EnumDescriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{2}
+}
+
+// Способ отбора по нескольким меткам.
+type TagMatch int32
+
+const (
+	// Задача должна иметь хотя бы одну из меток.
+	TagMatch_TAG_MATCH_ANY TagMatch = 0
+	// Задача должна иметь все метки.
+	TagMatch_TAG_MATCH_ALL TagMatch = 1
+)
+
+// Enum value maps for TagMatch.
+var (
+	TagMatch_name = map[int32]string{
+		0: "TAG_MATCH_ANY",
+		1: "TAG_MATCH_ALL",
+	}
+	TagMatch_value = map[string]int32{
+		"TAG_MATCH_ANY": 0,
+		"TAG_MATCH_ALL": 1,
+	}
+)
+
+func (x TagMatch) Enum() *TagMatch {
+	p := new(TagMatch)
+	*p = x
+	return p
+}
+
+func (x TagMatch) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TagMatch) Descriptor() protoreflect.EnumDescriptor {
+	return file_todo_v1_todo_proto_enumTypes[3].Descriptor()
+}
+
+func (TagMatch) Type() protoreflect.EnumType {
+	return &file_todo_v1_todo_proto_enumTypes[3]
+}
+
+func (x TagMatch) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TagMatch.Descriptor instead.
+func (TagMatch) EnumDescriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{3}
+}
+
+// Отбор задач по владельцу.
+type Ownership int32
+
+const (
+	// Собственные задачи и доступные благодаря ролям.
+	Ownership_OWNERSHIP_ANY Ownership = 0
+	// Только собственные задачи вызывающего.
+	Ownership_OWNERSHIP_OWNED Ownership = 1
+	// Только задачи других пользователей, доступные вызывающему.
+	Ownership_OWNERSHIP_SHARED Ownership = 2
+)
+
+// Enum value maps for Ownership.
+var (
+	Ownership_name = map[int32]string{
+		0: "OWNERSHIP_ANY",
+		1: "OWNERSHIP_OWNED",
+		2: "OWNERSHIP_SHARED",
+	}
+	Ownership_value = map[string]int32{
+		"OWNERSHIP_ANY":    0,
+		"OWNERSHIP_OWNED":  1,
+		"OWNERSHIP_SHARED": 2,
+	}
+)
+
+func (x Ownership) Enum() *Ownership {
+	p := new(Ownership)
+	*p = x
+	return p
+}
+
+func (x Ownership) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Ownership) Descriptor() protoreflect.EnumDescriptor {
+	return file_todo_v1_todo_proto_enumTypes[4].Descriptor()
+}
+
+func (Ownership) Type() protoreflect.EnumType {
+	return &file_todo_v1_todo_proto_enumTypes[4]
+}
+
+func (x Ownership) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Ownership.Descriptor instead.
+func (Ownership) EnumDescriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{4}
+}
+
+// Вид изменения задачи.
+type TodoEventOperation int32
+
+const (
+	// Не задано.
+	TodoEventOperation_TODO_EVENT_OPERATION_UNSPECIFIED TodoEventOperation = 0
+	// Задача создана.
+	TodoEventOperation_TODO_EVENT_OPERATION_CREATE TodoEventOperation = 1
+	// Поля задачи изменены.
+	TodoEventOperation_TODO_EVENT_OPERATION_UPDATE TodoEventOperation = 2
+	// Задача перемещена в корзину.
+	TodoEventOperation_TODO_EVENT_OPERATION_DELETE TodoEventOperation = 3
+	// Задача восстановлена из корзины.
+	TodoEventOperation_TODO_EVENT_OPERATION_RESTORE TodoEventOperation = 4
+	// Задача удалена окончательно.
+	TodoEventOperation_TODO_EVENT_OPERATION_PURGE TodoEventOperation = 5
+)
+
+// Enum value maps for TodoEventOperation.
+var (
+	TodoEventOperation_name = map[int32]string{
+		0: "TODO_EVENT_OPERATION_UNSPECIFIED",
+		1: "TODO_EVENT_OPERATION_CREATE",
+		2: "TODO_EVENT_OPERATION_UPDATE",
+		3: "TODO_EVENT_OPERATION_DELETE",
+		4: "TODO_EVENT_OPERATION_RESTORE",
+		5: "TODO_EVENT_OPERATION_PURGE",
+	}
+	TodoEventOperation_value = map[string]int32{
+		"TODO_EVENT_OPERATION_UNSPECIFIED": 0,
+		"TODO_EVENT_OPERATION_CREATE":      1,
+		"TODO_EVENT_OPERATION_UPDATE":      2,
+		"TODO_EVENT_OPERATION_DELETE":      3,
+		"TODO_EVENT_OPERATION_RESTORE":     4,
+		"TODO_EVENT_OPERATION_PURGE":       5,
+	}
+)
+
+func (x TodoEventOperation) Enum() *TodoEventOperation {
+	p := new(TodoEventOperation)
+	*p = x
+	return p
+}
+
+func (x TodoEventOperation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TodoEventOperation) Descriptor() protoreflect.EnumDescriptor {
+	return file_todo_v1_todo_proto_enumTypes[5].Descriptor()
+}
+
+func (TodoEventOperation) Type() protoreflect.EnumType {
+	return &file_todo_v1_todo_proto_enumTypes[5]
+}
+
+func (x TodoEventOperation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TodoEventOperation.Descriptor instead.
+func (TodoEventOperation) EnumDescriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{5}
+}
+
+// Формат файла выгрузки и загрузки задач.
+type TransferFormat int32
+
+const (
+	TransferFormat_TRANSFER_FORMAT_UNSPECIFIED TransferFormat = 0
+	// Один JSON-объект задачи на строку.
+	TransferFormat_TRANSFER_FORMAT_NDJSON TransferFormat = 1
+	// CSV со строкой заголовка; метки записываются JSON-массивом.
+	TransferFormat_TRANSFER_FORMAT_CSV TransferFormat = 2
+)
+
+// Enum value maps for TransferFormat.
+var (
+	TransferFormat_name = map[int32]string{
+		0: "TRANSFER_FORMAT_UNSPECIFIED",
+		1: "TRANSFER_FORMAT_NDJSON",
+		2: "TRANSFER_FORMAT_CSV",
+	}
+	TransferFormat_value = map[string]int32{
+		"TRANSFER_FORMAT_UNSPECIFIED": 0,
+		"TRANSFER_FORMAT_NDJSON":      1,
+		"TRANSFER_FORMAT_CSV":         2,
+	}
+)
+
+func (x TransferFormat) Enum() *TransferFormat {
+	p := new(TransferFormat)
+	*p = x
+	return p
+}
+
+func (x TransferFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TransferFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_todo_v1_todo_proto_enumTypes[6].Descriptor()
+}
+
+func (TransferFormat) Type() protoreflect.EnumType {
+	return &file_todo_v1_todo_proto_enumTypes[6]
+}
+
+func (x TransferFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TransferFormat.Descriptor instead.
+func (TransferFormat) EnumDescriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{6}
+}
+
+// Как загрузка поступает с идентификаторами задач.
+type ImportMode int32
+
+const (
+	ImportMode_IMPORT_MODE_UNSPECIFIED ImportMode = 0
+	// Каждая строка создаёт новую задачу; id из файла не используется.
+	ImportMode_IMPORT_MODE_CREATE ImportMode = 1
+	// Строка с id изменяет эту задачу, а если её нет — создаёт задачу с этим id;
+	// строка без id создаёт новую задачу.
+	ImportMode_IMPORT_MODE_UPSERT ImportMode = 2
+)
+
+// Enum value maps for ImportMode.
+var (
+	ImportMode_name = map[int32]string{
+		0: "IMPORT_MODE_UNSPECIFIED",
+		1: "IMPORT_MODE_CREATE",
+		2: "IMPORT_MODE_UPSERT",
+	}
+	ImportMode_value = map[string]int32{
+		"IMPORT_MODE_UNSPECIFIED": 0,
+		"IMPORT_MODE_CREATE":      1,
+		"IMPORT_MODE_UPSERT":      2,
+	}
+)
+
+func (x ImportMode) Enum() *ImportMode {
+	p := new(ImportMode)
+	*p = x
+	return p
+}
+
+func (x ImportMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ImportMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_todo_v1_todo_proto_enumTypes[7].Descriptor()
+}
+
+func (ImportMode) Type() protoreflect.EnumType {
+	return &file_todo_v1_todo_proto_enumTypes[7]
+}
+
+func (x ImportMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ImportMode.Descriptor instead.
+func (ImportMode) EnumDescriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{7}
+}
+
 // Задача с основными полями и статусом выполнения.
 type Todo struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -35,7 +492,41 @@ type Todo struct {
 	// Время создания в unix timestamp.
 	CreatedAt int64 `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	// Время обновления в unix timestamp.
-	UpdatedAt     int64 `protobuf:"varint,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	UpdatedAt int64 `protobuf:"varint,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Версия задачи; увеличивается при каждом изменении.
+	Version int64 `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
+	// Время перемещения в корзину в unix timestamp; 0, если задача не удалена.
+	DeletedAt int64 `protobuf:"varint,8,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	// Срок выполнения в unix timestamp.
+	DueAt *int64 `protobuf:"varint,9,opt,name=due_at,json=dueAt,proto3,oneof" json:"due_at,omitempty"`
+	// Время начала работы в unix timestamp.
+	StartAt *int64 `protobuf:"varint,10,opt,name=start_at,json=startAt,proto3,oneof" json:"start_at,omitempty"`
+	// Задача на весь день: due_at и start_at указывают на начало дня в time_zone.
+	AllDay bool `protobuf:"varint,11,opt,name=all_day,json=allDay,proto3" json:"all_day,omitempty"`
+	// Часовой пояс IANA, в котором заданы сроки (например, Europe/Moscow).
+	TimeZone string `protobuf:"bytes,12,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	// Приоритет задачи.
+	Priority Priority `protobuf:"varint,13,opt,name=priority,proto3,enum=todo.v1.Priority" json:"priority,omitempty"`
+	// Метки задачи в нижнем регистре, по алфавиту.
+	Tags []string `protobuf:"bytes,14,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Проект задачи; пустая строка означает «Входящие».
+	ProjectId string `protobuf:"bytes,15,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// Родительская задача; пустая строка означает задачу верхнего уровня.
+	ParentId string `protobuf:"bytes,16,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	// Задачу блокирует хотя бы одна незавершённая задача.
+	Blocked bool `protobuf:"varint,17,opt,name=blocked,proto3" json:"blocked,omitempty"`
+	// Правило повторения RFC 5545 (RRULE) в каноническом виде; пусто у разовых задач.
+	Recurrence string `protobuf:"bytes,18,opt,name=recurrence,proto3" json:"recurrence,omitempty"`
+	// Поведение при завершении повторяющейся задачи.
+	RecurrenceMode RecurrenceMode `protobuf:"varint,19,opt,name=recurrence_mode,json=recurrenceMode,proto3,enum=todo.v1.RecurrenceMode" json:"recurrence_mode,omitempty"`
+	// Серия, к которой относятся все вхождения повторяющейся задачи.
+	SeriesId string `protobuf:"bytes,20,opt,name=series_id,json=seriesId,proto3" json:"series_id,omitempty"`
+	// Число комментариев к задаче.
+	CommentCount int64 `protobuf:"varint,21,opt,name=comment_count,json=commentCount,proto3" json:"comment_count,omitempty"`
+	// Пользователь, создавший задачу.
+	OwnerId string `protobuf:"bytes,22,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	// UID задачи в iCalendar; у созданных в сервисе задач совпадает с id.
+	IcalUid       string `protobuf:"bytes,23,opt,name=ical_uid,json=icalUid,proto3" json:"ical_uid,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -77,67 +568,2882 @@ func (x *Todo) GetId() string {
 	return ""
 }
 
-func (x *Todo) GetTitle() string {
-	if x != nil {
-		return x.Title
-	}
-	return ""
+func (x *Todo) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Todo) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Todo) GetCompleted() bool {
+	if x != nil {
+		return x.Completed
+	}
+	return false
+}
+
+func (x *Todo) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Todo) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *Todo) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Todo) GetDeletedAt() int64 {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return 0
+}
+
+func (x *Todo) GetDueAt() int64 {
+	if x != nil && x.DueAt != nil {
+		return *x.DueAt
+	}
+	return 0
+}
+
+func (x *Todo) GetStartAt() int64 {
+	if x != nil && x.StartAt != nil {
+		return *x.StartAt
+	}
+	return 0
+}
+
+func (x *Todo) GetAllDay() bool {
+	if x != nil {
+		return x.AllDay
+	}
+	return false
+}
+
+func (x *Todo) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *Todo) GetPriority() Priority {
+	if x != nil {
+		return x.Priority
+	}
+	return Priority_PRIORITY_NONE
+}
+
+func (x *Todo) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Todo) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *Todo) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *Todo) GetBlocked() bool {
+	if x != nil {
+		return x.Blocked
+	}
+	return false
+}
+
+func (x *Todo) GetRecurrence() string {
+	if x != nil {
+		return x.Recurrence
+	}
+	return ""
+}
+
+func (x *Todo) GetRecurrenceMode() RecurrenceMode {
+	if x != nil {
+		return x.RecurrenceMode
+	}
+	return RecurrenceMode_RECURRENCE_MODE_SPAWN
+}
+
+func (x *Todo) GetSeriesId() string {
+	if x != nil {
+		return x.SeriesId
+	}
+	return ""
+}
+
+func (x *Todo) GetCommentCount() int64 {
+	if x != nil {
+		return x.CommentCount
+	}
+	return 0
+}
+
+func (x *Todo) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *Todo) GetIcalUid() string {
+	if x != nil {
+		return x.IcalUid
+	}
+	return ""
+}
+
+// Запрос на создание новой задачи.
+type CreateTodoRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Заголовок задачи.
+	Title string `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	// Подробное описание.
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// Срок выполнения в unix timestamp.
+	DueAt *int64 `protobuf:"varint,3,opt,name=due_at,json=dueAt,proto3,oneof" json:"due_at,omitempty"`
+	// Время начала работы в unix timestamp; должно быть раньше due_at.
+	StartAt *int64 `protobuf:"varint,4,opt,name=start_at,json=startAt,proto3,oneof" json:"start_at,omitempty"`
+	// Задача на весь день: время в due_at и start_at отбрасывается.
+	AllDay bool `protobuf:"varint,5,opt,name=all_day,json=allDay,proto3" json:"all_day,omitempty"`
+	// Часовой пояс IANA; по умолчанию UTC.
+	TimeZone string `protobuf:"bytes,6,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	// Приоритет задачи.
+	Priority Priority `protobuf:"varint,7,opt,name=priority,proto3,enum=todo.v1.Priority" json:"priority,omitempty"`
+	// Метки задачи; регистр не учитывается.
+	Tags []string `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Проект задачи; пустая строка означает «Входящие».
+	ProjectId string `protobuf:"bytes,9,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// Родительская задача; пустая строка означает задачу верхнего уровня.
+	ParentId string `protobuf:"bytes,10,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	// Правило повторения RFC 5545, например "FREQ=WEEKLY;BYDAY=MO"; требует due_at.
+	Recurrence string `protobuf:"bytes,11,opt,name=recurrence,proto3" json:"recurrence,omitempty"`
+	// Поведение при завершении повторяющейся задачи.
+	RecurrenceMode RecurrenceMode `protobuf:"varint,12,opt,name=recurrence_mode,json=recurrenceMode,proto3,enum=todo.v1.RecurrenceMode" json:"recurrence_mode,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateTodoRequest) Reset() {
+	*x = CreateTodoRequest{}
+	mi := &file_todo_v1_todo_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTodoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTodoRequest) ProtoMessage() {}
+
+func (x *CreateTodoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTodoRequest.ProtoReflect.Descriptor instead.
+func (*CreateTodoRequest) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateTodoRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateTodoRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateTodoRequest) GetDueAt() int64 {
+	if x != nil && x.DueAt != nil {
+		return *x.DueAt
+	}
+	return 0
+}
+
+func (x *CreateTodoRequest) GetStartAt() int64 {
+	if x != nil && x.StartAt != nil {
+		return *x.StartAt
+	}
+	return 0
+}
+
+func (x *CreateTodoRequest) GetAllDay() bool {
+	if x != nil {
+		return x.AllDay
+	}
+	return false
+}
+
+func (x *CreateTodoRequest) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *CreateTodoRequest) GetPriority() Priority {
+	if x != nil {
+		return x.Priority
+	}
+	return Priority_PRIORITY_NONE
+}
+
+func (x *CreateTodoRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *CreateTodoRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *CreateTodoRequest) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *CreateTodoRequest) GetRecurrence() string {
+	if x != nil {
+		return x.Recurrence
+	}
+	return ""
+}
+
+func (x *CreateTodoRequest) GetRecurrenceMode() RecurrenceMode {
+	if x != nil {
+		return x.RecurrenceMode
+	}
+	return RecurrenceMode_RECURRENCE_MODE_SPAWN
+}
+
+// Ответ с созданной задачей.
+type CreateTodoResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Созданная задача.
+	Todo          *Todo `protobuf:"bytes,1,opt,name=todo,proto3" json:"todo,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTodoResponse) Reset() {
+	*x = CreateTodoResponse{}
+	mi := &file_todo_v1_todo_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTodoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTodoResponse) ProtoMessage() {}
+
+func (x *CreateTodoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTodoResponse.ProtoReflect.Descriptor instead.
+func (*CreateTodoResponse) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateTodoResponse) GetTodo() *Todo {
+	if x != nil {
+		return x.Todo
+	}
+	return nil
+}
+
+// Запрос на получение задачи по идентификатору.
+type GetTodoRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Уникальный идентификатор задачи.
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTodoRequest) Reset() {
+	*x = GetTodoRequest{}
+	mi := &file_todo_v1_todo_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTodoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTodoRequest) ProtoMessage() {}
+
+func (x *GetTodoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTodoRequest.ProtoReflect.Descriptor instead.
+func (*GetTodoRequest) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetTodoRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Условия отбора задач. Пустые поля не ограничивают выборку.
+type TodoFilter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Отбор по признаку завершения.
+	Completed *bool `protobuf:"varint,1,opt,name=completed,proto3,oneof" json:"completed,omitempty"`
+	// Создана не раньше указанного unix timestamp (включительно).
+	CreatedAfter int64 `protobuf:"varint,2,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	// Создана раньше указанного unix timestamp.
+	CreatedBefore int64 `protobuf:"varint,3,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	// Обновлена не раньше указанного unix timestamp (включительно).
+	UpdatedAfter int64 `protobuf:"varint,4,opt,name=updated_after,json=updatedAfter,proto3" json:"updated_after,omitempty"`
+	// Обновлена раньше указанного unix timestamp.
+	UpdatedBefore int64 `protobuf:"varint,5,opt,name=updated_before,json=updatedBefore,proto3" json:"updated_before,omitempty"`
+	// Заголовок начинается с указанной строки (с учётом регистра).
+	TitlePrefix string `protobuf:"bytes,6,opt,name=title_prefix,json=titlePrefix,proto3" json:"title_prefix,omitempty"`
+	// Только незавершённые задачи с истёкшим сроком.
+	Overdue bool `protobuf:"varint,7,opt,name=overdue,proto3" json:"overdue,omitempty"`
+	// Только задачи со сроком на сегодня.
+	DueToday bool `protobuf:"varint,8,opt,name=due_today,json=dueToday,proto3" json:"due_today,omitempty"`
+	// Только задачи со сроком в ближайшие N дней, считая сегодняшний.
+	DueWithinDays int32 `protobuf:"varint,9,opt,name=due_within_days,json=dueWithinDays,proto3" json:"due_within_days,omitempty"`
+	// Часовой пояс IANA, задающий границы «сегодня»; по умолчанию UTC.
+	TimeZone string `protobuf:"bytes,10,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	// Метки задачи; регистр не учитывается.
+	Tags []string `protobuf:"bytes,11,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Требовать любую из меток или все сразу.
+	TagMatch TagMatch `protobuf:"varint,12,opt,name=tag_match,json=tagMatch,proto3,enum=todo.v1.TagMatch" json:"tag_match,omitempty"`
+	// Только задачи проекта; пустая строка отбирает задачи из «Входящих».
+	ProjectId *string `protobuf:"bytes,13,opt,name=project_id,json=projectId,proto3,oneof" json:"project_id,omitempty"`
+	// Только подзадачи указанной задачи; пустая строка отбирает задачи верхнего уровня.
+	ParentId *string `protobuf:"bytes,14,opt,name=parent_id,json=parentId,proto3,oneof" json:"parent_id,omitempty"`
+	// Только задачи, которые не заблокированы другими.
+	Actionable bool `protobuf:"varint,15,opt,name=actionable,proto3" json:"actionable,omitempty"`
+	// Только вхождения указанной серии повторяющейся задачи.
+	SeriesId string `protobuf:"bytes,16,opt,name=series_id,json=seriesId,proto3" json:"series_id,omitempty"`
+	// Отбор по владельцу.
+	Ownership     Ownership `protobuf:"varint,17,opt,name=ownership,proto3,enum=todo.v1.Ownership" json:"ownership,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TodoFilter) Reset() {
+	*x = TodoFilter{}
+	mi := &file_todo_v1_todo_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TodoFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TodoFilter) ProtoMessage() {}
+
+func (x *TodoFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TodoFilter.ProtoReflect.Descriptor instead.
+func (*TodoFilter) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TodoFilter) GetCompleted() bool {
+	if x != nil && x.Completed != nil {
+		return *x.Completed
+	}
+	return false
+}
+
+func (x *TodoFilter) GetCreatedAfter() int64 {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return 0
+}
+
+func (x *TodoFilter) GetCreatedBefore() int64 {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return 0
+}
+
+func (x *TodoFilter) GetUpdatedAfter() int64 {
+	if x != nil {
+		return x.UpdatedAfter
+	}
+	return 0
+}
+
+func (x *TodoFilter) GetUpdatedBefore() int64 {
+	if x != nil {
+		return x.UpdatedBefore
+	}
+	return 0
+}
+
+func (x *TodoFilter) GetTitlePrefix() string {
+	if x != nil {
+		return x.TitlePrefix
+	}
+	return ""
+}
+
+func (x *TodoFilter) GetOverdue() bool {
+	if x != nil {
+		return x.Overdue
+	}
+	return false
+}
+
+func (x *TodoFilter) GetDueToday() bool {
+	if x != nil {
+		return x.DueToday
+	}
+	return false
+}
+
+func (x *TodoFilter) GetDueWithinDays() int32 {
+	if x != nil {
+		return x.DueWithinDays
+	}
+	return 0
+}
+
+func (x *TodoFilter) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *TodoFilter) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *TodoFilter) GetTagMatch() TagMatch {
+	if x != nil {
+		return x.TagMatch
+	}
+	return TagMatch_TAG_MATCH_ANY
+}
+
+func (x *TodoFilter) GetProjectId() string {
+	if x != nil && x.ProjectId != nil {
+		return *x.ProjectId
+	}
+	return ""
+}
+
+func (x *TodoFilter) GetParentId() string {
+	if x != nil && x.ParentId != nil {
+		return *x.ParentId
+	}
+	return ""
+}
+
+func (x *TodoFilter) GetActionable() bool {
+	if x != nil {
+		return x.Actionable
+	}
+	return false
+}
+
+func (x *TodoFilter) GetSeriesId() string {
+	if x != nil {
+		return x.SeriesId
+	}
+	return ""
+}
+
+func (x *TodoFilter) GetOwnership() Ownership {
+	if x != nil {
+		return x.Ownership
+	}
+	return Ownership_OWNERSHIP_ANY
+}
+
+// Запрос страницы списка задач.
+type ListTodosRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Максимальное число задач на странице; 0 означает значение по умолчанию.
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Курсор, полученный в next_page_token предыдущего ответа. Действителен
+	// только с теми же фильтром и порядком, иначе возвращается INVALID_ARGUMENT.
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Условия отбора.
+	Filter *TodoFilter `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	// Порядок сортировки.
+	OrderBy       TodoOrder `protobuf:"varint,4,opt,name=order_by,json=orderBy,proto3,enum=todo.v1.TodoOrder" json:"order_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTodosRequest) Reset() {
+	*x = ListTodosRequest{}
+	mi := &file_todo_v1_todo_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTodosRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTodosRequest) ProtoMessage() {}
+
+func (x *ListTodosRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTodosRequest.ProtoReflect.Descriptor instead.
+func (*ListTodosRequest) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListTodosRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTodosRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListTodosRequest) GetFilter() *TodoFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ListTodosRequest) GetOrderBy() TodoOrder {
+	if x != nil {
+		return x.OrderBy
+	}
+	return TodoOrder_TODO_ORDER_UNSPECIFIED
+}
+
+// Ответ со страницей задач.
+type ListTodosResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Коллекция найденных задач.
+	Todos []*Todo `protobuf:"bytes,1,rep,name=todos,proto3" json:"todos,omitempty"`
+	// Курсор следующей страницы; пустой, если страниц больше нет.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTodosResponse) Reset() {
+	*x = ListTodosResponse{}
+	mi := &file_todo_v1_todo_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTodosResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTodosResponse) ProtoMessage() {}
+
+func (x *ListTodosResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTodosResponse.ProtoReflect.Descriptor instead.
+func (*ListTodosResponse) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListTodosResponse) GetTodos() []*Todo {
+	if x != nil {
+		return x.Todos
+	}
+	return nil
+}
+
+func (x *ListTodosResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Запрос на обновление существующей задачи.
+type UpdateTodoRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Уникальный идентификатор задачи.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Новый заголовок.
+	Title string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	// Новое описание.
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Новый статус завершения.
+	Completed bool `protobuf:"varint,4,opt,name=completed,proto3" json:"completed,omitempty"`
+	// Изменяемые поля: title, description, completed, due_at, start_at, all_day, time_zone,
+	// priority, project_id, parent_id, recurrence, recurrence_mode, tags.
+	// Пустая маска означает title, description и completed.
+	UpdateMask *fieldmaskpb.FieldMask `protobuf:"bytes,5,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	// Ожидаемая текущая версия задачи; 0 отключает проверку.
+	ExpectedVersion int64 `protobuf:"varint,6,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	// Новый срок выполнения; если поле в маске, но не задано, срок снимается.
+	DueAt *int64 `protobuf:"varint,7,opt,name=due_at,json=dueAt,proto3,oneof" json:"due_at,omitempty"`
+	// Новое время начала; если поле в маске, но не задано, время снимается.
+	StartAt *int64 `protobuf:"varint,8,opt,name=start_at,json=startAt,proto3,oneof" json:"start_at,omitempty"`
+	// Новый признак задачи на весь день.
+	AllDay bool `protobuf:"varint,9,opt,name=all_day,json=allDay,proto3" json:"all_day,omitempty"`
+	// Новый часовой пояс IANA.
+	TimeZone string `protobuf:"bytes,10,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	// Новый приоритет.
+	Priority Priority `protobuf:"varint,11,opt,name=priority,proto3,enum=todo.v1.Priority" json:"priority,omitempty"`
+	// Проект, в который переносится задача; пустая строка означает «Входящие».
+	ProjectId string `protobuf:"bytes,12,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// Новая родительская задача; пустая строка делает задачу задачей верхнего уровня.
+	ParentId string `protobuf:"bytes,13,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	// Новое правило повторения; пустая строка делает задачу разовой.
+	Recurrence string `protobuf:"bytes,14,opt,name=recurrence,proto3" json:"recurrence,omitempty"`
+	// Новое поведение при завершении повторяющейся задачи.
+	RecurrenceMode RecurrenceMode `protobuf:"varint,15,opt,name=recurrence_mode,json=recurrenceMode,proto3,enum=todo.v1.RecurrenceMode" json:"recurrence_mode,omitempty"`
+	// Новые метки; заменяют текущие, регистр не учитывается.
+	Tags          []string `protobuf:"bytes,16,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTodoRequest) Reset() {
+	*x = UpdateTodoRequest{}
+	mi := &file_todo_v1_todo_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTodoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTodoRequest) ProtoMessage() {}
+
+func (x *UpdateTodoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTodoRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTodoRequest) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpdateTodoRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateTodoRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *UpdateTodoRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateTodoRequest) GetCompleted() bool {
+	if x != nil {
+		return x.Completed
+	}
+	return false
+}
+
+func (x *UpdateTodoRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *UpdateTodoRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *UpdateTodoRequest) GetDueAt() int64 {
+	if x != nil && x.DueAt != nil {
+		return *x.DueAt
+	}
+	return 0
+}
+
+func (x *UpdateTodoRequest) GetStartAt() int64 {
+	if x != nil && x.StartAt != nil {
+		return *x.StartAt
+	}
+	return 0
+}
+
+func (x *UpdateTodoRequest) GetAllDay() bool {
+	if x != nil {
+		return x.AllDay
+	}
+	return false
+}
+
+func (x *UpdateTodoRequest) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *UpdateTodoRequest) GetPriority() Priority {
+	if x != nil {
+		return x.Priority
+	}
+	return Priority_PRIORITY_NONE
+}
+
+func (x *UpdateTodoRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *UpdateTodoRequest) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *UpdateTodoRequest) GetRecurrence() string {
+	if x != nil {
+		return x.Recurrence
+	}
+	return ""
+}
+
+func (x *UpdateTodoRequest) GetRecurrenceMode() RecurrenceMode {
+	if x != nil {
+		return x.RecurrenceMode
+	}
+	return RecurrenceMode_RECURRENCE_MODE_SPAWN
+}
+
+func (x *UpdateTodoRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// Запрос на удаление задачи.
+type DeleteTodoRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Уникальный идентификатор задачи.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Ожидаемая текущая версия задачи; 0 отключает проверку.
+	ExpectedVersion int64 `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeleteTodoRequest) Reset() {
+	*x = DeleteTodoRequest{}
+	mi := &file_todo_v1_todo_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTodoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTodoRequest) ProtoMessage() {}
+
+func (x *DeleteTodoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTodoRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTodoRequest) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteTodoRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteTodoRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+// Ответ на удаление задачи (пустой).
+type DeleteTodoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTodoResponse) Reset() {
+	*x = DeleteTodoResponse{}
+	mi := &file_todo_v1_todo_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTodoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTodoResponse) ProtoMessage() {}
+
+func (x *DeleteTodoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTodoResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTodoResponse) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{9}
+}
+
+// Запрос на восстановление задачи из корзины.
+type RestoreTodoRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Уникальный идентификатор задачи.
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreTodoRequest) Reset() {
+	*x = RestoreTodoRequest{}
+	mi := &file_todo_v1_todo_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreTodoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreTodoRequest) ProtoMessage() {}
+
+func (x *RestoreTodoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreTodoRequest.ProtoReflect.Descriptor instead.
+func (*RestoreTodoRequest) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RestoreTodoRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Запрос страницы задач из корзины.
+type ListTrashRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Максимальное число задач на странице; 0 означает значение по умолчанию.
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Курсор, полученный в next_page_token предыдущего ответа.
+	PageToken     string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTrashRequest) Reset() {
+	*x = ListTrashRequest{}
+	mi := &file_todo_v1_todo_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTrashRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrashRequest) ProtoMessage() {}
+
+func (x *ListTrashRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrashRequest.ProtoReflect.Descriptor instead.
+func (*ListTrashRequest) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListTrashRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTrashRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// Ответ со страницей задач из корзины.
+type ListTrashResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Задачи в корзине.
+	Todos []*Todo `protobuf:"bytes,1,rep,name=todos,proto3" json:"todos,omitempty"`
+	// Курсор следующей страницы; пустой, если страниц больше нет.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTrashResponse) Reset() {
+	*x = ListTrashResponse{}
+	mi := &file_todo_v1_todo_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTrashResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrashResponse) ProtoMessage() {}
+
+func (x *ListTrashResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrashResponse.ProtoReflect.Descriptor instead.
+func (*ListTrashResponse) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListTrashResponse) GetTodos() []*Todo {
+	if x != nil {
+		return x.Todos
+	}
+	return nil
+}
+
+func (x *ListTrashResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Запрос на окончательное удаление задачи из корзины.
+type PurgeTodoRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Уникальный идентификатор задачи.
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeTodoRequest) Reset() {
+	*x = PurgeTodoRequest{}
+	mi := &file_todo_v1_todo_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeTodoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeTodoRequest) ProtoMessage() {}
+
+func (x *PurgeTodoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeTodoRequest.ProtoReflect.Descriptor instead.
+func (*PurgeTodoRequest) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PurgeTodoRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Ответ на окончательное удаление задачи (пустой).
+type PurgeTodoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeTodoResponse) Reset() {
+	*x = PurgeTodoResponse{}
+	mi := &file_todo_v1_todo_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeTodoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeTodoResponse) ProtoMessage() {}
+
+func (x *PurgeTodoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeTodoResponse.ProtoReflect.Descriptor instead.
+func (*PurgeTodoResponse) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{14}
+}
+
+// Запрос на добавление меток задаче.
+type AddTagsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Уникальный идентификатор задачи.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Добавляемые метки; регистр не учитывается.
+	Tags          []string `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddTagsRequest) Reset() {
+	*x = AddTagsRequest{}
+	mi := &file_todo_v1_todo_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTagsRequest) ProtoMessage() {}
+
+func (x *AddTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTagsRequest.ProtoReflect.Descriptor instead.
+func (*AddTagsRequest) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *AddTagsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AddTagsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// Запрос на снятие меток с задачи.
+type RemoveTagsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Уникальный идентификатор задачи.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Снимаемые метки; регистр не учитывается.
+	Tags          []string `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveTagsRequest) Reset() {
+	*x = RemoveTagsRequest{}
+	mi := &file_todo_v1_todo_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveTagsRequest) ProtoMessage() {}
+
+func (x *RemoveTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveTagsRequest.ProtoReflect.Descriptor instead.
+func (*RemoveTagsRequest) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RemoveTagsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RemoveTagsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// Запрос списка меток.
+type ListTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTagsRequest) Reset() {
+	*x = ListTagsRequest{}
+	mi := &file_todo_v1_todo_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagsRequest) ProtoMessage() {}
+
+func (x *ListTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagsRequest.ProtoReflect.Descriptor instead.
+func (*ListTagsRequest) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{17}
+}
+
+// Метка и число задач с ней.
+type Tag struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Имя метки в нижнем регистре.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Число задач вне корзины с этой меткой.
+	TodoCount     int64 `protobuf:"varint,2,opt,name=todo_count,json=todoCount,proto3" json:"todo_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tag) Reset() {
+	*x = Tag{}
+	mi := &file_todo_v1_todo_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tag) ProtoMessage() {}
+
+func (x *Tag) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tag.ProtoReflect.Descriptor instead.
+func (*Tag) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Tag) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Tag) GetTodoCount() int64 {
+	if x != nil {
+		return x.TodoCount
+	}
+	return 0
+}
+
+// Ответ со списком меток.
+type ListTagsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Метки в алфавитном порядке.
+	Tags          []*Tag `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTagsResponse) Reset() {
+	*x = ListTagsResponse{}
+	mi := &file_todo_v1_todo_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagsResponse) ProtoMessage() {}
+
+func (x *ListTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagsResponse.ProtoReflect.Descriptor instead.
+func (*ListTagsResponse) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListTagsResponse) GetTags() []*Tag {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// Запрос дерева задачи.
+type GetTodoTreeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Идентификатор корневой задачи.
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTodoTreeRequest) Reset() {
+	*x = GetTodoTreeRequest{}
+	mi := &file_todo_v1_todo_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTodoTreeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTodoTreeRequest) ProtoMessage() {}
+
+func (x *GetTodoTreeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTodoTreeRequest.ProtoReflect.Descriptor instead.
+func (*GetTodoTreeRequest) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetTodoTreeRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Задача с подзадачами и сводкой по их завершению.
+type TodoNode struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Задача.
+	Todo *Todo `protobuf:"bytes,1,opt,name=todo,proto3" json:"todo,omitempty"`
+	// Непосредственные подзадачи в порядке создания.
+	Children []*TodoNode `protobuf:"bytes,2,rep,name=children,proto3" json:"children,omitempty"`
+	// Число завершённых подзадач на всех уровнях.
+	CompletedCount int32 `protobuf:"varint,3,opt,name=completed_count,json=completedCount,proto3" json:"completed_count,omitempty"`
+	// Общее число подзадач на всех уровнях.
+	TotalCount    int32 `protobuf:"varint,4,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TodoNode) Reset() {
+	*x = TodoNode{}
+	mi := &file_todo_v1_todo_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TodoNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TodoNode) ProtoMessage() {}
+
+func (x *TodoNode) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TodoNode.ProtoReflect.Descriptor instead.
+func (*TodoNode) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *TodoNode) GetTodo() *Todo {
+	if x != nil {
+		return x.Todo
+	}
+	return nil
+}
+
+func (x *TodoNode) GetChildren() []*TodoNode {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+func (x *TodoNode) GetCompletedCount() int32 {
+	if x != nil {
+		return x.CompletedCount
+	}
+	return 0
+}
+
+func (x *TodoNode) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+// Запрос на добавление зависимости.
+type AddDependencyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Идентификатор заблокированной задачи.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Идентификатор задачи, которую нужно завершить раньше.
+	BlockerId     string `protobuf:"bytes,2,opt,name=blocker_id,json=blockerId,proto3" json:"blocker_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddDependencyRequest) Reset() {
+	*x = AddDependencyRequest{}
+	mi := &file_todo_v1_todo_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddDependencyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddDependencyRequest) ProtoMessage() {}
+
+func (x *AddDependencyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddDependencyRequest.ProtoReflect.Descriptor instead.
+func (*AddDependencyRequest) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *AddDependencyRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AddDependencyRequest) GetBlockerId() string {
+	if x != nil {
+		return x.BlockerId
+	}
+	return ""
+}
+
+// Запрос на снятие зависимости.
+type RemoveDependencyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Идентификатор заблокированной задачи.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Идентификатор блокирующей задачи.
+	BlockerId     string `protobuf:"bytes,2,opt,name=blocker_id,json=blockerId,proto3" json:"blocker_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveDependencyRequest) Reset() {
+	*x = RemoveDependencyRequest{}
+	mi := &file_todo_v1_todo_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveDependencyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveDependencyRequest) ProtoMessage() {}
+
+func (x *RemoveDependencyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveDependencyRequest.ProtoReflect.Descriptor instead.
+func (*RemoveDependencyRequest) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *RemoveDependencyRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RemoveDependencyRequest) GetBlockerId() string {
+	if x != nil {
+		return x.BlockerId
+	}
+	return ""
+}
+
+// Запрос на выдачу роли на задачу.
+type ShareTodoRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Уникальный идентификатор задачи.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Пользователь, которому выдаётся роль.
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Выдаваемая роль.
+	Role          Role `protobuf:"varint,3,opt,name=role,proto3,enum=todo.v1.Role" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareTodoRequest) Reset() {
+	*x = ShareTodoRequest{}
+	mi := &file_todo_v1_todo_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareTodoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareTodoRequest) ProtoMessage() {}
+
+func (x *ShareTodoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareTodoRequest.ProtoReflect.Descriptor instead.
+func (*ShareTodoRequest) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ShareTodoRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ShareTodoRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ShareTodoRequest) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+// Запрос на отзыв роли на задачу.
+type UnshareTodoRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Уникальный идентификатор задачи.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Пользователь, роль которого отзывается.
+	UserId        string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnshareTodoRequest) Reset() {
+	*x = UnshareTodoRequest{}
+	mi := &file_todo_v1_todo_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnshareTodoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnshareTodoRequest) ProtoMessage() {}
+
+func (x *UnshareTodoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnshareTodoRequest.ProtoReflect.Descriptor instead.
+func (*UnshareTodoRequest) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *UnshareTodoRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UnshareTodoRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// Ответ на отзыв роли (пустой).
+type UnshareTodoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnshareTodoResponse) Reset() {
+	*x = UnshareTodoResponse{}
+	mi := &file_todo_v1_todo_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnshareTodoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnshareTodoResponse) ProtoMessage() {}
+
+func (x *UnshareTodoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnshareTodoResponse.ProtoReflect.Descriptor instead.
+func (*UnshareTodoResponse) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{26}
+}
+
+// Запрос участников задачи.
+type ListCollaboratorsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Уникальный идентификатор задачи.
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCollaboratorsRequest) Reset() {
+	*x = ListCollaboratorsRequest{}
+	mi := &file_todo_v1_todo_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCollaboratorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCollaboratorsRequest) ProtoMessage() {}
+
+func (x *ListCollaboratorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCollaboratorsRequest.ProtoReflect.Descriptor instead.
+func (*ListCollaboratorsRequest) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListCollaboratorsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Ответ со списком участников.
+type ListCollaboratorsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Владелец, затем пользователи в порядке выдачи ролей.
+	Collaborators []*Collaborator `protobuf:"bytes,1,rep,name=collaborators,proto3" json:"collaborators,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCollaboratorsResponse) Reset() {
+	*x = ListCollaboratorsResponse{}
+	mi := &file_todo_v1_todo_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCollaboratorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCollaboratorsResponse) ProtoMessage() {}
+
+func (x *ListCollaboratorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCollaboratorsResponse.ProtoReflect.Descriptor instead.
+func (*ListCollaboratorsResponse) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListCollaboratorsResponse) GetCollaborators() []*Collaborator {
+	if x != nil {
+		return x.Collaborators
+	}
+	return nil
+}
+
+// Изменение одного поля задачи.
+type FieldChange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Имя поля, например title или due_at.
+	Field string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	// Значение до изменения; null для пустого значения.
+	Before *structpb.Value `protobuf:"bytes,2,opt,name=before,proto3" json:"before,omitempty"`
+	// Значение после изменения; null для пустого значения.
+	After         *structpb.Value `protobuf:"bytes,3,opt,name=after,proto3" json:"after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldChange) Reset() {
+	*x = FieldChange{}
+	mi := &file_todo_v1_todo_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldChange) ProtoMessage() {}
+
+func (x *FieldChange) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldChange.ProtoReflect.Descriptor instead.
+func (*FieldChange) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *FieldChange) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *FieldChange) GetBefore() *structpb.Value {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+func (x *FieldChange) GetAfter() *structpb.Value {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+// Запись журнала изменений задачи.
+type TodoEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Порядковый номер события; возрастает со временем.
+	Seq int64 `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	// Идентификатор задачи.
+	TodoId string `protobuf:"bytes,2,opt,name=todo_id,json=todoId,proto3" json:"todo_id,omitempty"`
+	// Пользователь, внёсший изменение.
+	Actor string `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	// Вид изменения.
+	Operation TodoEventOperation `protobuf:"varint,4,opt,name=operation,proto3,enum=todo.v1.TodoEventOperation" json:"operation,omitempty"`
+	// Изменённые поля в алфавитном порядке; при создании — заданные.
+	Changes []*FieldChange `protobuf:"bytes,5,rep,name=changes,proto3" json:"changes,omitempty"`
+	// Время изменения в unix timestamp.
+	CreatedAt     int64 `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TodoEvent) Reset() {
+	*x = TodoEvent{}
+	mi := &file_todo_v1_todo_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TodoEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TodoEvent) ProtoMessage() {}
+
+func (x *TodoEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TodoEvent.ProtoReflect.Descriptor instead.
+func (*TodoEvent) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *TodoEvent) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *TodoEvent) GetTodoId() string {
+	if x != nil {
+		return x.TodoId
+	}
+	return ""
+}
+
+func (x *TodoEvent) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *TodoEvent) GetOperation() TodoEventOperation {
+	if x != nil {
+		return x.Operation
+	}
+	return TodoEventOperation_TODO_EVENT_OPERATION_UNSPECIFIED
+}
+
+func (x *TodoEvent) GetChanges() []*FieldChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *TodoEvent) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+// Запрос страницы журнала изменений задачи.
+type ListTodoHistoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Уникальный идентификатор задачи.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Максимальное число событий на странице; 0 означает значение по умолчанию.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Курсор, полученный в next_page_token предыдущего ответа.
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTodoHistoryRequest) Reset() {
+	*x = ListTodoHistoryRequest{}
+	mi := &file_todo_v1_todo_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTodoHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTodoHistoryRequest) ProtoMessage() {}
+
+func (x *ListTodoHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTodoHistoryRequest.ProtoReflect.Descriptor instead.
+func (*ListTodoHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListTodoHistoryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ListTodoHistoryRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTodoHistoryRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// Страница журнала изменений задачи.
+type ListTodoHistoryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// События от новых к старым.
+	Events []*TodoEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	// Курсор следующей страницы; пустой, если страниц больше нет.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTodoHistoryResponse) Reset() {
+	*x = ListTodoHistoryResponse{}
+	mi := &file_todo_v1_todo_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTodoHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTodoHistoryResponse) ProtoMessage() {}
+
+func (x *ListTodoHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTodoHistoryResponse.ProtoReflect.Descriptor instead.
+func (*ListTodoHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListTodoHistoryResponse) GetEvents() []*TodoEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *ListTodoHistoryResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Запрос на подписку на изменения задач.
+type WatchTodosRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Условия отбора, как у ListTodos; относительные сроки вычисляются при подписке.
+	Filter *TodoFilter `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	// Продолжить после события с этим номером; 0 — только новые изменения.
+	AfterSeq      int64 `protobuf:"varint,2,opt,name=after_seq,json=afterSeq,proto3" json:"after_seq,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchTodosRequest) Reset() {
+	*x = WatchTodosRequest{}
+	mi := &file_todo_v1_todo_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchTodosRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchTodosRequest) ProtoMessage() {}
+
+func (x *WatchTodosRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchTodosRequest.ProtoReflect.Descriptor instead.
+func (*WatchTodosRequest) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *WatchTodosRequest) GetFilter() *TodoFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *WatchTodosRequest) GetAfterSeq() int64 {
+	if x != nil {
+		return x.AfterSeq
+	}
+	return 0
+}
+
+// Изменение задачи в подписке.
+type WatchTodosResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Номер события; передайте его в after_seq, чтобы продолжить после переподключения.
+	Seq int64 `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	// Вид изменения.
+	Operation TodoEventOperation `protobuf:"varint,2,opt,name=operation,proto3,enum=todo.v1.TodoEventOperation" json:"operation,omitempty"`
+	// Задача в текущем состоянии, которое может быть новее события;
+	// не задана, если задача удалена окончательно или больше не видна.
+	Todo *Todo `protobuf:"bytes,3,opt,name=todo,proto3" json:"todo,omitempty"`
+	// Событие журнала с изменёнными им полями.
+	Event *TodoEvent `protobuf:"bytes,4,opt,name=event,proto3" json:"event,omitempty"`
+	// Задача перестала подходить под фильтр подписки, стала невидимой или
+	// удалена окончательно; следующих её изменений подписка не передаёт,
+	// пока задача снова не подойдёт под фильтр. В таком ответе заданы только
+	// seq и идентификатор задачи в event.
+	LeftFilter    bool `protobuf:"varint,5,opt,name=left_filter,json=leftFilter,proto3" json:"left_filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchTodosResponse) Reset() {
+	*x = WatchTodosResponse{}
+	mi := &file_todo_v1_todo_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchTodosResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchTodosResponse) ProtoMessage() {}
+
+func (x *WatchTodosResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchTodosResponse.ProtoReflect.Descriptor instead.
+func (*WatchTodosResponse) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *WatchTodosResponse) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *WatchTodosResponse) GetOperation() TodoEventOperation {
+	if x != nil {
+		return x.Operation
+	}
+	return TodoEventOperation_TODO_EVENT_OPERATION_UNSPECIFIED
+}
+
+func (x *WatchTodosResponse) GetTodo() *Todo {
+	if x != nil {
+		return x.Todo
+	}
+	return nil
+}
+
+func (x *WatchTodosResponse) GetEvent() *TodoEvent {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *WatchTodosResponse) GetLeftFilter() bool {
+	if x != nil {
+		return x.LeftFilter
+	}
+	return false
+}
+
+// Запрос на создание пакета задач.
+type BatchCreateTodosRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Задачи в порядке создания.
+	Requests []*CreateTodoRequest `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	// Выполнить пакет в одной транзакции: при ошибке любого элемента не создаётся
+	// ни одна задача, а вызов завершается ошибкой с номером элемента.
+	AllOrNothing  bool `protobuf:"varint,2,opt,name=all_or_nothing,json=allOrNothing,proto3" json:"all_or_nothing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchCreateTodosRequest) Reset() {
+	*x = BatchCreateTodosRequest{}
+	mi := &file_todo_v1_todo_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchCreateTodosRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCreateTodosRequest) ProtoMessage() {}
+
+func (x *BatchCreateTodosRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCreateTodosRequest.ProtoReflect.Descriptor instead.
+func (*BatchCreateTodosRequest) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *BatchCreateTodosRequest) GetRequests() []*CreateTodoRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+func (x *BatchCreateTodosRequest) GetAllOrNothing() bool {
+	if x != nil {
+		return x.AllOrNothing
+	}
+	return false
+}
+
+// Запрос на изменение пакета задач.
+type BatchUpdateTodosRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Изменения; каждая задача может встретиться только один раз.
+	Requests []*UpdateTodoRequest `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	// Выполнить пакет в одной транзакции, как в BatchCreateTodosRequest.
+	AllOrNothing  bool `protobuf:"varint,2,opt,name=all_or_nothing,json=allOrNothing,proto3" json:"all_or_nothing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchUpdateTodosRequest) Reset() {
+	*x = BatchUpdateTodosRequest{}
+	mi := &file_todo_v1_todo_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchUpdateTodosRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchUpdateTodosRequest) ProtoMessage() {}
+
+func (x *BatchUpdateTodosRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchUpdateTodosRequest.ProtoReflect.Descriptor instead.
+func (*BatchUpdateTodosRequest) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *BatchUpdateTodosRequest) GetRequests() []*UpdateTodoRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+func (x *BatchUpdateTodosRequest) GetAllOrNothing() bool {
+	if x != nil {
+		return x.AllOrNothing
+	}
+	return false
+}
+
+// Запрос на удаление пакета задач.
+type BatchDeleteTodosRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Удаления; каждая задача может встретиться только один раз.
+	Requests []*DeleteTodoRequest `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	// Выполнить пакет в одной транзакции, как в BatchCreateTodosRequest.
+	AllOrNothing  bool `protobuf:"varint,2,opt,name=all_or_nothing,json=allOrNothing,proto3" json:"all_or_nothing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchDeleteTodosRequest) Reset() {
+	*x = BatchDeleteTodosRequest{}
+	mi := &file_todo_v1_todo_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchDeleteTodosRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchDeleteTodosRequest) ProtoMessage() {}
+
+func (x *BatchDeleteTodosRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchDeleteTodosRequest.ProtoReflect.Descriptor instead.
+func (*BatchDeleteTodosRequest) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *BatchDeleteTodosRequest) GetRequests() []*DeleteTodoRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+func (x *BatchDeleteTodosRequest) GetAllOrNothing() bool {
+	if x != nil {
+		return x.AllOrNothing
+	}
+	return false
+}
+
+// Итог операции над одним элементом пакета.
+type BatchTodoResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Код google.rpc.Code; 0 означает успех.
+	Code int32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	// Описание ошибки; пусто при успехе.
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// Задача после операции; не задана при ошибке и при удалении.
+	Todo          *Todo `protobuf:"bytes,3,opt,name=todo,proto3" json:"todo,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchTodoResult) Reset() {
+	*x = BatchTodoResult{}
+	mi := &file_todo_v1_todo_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchTodoResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchTodoResult) ProtoMessage() {}
+
+func (x *BatchTodoResult) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchTodoResult.ProtoReflect.Descriptor instead.
+func (*BatchTodoResult) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *BatchTodoResult) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *BatchTodoResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *BatchTodoResult) GetTodo() *Todo {
+	if x != nil {
+		return x.Todo
+	}
+	return nil
+}
+
+// Ответ на пакетную операцию.
+type BatchTodosResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Итоги в порядке элементов запроса.
+	Results       []*BatchTodoResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchTodosResponse) Reset() {
+	*x = BatchTodosResponse{}
+	mi := &file_todo_v1_todo_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchTodosResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchTodosResponse) ProtoMessage() {}
+
+func (x *BatchTodosResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchTodosResponse.ProtoReflect.Descriptor instead.
+func (*BatchTodosResponse) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *BatchTodosResponse) GetResults() []*BatchTodoResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// Запрос на массовое изменение задач по фильтру.
+type BulkUpdateByFilterRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Условия отбора, как у ListTodos.
+	Filter *TodoFilter `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	// Новый признак завершения. Повторяющиеся и заблокированные задачи
+	// не завершаются, открытые подзадачи обрабатываются по политике сервера.
+	Completed *bool `protobuf:"varint,2,opt,name=completed,proto3,oneof" json:"completed,omitempty"`
+	// Новый приоритет.
+	Priority *Priority `protobuf:"varint,3,opt,name=priority,proto3,enum=todo.v1.Priority,oneof" json:"priority,omitempty"`
+	// Новый проект; пустая строка означает «Входящие».
+	ProjectId *string `protobuf:"bytes,4,opt,name=project_id,json=projectId,proto3,oneof" json:"project_id,omitempty"`
+	// Только посчитать затрагиваемые задачи, ничего не меняя.
+	DryRun bool `protobuf:"varint,5,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// Сколько затрагиваемых задач вернуть при пробном запуске; 0 — значение по умолчанию.
+	SampleSize    int32 `protobuf:"varint,6,opt,name=sample_size,json=sampleSize,proto3" json:"sample_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkUpdateByFilterRequest) Reset() {
+	*x = BulkUpdateByFilterRequest{}
+	mi := &file_todo_v1_todo_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkUpdateByFilterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkUpdateByFilterRequest) ProtoMessage() {}
+
+func (x *BulkUpdateByFilterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkUpdateByFilterRequest.ProtoReflect.Descriptor instead.
+func (*BulkUpdateByFilterRequest) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *BulkUpdateByFilterRequest) GetFilter() *TodoFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *BulkUpdateByFilterRequest) GetCompleted() bool {
+	if x != nil && x.Completed != nil {
+		return *x.Completed
+	}
+	return false
+}
+
+func (x *BulkUpdateByFilterRequest) GetPriority() Priority {
+	if x != nil && x.Priority != nil {
+		return *x.Priority
+	}
+	return Priority_PRIORITY_NONE
+}
+
+func (x *BulkUpdateByFilterRequest) GetProjectId() string {
+	if x != nil && x.ProjectId != nil {
+		return *x.ProjectId
+	}
+	return ""
+}
+
+func (x *BulkUpdateByFilterRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *BulkUpdateByFilterRequest) GetSampleSize() int32 {
+	if x != nil {
+		return x.SampleSize
+	}
+	return 0
+}
+
+// Запрос на массовое удаление задач по фильтру.
+type BulkDeleteByFilterRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Условия отбора, как у ListTodos.
+	Filter *TodoFilter `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	// Только посчитать затрагиваемые задачи, ничего не меняя.
+	DryRun bool `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// Сколько затрагиваемых задач вернуть при пробном запуске; 0 — значение по умолчанию.
+	SampleSize    int32 `protobuf:"varint,3,opt,name=sample_size,json=sampleSize,proto3" json:"sample_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkDeleteByFilterRequest) Reset() {
+	*x = BulkDeleteByFilterRequest{}
+	mi := &file_todo_v1_todo_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkDeleteByFilterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkDeleteByFilterRequest) ProtoMessage() {}
+
+func (x *BulkDeleteByFilterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkDeleteByFilterRequest.ProtoReflect.Descriptor instead.
+func (*BulkDeleteByFilterRequest) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *BulkDeleteByFilterRequest) GetFilter() *TodoFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *BulkDeleteByFilterRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *BulkDeleteByFilterRequest) GetSampleSize() int32 {
+	if x != nil {
+		return x.SampleSize
+	}
+	return 0
+}
+
+// Итог массового действия.
+type BulkResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Число затронутых задач; при пробном запуске — число тех, что были бы затронуты.
+	AffectedCount int64 `protobuf:"varint,1,opt,name=affected_count,json=affectedCount,proto3" json:"affected_count,omitempty"`
+	// Часть затрагиваемых задач, начиная с новых; только при пробном запуске.
+	Sample        []*Todo `protobuf:"bytes,2,rep,name=sample,proto3" json:"sample,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkResponse) Reset() {
+	*x = BulkResponse{}
+	mi := &file_todo_v1_todo_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Todo) GetDescription() string {
-	if x != nil {
-		return x.Description
-	}
-	return ""
+func (x *BulkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *Todo) GetCompleted() bool {
+func (*BulkResponse) ProtoMessage() {}
+
+func (x *BulkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[42]
 	if x != nil {
-		return x.Completed
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return false
+	return mi.MessageOf(x)
 }
 
-func (x *Todo) GetCreatedAt() int64 {
+// Deprecated: Use BulkResponse.ProtoReflect.Descriptor instead.
+func (*BulkResponse) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *BulkResponse) GetAffectedCount() int64 {
 	if x != nil {
-		return x.CreatedAt
+		return x.AffectedCount
 	}
 	return 0
 }
 
-func (x *Todo) GetUpdatedAt() int64 {
+func (x *BulkResponse) GetSample() []*Todo {
 	if x != nil {
-		return x.UpdatedAt
+		return x.Sample
 	}
-	return 0
+	return nil
 }
 
-// Запрос на создание новой задачи.
-type CreateTodoRequest struct {
+// Запрос на выгрузку задач.
+type ExportTodosRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Заголовок задачи.
-	Title string `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
-	// Подробное описание.
-	Description   string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// Условия отбора, как у ListTodos.
+	Filter        *TodoFilter    `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	Format        TransferFormat `protobuf:"varint,2,opt,name=format,proto3,enum=todo.v1.TransferFormat" json:"format,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateTodoRequest) Reset() {
-	*x = CreateTodoRequest{}
-	mi := &file_todo_v1_todo_proto_msgTypes[1]
+func (x *ExportTodosRequest) Reset() {
+	*x = ExportTodosRequest{}
+	mi := &file_todo_v1_todo_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateTodoRequest) String() string {
+func (x *ExportTodosRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateTodoRequest) ProtoMessage() {}
+func (*ExportTodosRequest) ProtoMessage() {}
 
-func (x *CreateTodoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_todo_v1_todo_proto_msgTypes[1]
+func (x *ExportTodosRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -148,49 +3454,48 @@ func (x *CreateTodoRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateTodoRequest.ProtoReflect.Descriptor instead.
-func (*CreateTodoRequest) Descriptor() ([]byte, []int) {
-	return file_todo_v1_todo_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use ExportTodosRequest.ProtoReflect.Descriptor instead.
+func (*ExportTodosRequest) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{43}
 }
 
-func (x *CreateTodoRequest) GetTitle() string {
+func (x *ExportTodosRequest) GetFilter() *TodoFilter {
 	if x != nil {
-		return x.Title
+		return x.Filter
 	}
-	return ""
+	return nil
 }
 
-func (x *CreateTodoRequest) GetDescription() string {
+func (x *ExportTodosRequest) GetFormat() TransferFormat {
 	if x != nil {
-		return x.Description
+		return x.Format
 	}
-	return ""
+	return TransferFormat_TRANSFER_FORMAT_UNSPECIFIED
 }
 
-// Ответ с созданной задачей.
-type CreateTodoResponse struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Созданная задача.
-	Todo          *Todo `protobuf:"bytes,1,opt,name=todo,proto3" json:"todo,omitempty"`
+// Очередная часть файла выгрузки.
+type ExportTodosResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chunk         []byte                 `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateTodoResponse) Reset() {
-	*x = CreateTodoResponse{}
-	mi := &file_todo_v1_todo_proto_msgTypes[2]
+func (x *ExportTodosResponse) Reset() {
+	*x = ExportTodosResponse{}
+	mi := &file_todo_v1_todo_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateTodoResponse) String() string {
+func (x *ExportTodosResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateTodoResponse) ProtoMessage() {}
+func (*ExportTodosResponse) ProtoMessage() {}
 
-func (x *CreateTodoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_todo_v1_todo_proto_msgTypes[2]
+func (x *ExportTodosResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -201,42 +3506,42 @@ func (x *CreateTodoResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateTodoResponse.ProtoReflect.Descriptor instead.
-func (*CreateTodoResponse) Descriptor() ([]byte, []int) {
-	return file_todo_v1_todo_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use ExportTodosResponse.ProtoReflect.Descriptor instead.
+func (*ExportTodosResponse) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{44}
 }
 
-func (x *CreateTodoResponse) GetTodo() *Todo {
+func (x *ExportTodosResponse) GetChunk() []byte {
 	if x != nil {
-		return x.Todo
+		return x.Chunk
 	}
 	return nil
 }
 
-// Запрос на получение задачи по идентификатору.
-type GetTodoRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Уникальный идентификатор задачи.
-	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+// Параметры загрузки.
+type ImportOptions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Format        TransferFormat         `protobuf:"varint,1,opt,name=format,proto3,enum=todo.v1.TransferFormat" json:"format,omitempty"`
+	Mode          ImportMode             `protobuf:"varint,2,opt,name=mode,proto3,enum=todo.v1.ImportMode" json:"mode,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTodoRequest) Reset() {
-	*x = GetTodoRequest{}
-	mi := &file_todo_v1_todo_proto_msgTypes[3]
+func (x *ImportOptions) Reset() {
+	*x = ImportOptions{}
+	mi := &file_todo_v1_todo_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTodoRequest) String() string {
+func (x *ImportOptions) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTodoRequest) ProtoMessage() {}
+func (*ImportOptions) ProtoMessage() {}
 
-func (x *GetTodoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_todo_v1_todo_proto_msgTypes[3]
+func (x *ImportOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -247,40 +3552,52 @@ func (x *GetTodoRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTodoRequest.ProtoReflect.Descriptor instead.
-func (*GetTodoRequest) Descriptor() ([]byte, []int) {
-	return file_todo_v1_todo_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use ImportOptions.ProtoReflect.Descriptor instead.
+func (*ImportOptions) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *GetTodoRequest) GetId() string {
+func (x *ImportOptions) GetFormat() TransferFormat {
 	if x != nil {
-		return x.Id
+		return x.Format
 	}
-	return ""
+	return TransferFormat_TRANSFER_FORMAT_UNSPECIFIED
 }
 
-// Запрос списка всех задач.
-type ListTodosRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+func (x *ImportOptions) GetMode() ImportMode {
+	if x != nil {
+		return x.Mode
+	}
+	return ImportMode_IMPORT_MODE_UNSPECIFIED
+}
+
+// Сообщение потока загрузки задач.
+type ImportTodosRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*ImportTodosRequest_Options
+	//	*ImportTodosRequest_Chunk
+	Payload       isImportTodosRequest_Payload `protobuf_oneof:"payload"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListTodosRequest) Reset() {
-	*x = ListTodosRequest{}
-	mi := &file_todo_v1_todo_proto_msgTypes[4]
+func (x *ImportTodosRequest) Reset() {
+	*x = ImportTodosRequest{}
+	mi := &file_todo_v1_todo_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListTodosRequest) String() string {
+func (x *ImportTodosRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTodosRequest) ProtoMessage() {}
+func (*ImportTodosRequest) ProtoMessage() {}
 
-func (x *ListTodosRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_todo_v1_todo_proto_msgTypes[4]
+func (x *ImportTodosRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -291,35 +3608,81 @@ func (x *ListTodosRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTodosRequest.ProtoReflect.Descriptor instead.
-func (*ListTodosRequest) Descriptor() ([]byte, []int) {
-	return file_todo_v1_todo_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use ImportTodosRequest.ProtoReflect.Descriptor instead.
+func (*ImportTodosRequest) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{46}
 }
 
-// Ответ со списком задач.
-type ListTodosResponse struct {
+func (x *ImportTodosRequest) GetPayload() isImportTodosRequest_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ImportTodosRequest) GetOptions() *ImportOptions {
+	if x != nil {
+		if x, ok := x.Payload.(*ImportTodosRequest_Options); ok {
+			return x.Options
+		}
+	}
+	return nil
+}
+
+func (x *ImportTodosRequest) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*ImportTodosRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isImportTodosRequest_Payload interface {
+	isImportTodosRequest_Payload()
+}
+
+type ImportTodosRequest_Options struct {
+	// Параметры; передаются первым сообщением.
+	Options *ImportOptions `protobuf:"bytes,1,opt,name=options,proto3,oneof"`
+}
+
+type ImportTodosRequest_Chunk struct {
+	// Очередная часть файла.
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*ImportTodosRequest_Options) isImportTodosRequest_Payload() {}
+
+func (*ImportTodosRequest_Chunk) isImportTodosRequest_Payload() {}
+
+// Ошибка одной строки файла загрузки.
+type ImportRowError struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Коллекция найденных задач.
-	Todos         []*Todo `protobuf:"bytes,1,rep,name=todos,proto3" json:"todos,omitempty"`
+	// Номер строки файла, начиная с 1.
+	Row int64 `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"`
+	// Код google.rpc.Code.
+	Code          int32  `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListTodosResponse) Reset() {
-	*x = ListTodosResponse{}
-	mi := &file_todo_v1_todo_proto_msgTypes[5]
+func (x *ImportRowError) Reset() {
+	*x = ImportRowError{}
+	mi := &file_todo_v1_todo_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListTodosResponse) String() string {
+func (x *ImportRowError) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTodosResponse) ProtoMessage() {}
+func (*ImportRowError) ProtoMessage() {}
 
-func (x *ListTodosResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_todo_v1_todo_proto_msgTypes[5]
+func (x *ImportRowError) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -330,48 +3693,60 @@ func (x *ListTodosResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTodosResponse.ProtoReflect.Descriptor instead.
-func (*ListTodosResponse) Descriptor() ([]byte, []int) {
-	return file_todo_v1_todo_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use ImportRowError.ProtoReflect.Descriptor instead.
+func (*ImportRowError) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *ListTodosResponse) GetTodos() []*Todo {
+func (x *ImportRowError) GetRow() int64 {
 	if x != nil {
-		return x.Todos
+		return x.Row
 	}
-	return nil
+	return 0
 }
 
-// Запрос на обновление существующей задачи.
-type UpdateTodoRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Уникальный идентификатор задачи.
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	// Новый заголовок.
-	Title string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	// Новое описание.
-	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	// Новый статус завершения.
-	Completed     bool `protobuf:"varint,4,opt,name=completed,proto3" json:"completed,omitempty"`
+func (x *ImportRowError) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *ImportRowError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Итог загрузки.
+type ImportTodosResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Created int64                  `protobuf:"varint,1,opt,name=created,proto3" json:"created,omitempty"`
+	Updated int64                  `protobuf:"varint,2,opt,name=updated,proto3" json:"updated,omitempty"`
+	// Число строк, которые не удалось загрузить.
+	Failed int64 `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	// Ошибки первых строк; остальные только учитываются в failed.
+	Errors        []*ImportRowError `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateTodoRequest) Reset() {
-	*x = UpdateTodoRequest{}
-	mi := &file_todo_v1_todo_proto_msgTypes[6]
+func (x *ImportTodosResponse) Reset() {
+	*x = ImportTodosResponse{}
+	mi := &file_todo_v1_todo_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateTodoRequest) String() string {
+func (x *ImportTodosResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateTodoRequest) ProtoMessage() {}
+func (*ImportTodosResponse) ProtoMessage() {}
 
-func (x *UpdateTodoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_todo_v1_todo_proto_msgTypes[6]
+func (x *ImportTodosResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -382,63 +3757,63 @@ func (x *UpdateTodoRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateTodoRequest.ProtoReflect.Descriptor instead.
-func (*UpdateTodoRequest) Descriptor() ([]byte, []int) {
-	return file_todo_v1_todo_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use ImportTodosResponse.ProtoReflect.Descriptor instead.
+func (*ImportTodosResponse) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *UpdateTodoRequest) GetId() string {
+func (x *ImportTodosResponse) GetCreated() int64 {
 	if x != nil {
-		return x.Id
+		return x.Created
 	}
-	return ""
+	return 0
 }
 
-func (x *UpdateTodoRequest) GetTitle() string {
+func (x *ImportTodosResponse) GetUpdated() int64 {
 	if x != nil {
-		return x.Title
+		return x.Updated
 	}
-	return ""
+	return 0
 }
 
-func (x *UpdateTodoRequest) GetDescription() string {
+func (x *ImportTodosResponse) GetFailed() int64 {
 	if x != nil {
-		return x.Description
+		return x.Failed
 	}
-	return ""
+	return 0
 }
 
-func (x *UpdateTodoRequest) GetCompleted() bool {
+func (x *ImportTodosResponse) GetErrors() []*ImportRowError {
 	if x != nil {
-		return x.Completed
+		return x.Errors
 	}
-	return false
+	return nil
 }
 
-// Запрос на удаление задачи.
-type DeleteTodoRequest struct {
+// Запрос на выгрузку задач в iCalendar.
+type ExportICalendarRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Уникальный идентификатор задачи.
-	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Условия отбора, как у ListTodos.
+	Filter        *TodoFilter `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteTodoRequest) Reset() {
-	*x = DeleteTodoRequest{}
-	mi := &file_todo_v1_todo_proto_msgTypes[7]
+func (x *ExportICalendarRequest) Reset() {
+	*x = ExportICalendarRequest{}
+	mi := &file_todo_v1_todo_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteTodoRequest) String() string {
+func (x *ExportICalendarRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteTodoRequest) ProtoMessage() {}
+func (*ExportICalendarRequest) ProtoMessage() {}
 
-func (x *DeleteTodoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_todo_v1_todo_proto_msgTypes[7]
+func (x *ExportICalendarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -449,40 +3824,42 @@ func (x *DeleteTodoRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteTodoRequest.ProtoReflect.Descriptor instead.
-func (*DeleteTodoRequest) Descriptor() ([]byte, []int) {
-	return file_todo_v1_todo_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use ExportICalendarRequest.ProtoReflect.Descriptor instead.
+func (*ExportICalendarRequest) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{49}
 }
 
-func (x *DeleteTodoRequest) GetId() string {
+func (x *ExportICalendarRequest) GetFilter() *TodoFilter {
 	if x != nil {
-		return x.Id
+		return x.Filter
 	}
-	return ""
+	return nil
 }
 
-// Ответ на удаление задачи (пустой).
-type DeleteTodoResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+// Сообщение потока загрузки календаря.
+type ImportICalendarRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Очередная часть файла .ics.
+	Chunk         []byte `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteTodoResponse) Reset() {
-	*x = DeleteTodoResponse{}
-	mi := &file_todo_v1_todo_proto_msgTypes[8]
+func (x *ImportICalendarRequest) Reset() {
+	*x = ImportICalendarRequest{}
+	mi := &file_todo_v1_todo_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteTodoResponse) String() string {
+func (x *ImportICalendarRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteTodoResponse) ProtoMessage() {}
+func (*ImportICalendarRequest) ProtoMessage() {}
 
-func (x *DeleteTodoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_todo_v1_todo_proto_msgTypes[8]
+func (x *ImportICalendarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_todo_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -493,16 +3870,23 @@ func (x *DeleteTodoResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteTodoResponse.ProtoReflect.Descriptor instead.
-func (*DeleteTodoResponse) Descriptor() ([]byte, []int) {
-	return file_todo_v1_todo_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use ImportICalendarRequest.ProtoReflect.Descriptor instead.
+func (*ImportICalendarRequest) Descriptor() ([]byte, []int) {
+	return file_todo_v1_todo_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ImportICalendarRequest) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
 }
 
 var File_todo_v1_todo_proto protoreflect.FileDescriptor
 
 const file_todo_v1_todo_proto_rawDesc = "" +
 	"\n" +
-	"\x12todo/v1/todo.proto\x12\atodo.v1\"\xaa\x01\n" +
+	"\x12todo/v1/todo.proto\x12\atodo.v1\x1a google/protobuf/field_mask.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x15todo/v1/sharing.proto\"\xe0\x05\n" +
 	"\x04Todo\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
@@ -511,25 +3895,304 @@ const file_todo_v1_todo_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x05 \x01(\x03R\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\x06 \x01(\x03R\tupdatedAt\"K\n" +
+	"updated_at\x18\x06 \x01(\x03R\tupdatedAt\x12\x18\n" +
+	"\aversion\x18\a \x01(\x03R\aversion\x12\x1d\n" +
+	"\n" +
+	"deleted_at\x18\b \x01(\x03R\tdeletedAt\x12\x1a\n" +
+	"\x06due_at\x18\t \x01(\x03H\x00R\x05dueAt\x88\x01\x01\x12\x1e\n" +
+	"\bstart_at\x18\n" +
+	" \x01(\x03H\x01R\astartAt\x88\x01\x01\x12\x17\n" +
+	"\aall_day\x18\v \x01(\bR\x06allDay\x12\x1b\n" +
+	"\ttime_zone\x18\f \x01(\tR\btimeZone\x12-\n" +
+	"\bpriority\x18\r \x01(\x0e2\x11.todo.v1.PriorityR\bpriority\x12\x12\n" +
+	"\x04tags\x18\x0e \x03(\tR\x04tags\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x0f \x01(\tR\tprojectId\x12\x1b\n" +
+	"\tparent_id\x18\x10 \x01(\tR\bparentId\x12\x18\n" +
+	"\ablocked\x18\x11 \x01(\bR\ablocked\x12\x1e\n" +
+	"\n" +
+	"recurrence\x18\x12 \x01(\tR\n" +
+	"recurrence\x12@\n" +
+	"\x0frecurrence_mode\x18\x13 \x01(\x0e2\x17.todo.v1.RecurrenceModeR\x0erecurrenceMode\x12\x1b\n" +
+	"\tseries_id\x18\x14 \x01(\tR\bseriesId\x12#\n" +
+	"\rcomment_count\x18\x15 \x01(\x03R\fcommentCount\x12\x19\n" +
+	"\bowner_id\x18\x16 \x01(\tR\aownerId\x12\x19\n" +
+	"\bical_uid\x18\x17 \x01(\tR\aicalUidB\t\n" +
+	"\a_due_atB\v\n" +
+	"\t_start_at\"\xb6\x03\n" +
 	"\x11CreateTodoRequest\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
-	"\vdescription\x18\x02 \x01(\tR\vdescription\"7\n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1a\n" +
+	"\x06due_at\x18\x03 \x01(\x03H\x00R\x05dueAt\x88\x01\x01\x12\x1e\n" +
+	"\bstart_at\x18\x04 \x01(\x03H\x01R\astartAt\x88\x01\x01\x12\x17\n" +
+	"\aall_day\x18\x05 \x01(\bR\x06allDay\x12\x1b\n" +
+	"\ttime_zone\x18\x06 \x01(\tR\btimeZone\x12-\n" +
+	"\bpriority\x18\a \x01(\x0e2\x11.todo.v1.PriorityR\bpriority\x12\x12\n" +
+	"\x04tags\x18\b \x03(\tR\x04tags\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\t \x01(\tR\tprojectId\x12\x1b\n" +
+	"\tparent_id\x18\n" +
+	" \x01(\tR\bparentId\x12\x1e\n" +
+	"\n" +
+	"recurrence\x18\v \x01(\tR\n" +
+	"recurrence\x12@\n" +
+	"\x0frecurrence_mode\x18\f \x01(\x0e2\x17.todo.v1.RecurrenceModeR\x0erecurrenceModeB\t\n" +
+	"\a_due_atB\v\n" +
+	"\t_start_at\"7\n" +
 	"\x12CreateTodoResponse\x12!\n" +
 	"\x04todo\x18\x01 \x01(\v2\r.todo.v1.TodoR\x04todo\" \n" +
 	"\x0eGetTodoRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\x12\n" +
-	"\x10ListTodosRequest\"8\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x8a\x05\n" +
+	"\n" +
+	"TodoFilter\x12!\n" +
+	"\tcompleted\x18\x01 \x01(\bH\x00R\tcompleted\x88\x01\x01\x12#\n" +
+	"\rcreated_after\x18\x02 \x01(\x03R\fcreatedAfter\x12%\n" +
+	"\x0ecreated_before\x18\x03 \x01(\x03R\rcreatedBefore\x12#\n" +
+	"\rupdated_after\x18\x04 \x01(\x03R\fupdatedAfter\x12%\n" +
+	"\x0eupdated_before\x18\x05 \x01(\x03R\rupdatedBefore\x12!\n" +
+	"\ftitle_prefix\x18\x06 \x01(\tR\vtitlePrefix\x12\x18\n" +
+	"\aoverdue\x18\a \x01(\bR\aoverdue\x12\x1b\n" +
+	"\tdue_today\x18\b \x01(\bR\bdueToday\x12&\n" +
+	"\x0fdue_within_days\x18\t \x01(\x05R\rdueWithinDays\x12\x1b\n" +
+	"\ttime_zone\x18\n" +
+	" \x01(\tR\btimeZone\x12\x12\n" +
+	"\x04tags\x18\v \x03(\tR\x04tags\x12.\n" +
+	"\ttag_match\x18\f \x01(\x0e2\x11.todo.v1.TagMatchR\btagMatch\x12\"\n" +
+	"\n" +
+	"project_id\x18\r \x01(\tH\x01R\tprojectId\x88\x01\x01\x12 \n" +
+	"\tparent_id\x18\x0e \x01(\tH\x02R\bparentId\x88\x01\x01\x12\x1e\n" +
+	"\n" +
+	"actionable\x18\x0f \x01(\bR\n" +
+	"actionable\x12\x1b\n" +
+	"\tseries_id\x18\x10 \x01(\tR\bseriesId\x120\n" +
+	"\townership\x18\x11 \x01(\x0e2\x12.todo.v1.OwnershipR\townershipB\f\n" +
+	"\n" +
+	"_completedB\r\n" +
+	"\v_project_idB\f\n" +
+	"\n" +
+	"_parent_id\"\xaa\x01\n" +
+	"\x10ListTodosRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12+\n" +
+	"\x06filter\x18\x03 \x01(\v2\x13.todo.v1.TodoFilterR\x06filter\x12-\n" +
+	"\border_by\x18\x04 \x01(\x0e2\x12.todo.v1.TodoOrderR\aorderBy\"`\n" +
 	"\x11ListTodosResponse\x12#\n" +
-	"\x05todos\x18\x01 \x03(\v2\r.todo.v1.TodoR\x05todos\"y\n" +
+	"\x05todos\x18\x01 \x03(\v2\r.todo.v1.TodoR\x05todos\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xcc\x04\n" +
 	"\x11UpdateTodoRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
 	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1c\n" +
-	"\tcompleted\x18\x04 \x01(\bR\tcompleted\"#\n" +
+	"\tcompleted\x18\x04 \x01(\bR\tcompleted\x12;\n" +
+	"\vupdate_mask\x18\x05 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x12)\n" +
+	"\x10expected_version\x18\x06 \x01(\x03R\x0fexpectedVersion\x12\x1a\n" +
+	"\x06due_at\x18\a \x01(\x03H\x00R\x05dueAt\x88\x01\x01\x12\x1e\n" +
+	"\bstart_at\x18\b \x01(\x03H\x01R\astartAt\x88\x01\x01\x12\x17\n" +
+	"\aall_day\x18\t \x01(\bR\x06allDay\x12\x1b\n" +
+	"\ttime_zone\x18\n" +
+	" \x01(\tR\btimeZone\x12-\n" +
+	"\bpriority\x18\v \x01(\x0e2\x11.todo.v1.PriorityR\bpriority\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\f \x01(\tR\tprojectId\x12\x1b\n" +
+	"\tparent_id\x18\r \x01(\tR\bparentId\x12\x1e\n" +
+	"\n" +
+	"recurrence\x18\x0e \x01(\tR\n" +
+	"recurrence\x12@\n" +
+	"\x0frecurrence_mode\x18\x0f \x01(\x0e2\x17.todo.v1.RecurrenceModeR\x0erecurrenceMode\x12\x12\n" +
+	"\x04tags\x18\x10 \x03(\tR\x04tagsB\t\n" +
+	"\a_due_atB\v\n" +
+	"\t_start_at\"N\n" +
 	"\x11DeleteTodoRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\x14\n" +
-	"\x12DeleteTodoResponse2\xcb\x02\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12)\n" +
+	"\x10expected_version\x18\x02 \x01(\x03R\x0fexpectedVersion\"\x14\n" +
+	"\x12DeleteTodoResponse\"$\n" +
+	"\x12RestoreTodoRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"N\n" +
+	"\x10ListTrashRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\"`\n" +
+	"\x11ListTrashResponse\x12#\n" +
+	"\x05todos\x18\x01 \x03(\v2\r.todo.v1.TodoR\x05todos\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\"\n" +
+	"\x10PurgeTodoRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x13\n" +
+	"\x11PurgeTodoResponse\"4\n" +
+	"\x0eAddTagsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\"7\n" +
+	"\x11RemoveTagsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\"\x11\n" +
+	"\x0fListTagsRequest\"8\n" +
+	"\x03Tag\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"todo_count\x18\x02 \x01(\x03R\ttodoCount\"4\n" +
+	"\x10ListTagsResponse\x12 \n" +
+	"\x04tags\x18\x01 \x03(\v2\f.todo.v1.TagR\x04tags\"$\n" +
+	"\x12GetTodoTreeRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xa6\x01\n" +
+	"\bTodoNode\x12!\n" +
+	"\x04todo\x18\x01 \x01(\v2\r.todo.v1.TodoR\x04todo\x12-\n" +
+	"\bchildren\x18\x02 \x03(\v2\x11.todo.v1.TodoNodeR\bchildren\x12'\n" +
+	"\x0fcompleted_count\x18\x03 \x01(\x05R\x0ecompletedCount\x12\x1f\n" +
+	"\vtotal_count\x18\x04 \x01(\x05R\n" +
+	"totalCount\"E\n" +
+	"\x14AddDependencyRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"blocker_id\x18\x02 \x01(\tR\tblockerId\"H\n" +
+	"\x17RemoveDependencyRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"blocker_id\x18\x02 \x01(\tR\tblockerId\"^\n" +
+	"\x10ShareTodoRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12!\n" +
+	"\x04role\x18\x03 \x01(\x0e2\r.todo.v1.RoleR\x04role\"=\n" +
+	"\x12UnshareTodoRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x15\n" +
+	"\x13UnshareTodoResponse\"*\n" +
+	"\x18ListCollaboratorsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"X\n" +
+	"\x19ListCollaboratorsResponse\x12;\n" +
+	"\rcollaborators\x18\x01 \x03(\v2\x15.todo.v1.CollaboratorR\rcollaborators\"\x81\x01\n" +
+	"\vFieldChange\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12.\n" +
+	"\x06before\x18\x02 \x01(\v2\x16.google.protobuf.ValueR\x06before\x12,\n" +
+	"\x05after\x18\x03 \x01(\v2\x16.google.protobuf.ValueR\x05after\"\xd6\x01\n" +
+	"\tTodoEvent\x12\x10\n" +
+	"\x03seq\x18\x01 \x01(\x03R\x03seq\x12\x17\n" +
+	"\atodo_id\x18\x02 \x01(\tR\x06todoId\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\x129\n" +
+	"\toperation\x18\x04 \x01(\x0e2\x1b.todo.v1.TodoEventOperationR\toperation\x12.\n" +
+	"\achanges\x18\x05 \x03(\v2\x14.todo.v1.FieldChangeR\achanges\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\x03R\tcreatedAt\"d\n" +
+	"\x16ListTodoHistoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"m\n" +
+	"\x17ListTodoHistoryResponse\x12*\n" +
+	"\x06events\x18\x01 \x03(\v2\x12.todo.v1.TodoEventR\x06events\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"]\n" +
+	"\x11WatchTodosRequest\x12+\n" +
+	"\x06filter\x18\x01 \x01(\v2\x13.todo.v1.TodoFilterR\x06filter\x12\x1b\n" +
+	"\tafter_seq\x18\x02 \x01(\x03R\bafterSeq\"\xcf\x01\n" +
+	"\x12WatchTodosResponse\x12\x10\n" +
+	"\x03seq\x18\x01 \x01(\x03R\x03seq\x129\n" +
+	"\toperation\x18\x02 \x01(\x0e2\x1b.todo.v1.TodoEventOperationR\toperation\x12!\n" +
+	"\x04todo\x18\x03 \x01(\v2\r.todo.v1.TodoR\x04todo\x12(\n" +
+	"\x05event\x18\x04 \x01(\v2\x12.todo.v1.TodoEventR\x05event\x12\x1f\n" +
+	"\vleft_filter\x18\x05 \x01(\bR\n" +
+	"leftFilter\"w\n" +
+	"\x17BatchCreateTodosRequest\x126\n" +
+	"\brequests\x18\x01 \x03(\v2\x1a.todo.v1.CreateTodoRequestR\brequests\x12$\n" +
+	"\x0eall_or_nothing\x18\x02 \x01(\bR\fallOrNothing\"w\n" +
+	"\x17BatchUpdateTodosRequest\x126\n" +
+	"\brequests\x18\x01 \x03(\v2\x1a.todo.v1.UpdateTodoRequestR\brequests\x12$\n" +
+	"\x0eall_or_nothing\x18\x02 \x01(\bR\fallOrNothing\"w\n" +
+	"\x17BatchDeleteTodosRequest\x126\n" +
+	"\brequests\x18\x01 \x03(\v2\x1a.todo.v1.DeleteTodoRequestR\brequests\x12$\n" +
+	"\x0eall_or_nothing\x18\x02 \x01(\bR\fallOrNothing\"b\n" +
+	"\x0fBatchTodoResult\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12!\n" +
+	"\x04todo\x18\x03 \x01(\v2\r.todo.v1.TodoR\x04todo\"H\n" +
+	"\x12BatchTodosResponse\x122\n" +
+	"\aresults\x18\x01 \x03(\v2\x18.todo.v1.BatchTodoResultR\aresults\"\xa7\x02\n" +
+	"\x19BulkUpdateByFilterRequest\x12+\n" +
+	"\x06filter\x18\x01 \x01(\v2\x13.todo.v1.TodoFilterR\x06filter\x12!\n" +
+	"\tcompleted\x18\x02 \x01(\bH\x00R\tcompleted\x88\x01\x01\x122\n" +
+	"\bpriority\x18\x03 \x01(\x0e2\x11.todo.v1.PriorityH\x01R\bpriority\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"project_id\x18\x04 \x01(\tH\x02R\tprojectId\x88\x01\x01\x12\x17\n" +
+	"\adry_run\x18\x05 \x01(\bR\x06dryRun\x12\x1f\n" +
+	"\vsample_size\x18\x06 \x01(\x05R\n" +
+	"sampleSizeB\f\n" +
+	"\n" +
+	"_completedB\v\n" +
+	"\t_priorityB\r\n" +
+	"\v_project_id\"\x82\x01\n" +
+	"\x19BulkDeleteByFilterRequest\x12+\n" +
+	"\x06filter\x18\x01 \x01(\v2\x13.todo.v1.TodoFilterR\x06filter\x12\x17\n" +
+	"\adry_run\x18\x02 \x01(\bR\x06dryRun\x12\x1f\n" +
+	"\vsample_size\x18\x03 \x01(\x05R\n" +
+	"sampleSize\"\\\n" +
+	"\fBulkResponse\x12%\n" +
+	"\x0eaffected_count\x18\x01 \x01(\x03R\raffectedCount\x12%\n" +
+	"\x06sample\x18\x02 \x03(\v2\r.todo.v1.TodoR\x06sample\"r\n" +
+	"\x12ExportTodosRequest\x12+\n" +
+	"\x06filter\x18\x01 \x01(\v2\x13.todo.v1.TodoFilterR\x06filter\x12/\n" +
+	"\x06format\x18\x02 \x01(\x0e2\x17.todo.v1.TransferFormatR\x06format\"+\n" +
+	"\x13ExportTodosResponse\x12\x14\n" +
+	"\x05chunk\x18\x01 \x01(\fR\x05chunk\"i\n" +
+	"\rImportOptions\x12/\n" +
+	"\x06format\x18\x01 \x01(\x0e2\x17.todo.v1.TransferFormatR\x06format\x12'\n" +
+	"\x04mode\x18\x02 \x01(\x0e2\x13.todo.v1.ImportModeR\x04mode\"k\n" +
+	"\x12ImportTodosRequest\x122\n" +
+	"\aoptions\x18\x01 \x01(\v2\x16.todo.v1.ImportOptionsH\x00R\aoptions\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\t\n" +
+	"\apayload\"P\n" +
+	"\x0eImportRowError\x12\x10\n" +
+	"\x03row\x18\x01 \x01(\x03R\x03row\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\x92\x01\n" +
+	"\x13ImportTodosResponse\x12\x18\n" +
+	"\acreated\x18\x01 \x01(\x03R\acreated\x12\x18\n" +
+	"\aupdated\x18\x02 \x01(\x03R\aupdated\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x03R\x06failed\x12/\n" +
+	"\x06errors\x18\x04 \x03(\v2\x17.todo.v1.ImportRowErrorR\x06errors\"E\n" +
+	"\x16ExportICalendarRequest\x12+\n" +
+	"\x06filter\x18\x01 \x01(\v2\x13.todo.v1.TodoFilterR\x06filter\".\n" +
+	"\x16ImportICalendarRequest\x12\x14\n" +
+	"\x05chunk\x18\x01 \x01(\fR\x05chunk*l\n" +
+	"\bPriority\x12\x11\n" +
+	"\rPRIORITY_NONE\x10\x00\x12\x10\n" +
+	"\fPRIORITY_LOW\x10\x01\x12\x13\n" +
+	"\x0fPRIORITY_MEDIUM\x10\x02\x12\x11\n" +
+	"\rPRIORITY_HIGH\x10\x03\x12\x13\n" +
+	"\x0fPRIORITY_URGENT\x10\x04*H\n" +
+	"\x0eRecurrenceMode\x12\x19\n" +
+	"\x15RECURRENCE_MODE_SPAWN\x10\x00\x12\x1b\n" +
+	"\x17RECURRENCE_MODE_ADVANCE\x10\x01*\xf3\x01\n" +
+	"\tTodoOrder\x12\x1a\n" +
+	"\x16TODO_ORDER_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aTODO_ORDER_CREATED_AT_DESC\x10\x01\x12\x1d\n" +
+	"\x19TODO_ORDER_CREATED_AT_ASC\x10\x02\x12\x1e\n" +
+	"\x1aTODO_ORDER_UPDATED_AT_DESC\x10\x03\x12\x1d\n" +
+	"\x19TODO_ORDER_UPDATED_AT_ASC\x10\x04\x12\x18\n" +
+	"\x14TODO_ORDER_TITLE_ASC\x10\x05\x12\x19\n" +
+	"\x15TODO_ORDER_TITLE_DESC\x10\x06\x12\x17\n" +
+	"\x13TODO_ORDER_PRIORITY\x10\a*0\n" +
+	"\bTagMatch\x12\x11\n" +
+	"\rTAG_MATCH_ANY\x10\x00\x12\x11\n" +
+	"\rTAG_MATCH_ALL\x10\x01*I\n" +
+	"\tOwnership\x12\x11\n" +
+	"\rOWNERSHIP_ANY\x10\x00\x12\x13\n" +
+	"\x0fOWNERSHIP_OWNED\x10\x01\x12\x14\n" +
+	"\x10OWNERSHIP_SHARED\x10\x02*\xdf\x01\n" +
+	"\x12TodoEventOperation\x12$\n" +
+	" TODO_EVENT_OPERATION_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bTODO_EVENT_OPERATION_CREATE\x10\x01\x12\x1f\n" +
+	"\x1bTODO_EVENT_OPERATION_UPDATE\x10\x02\x12\x1f\n" +
+	"\x1bTODO_EVENT_OPERATION_DELETE\x10\x03\x12 \n" +
+	"\x1cTODO_EVENT_OPERATION_RESTORE\x10\x04\x12\x1e\n" +
+	"\x1aTODO_EVENT_OPERATION_PURGE\x10\x05*f\n" +
+	"\x0eTransferFormat\x12\x1f\n" +
+	"\x1bTRANSFER_FORMAT_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16TRANSFER_FORMAT_NDJSON\x10\x01\x12\x17\n" +
+	"\x13TRANSFER_FORMAT_CSV\x10\x02*Y\n" +
+	"\n" +
+	"ImportMode\x12\x1b\n" +
+	"\x17IMPORT_MODE_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12IMPORT_MODE_CREATE\x10\x01\x12\x16\n" +
+	"\x12IMPORT_MODE_UPSERT\x10\x022\xdd\x0f\n" +
 	"\vTodoService\x12E\n" +
 	"\n" +
 	"CreateTodo\x12\x1a.todo.v1.CreateTodoRequest\x1a\x1b.todo.v1.CreateTodoResponse\x121\n" +
@@ -538,7 +4201,32 @@ const file_todo_v1_todo_proto_rawDesc = "" +
 	"\n" +
 	"UpdateTodo\x12\x1a.todo.v1.UpdateTodoRequest\x1a\r.todo.v1.Todo\x12E\n" +
 	"\n" +
-	"DeleteTodo\x12\x1a.todo.v1.DeleteTodoRequest\x1a\x1b.todo.v1.DeleteTodoResponseB Z\x1etodo/internal/gen/todo/v1;todob\x06proto3"
+	"DeleteTodo\x12\x1a.todo.v1.DeleteTodoRequest\x1a\x1b.todo.v1.DeleteTodoResponse\x129\n" +
+	"\vRestoreTodo\x12\x1b.todo.v1.RestoreTodoRequest\x1a\r.todo.v1.Todo\x12B\n" +
+	"\tListTrash\x12\x19.todo.v1.ListTrashRequest\x1a\x1a.todo.v1.ListTrashResponse\x12B\n" +
+	"\tPurgeTodo\x12\x19.todo.v1.PurgeTodoRequest\x1a\x1a.todo.v1.PurgeTodoResponse\x121\n" +
+	"\aAddTags\x12\x17.todo.v1.AddTagsRequest\x1a\r.todo.v1.Todo\x127\n" +
+	"\n" +
+	"RemoveTags\x12\x1a.todo.v1.RemoveTagsRequest\x1a\r.todo.v1.Todo\x12?\n" +
+	"\bListTags\x12\x18.todo.v1.ListTagsRequest\x1a\x19.todo.v1.ListTagsResponse\x12=\n" +
+	"\vGetTodoTree\x12\x1b.todo.v1.GetTodoTreeRequest\x1a\x11.todo.v1.TodoNode\x12=\n" +
+	"\rAddDependency\x12\x1d.todo.v1.AddDependencyRequest\x1a\r.todo.v1.Todo\x12C\n" +
+	"\x10RemoveDependency\x12 .todo.v1.RemoveDependencyRequest\x1a\r.todo.v1.Todo\x12=\n" +
+	"\tShareTodo\x12\x19.todo.v1.ShareTodoRequest\x1a\x15.todo.v1.Collaborator\x12H\n" +
+	"\vUnshareTodo\x12\x1b.todo.v1.UnshareTodoRequest\x1a\x1c.todo.v1.UnshareTodoResponse\x12Z\n" +
+	"\x11ListCollaborators\x12!.todo.v1.ListCollaboratorsRequest\x1a\".todo.v1.ListCollaboratorsResponse\x12T\n" +
+	"\x0fListTodoHistory\x12\x1f.todo.v1.ListTodoHistoryRequest\x1a .todo.v1.ListTodoHistoryResponse\x12G\n" +
+	"\n" +
+	"WatchTodos\x12\x1a.todo.v1.WatchTodosRequest\x1a\x1b.todo.v1.WatchTodosResponse0\x01\x12Q\n" +
+	"\x10BatchCreateTodos\x12 .todo.v1.BatchCreateTodosRequest\x1a\x1b.todo.v1.BatchTodosResponse\x12Q\n" +
+	"\x10BatchUpdateTodos\x12 .todo.v1.BatchUpdateTodosRequest\x1a\x1b.todo.v1.BatchTodosResponse\x12Q\n" +
+	"\x10BatchDeleteTodos\x12 .todo.v1.BatchDeleteTodosRequest\x1a\x1b.todo.v1.BatchTodosResponse\x12O\n" +
+	"\x12BulkUpdateByFilter\x12\".todo.v1.BulkUpdateByFilterRequest\x1a\x15.todo.v1.BulkResponse\x12O\n" +
+	"\x12BulkDeleteByFilter\x12\".todo.v1.BulkDeleteByFilterRequest\x1a\x15.todo.v1.BulkResponse\x12J\n" +
+	"\vExportTodos\x12\x1b.todo.v1.ExportTodosRequest\x1a\x1c.todo.v1.ExportTodosResponse0\x01\x12J\n" +
+	"\vImportTodos\x12\x1b.todo.v1.ImportTodosRequest\x1a\x1c.todo.v1.ImportTodosResponse(\x01\x12R\n" +
+	"\x0fExportICalendar\x12\x1f.todo.v1.ExportICalendarRequest\x1a\x1c.todo.v1.ExportTodosResponse0\x01\x12R\n" +
+	"\x0fImportICalendar\x12\x1f.todo.v1.ImportICalendarRequest\x1a\x1c.todo.v1.ImportTodosResponse(\x01B Z\x1etodo/internal/gen/todo/v1;todob\x06proto3"
 
 var (
 	file_todo_v1_todo_proto_rawDescOnce sync.Once
@@ -552,36 +4240,179 @@ func file_todo_v1_todo_proto_rawDescGZIP() []byte {
 	return file_todo_v1_todo_proto_rawDescData
 }
 
-var file_todo_v1_todo_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_todo_v1_todo_proto_enumTypes = make([]protoimpl.EnumInfo, 8)
+var file_todo_v1_todo_proto_msgTypes = make([]protoimpl.MessageInfo, 51)
 var file_todo_v1_todo_proto_goTypes = []any{
-	(*Todo)(nil),               // 0: todo.v1.Todo
-	(*CreateTodoRequest)(nil),  // 1: todo.v1.CreateTodoRequest
-	(*CreateTodoResponse)(nil), // 2: todo.v1.CreateTodoResponse
-	(*GetTodoRequest)(nil),     // 3: todo.v1.GetTodoRequest
-	(*ListTodosRequest)(nil),   // 4: todo.v1.ListTodosRequest
-	(*ListTodosResponse)(nil),  // 5: todo.v1.ListTodosResponse
-	(*UpdateTodoRequest)(nil),  // 6: todo.v1.UpdateTodoRequest
-	(*DeleteTodoRequest)(nil),  // 7: todo.v1.DeleteTodoRequest
-	(*DeleteTodoResponse)(nil), // 8: todo.v1.DeleteTodoResponse
+	(Priority)(0),                     // 0: todo.v1.Priority
+	(RecurrenceMode)(0),               // 1: todo.v1.RecurrenceMode
+	(TodoOrder)(0),                    // 2: todo.v1.TodoOrder
+	(TagMatch)(0),                     // 3: todo.v1.TagMatch
+	(Ownership)(0),                    // 4: todo.v1.Ownership
+	(TodoEventOperation)(0),           // 5: todo.v1.TodoEventOperation
+	(TransferFormat)(0),               // 6: todo.v1.TransferFormat
+	(ImportMode)(0),                   // 7: todo.v1.ImportMode
+	(*Todo)(nil),                      // 8: todo.v1.Todo
+	(*CreateTodoRequest)(nil),         // 9: todo.v1.CreateTodoRequest
+	(*CreateTodoResponse)(nil),        // 10: todo.v1.CreateTodoResponse
+	(*GetTodoRequest)(nil),            // 11: todo.v1.GetTodoRequest
+	(*TodoFilter)(nil),                // 12: todo.v1.TodoFilter
+	(*ListTodosRequest)(nil),          // 13: todo.v1.ListTodosRequest
+	(*ListTodosResponse)(nil),         // 14: todo.v1.ListTodosResponse
+	(*UpdateTodoRequest)(nil),         // 15: todo.v1.UpdateTodoRequest
+	(*DeleteTodoRequest)(nil),         // 16: todo.v1.DeleteTodoRequest
+	(*DeleteTodoResponse)(nil),        // 17: todo.v1.DeleteTodoResponse
+	(*RestoreTodoRequest)(nil),        // 18: todo.v1.RestoreTodoRequest
+	(*ListTrashRequest)(nil),          // 19: todo.v1.ListTrashRequest
+	(*ListTrashResponse)(nil),         // 20: todo.v1.ListTrashResponse
+	(*PurgeTodoRequest)(nil),          // 21: todo.v1.PurgeTodoRequest
+	(*PurgeTodoResponse)(nil),         // 22: todo.v1.PurgeTodoResponse
+	(*AddTagsRequest)(nil),            // 23: todo.v1.AddTagsRequest
+	(*RemoveTagsRequest)(nil),         // 24: todo.v1.RemoveTagsRequest
+	(*ListTagsRequest)(nil),           // 25: todo.v1.ListTagsRequest
+	(*Tag)(nil),                       // 26: todo.v1.Tag
+	(*ListTagsResponse)(nil),          // 27: todo.v1.ListTagsResponse
+	(*GetTodoTreeRequest)(nil),        // 28: todo.v1.GetTodoTreeRequest
+	(*TodoNode)(nil),                  // 29: todo.v1.TodoNode
+	(*AddDependencyRequest)(nil),      // 30: todo.v1.AddDependencyRequest
+	(*RemoveDependencyRequest)(nil),   // 31: todo.v1.RemoveDependencyRequest
+	(*ShareTodoRequest)(nil),          // 32: todo.v1.ShareTodoRequest
+	(*UnshareTodoRequest)(nil),        // 33: todo.v1.UnshareTodoRequest
+	(*UnshareTodoResponse)(nil),       // 34: todo.v1.UnshareTodoResponse
+	(*ListCollaboratorsRequest)(nil),  // 35: todo.v1.ListCollaboratorsRequest
+	(*ListCollaboratorsResponse)(nil), // 36: todo.v1.ListCollaboratorsResponse
+	(*FieldChange)(nil),               // 37: todo.v1.FieldChange
+	(*TodoEvent)(nil),                 // 38: todo.v1.TodoEvent
+	(*ListTodoHistoryRequest)(nil),    // 39: todo.v1.ListTodoHistoryRequest
+	(*ListTodoHistoryResponse)(nil),   // 40: todo.v1.ListTodoHistoryResponse
+	(*WatchTodosRequest)(nil),         // 41: todo.v1.WatchTodosRequest
+	(*WatchTodosResponse)(nil),        // 42: todo.v1.WatchTodosResponse
+	(*BatchCreateTodosRequest)(nil),   // 43: todo.v1.BatchCreateTodosRequest
+	(*BatchUpdateTodosRequest)(nil),   // 44: todo.v1.BatchUpdateTodosRequest
+	(*BatchDeleteTodosRequest)(nil),   // 45: todo.v1.BatchDeleteTodosRequest
+	(*BatchTodoResult)(nil),           // 46: todo.v1.BatchTodoResult
+	(*BatchTodosResponse)(nil),        // 47: todo.v1.BatchTodosResponse
+	(*BulkUpdateByFilterRequest)(nil), // 48: todo.v1.BulkUpdateByFilterRequest
+	(*BulkDeleteByFilterRequest)(nil), // 49: todo.v1.BulkDeleteByFilterRequest
+	(*BulkResponse)(nil),              // 50: todo.v1.BulkResponse
+	(*ExportTodosRequest)(nil),        // 51: todo.v1.ExportTodosRequest
+	(*ExportTodosResponse)(nil),       // 52: todo.v1.ExportTodosResponse
+	(*ImportOptions)(nil),             // 53: todo.v1.ImportOptions
+	(*ImportTodosRequest)(nil),        // 54: todo.v1.ImportTodosRequest
+	(*ImportRowError)(nil),            // 55: todo.v1.ImportRowError
+	(*ImportTodosResponse)(nil),       // 56: todo.v1.ImportTodosResponse
+	(*ExportICalendarRequest)(nil),    // 57: todo.v1.ExportICalendarRequest
+	(*ImportICalendarRequest)(nil),    // 58: todo.v1.ImportICalendarRequest
+	(*fieldmaskpb.FieldMask)(nil),     // 59: google.protobuf.FieldMask
+	(Role)(0),                         // 60: todo.v1.Role
+	(*Collaborator)(nil),              // 61: todo.v1.Collaborator
+	(*structpb.Value)(nil),            // 62: google.protobuf.Value
 }
 var file_todo_v1_todo_proto_depIdxs = []int32{
-	0, // 0: todo.v1.CreateTodoResponse.todo:type_name -> todo.v1.Todo
-	0, // 1: todo.v1.ListTodosResponse.todos:type_name -> todo.v1.Todo
-	1, // 2: todo.v1.TodoService.CreateTodo:input_type -> todo.v1.CreateTodoRequest
-	3, // 3: todo.v1.TodoService.GetTodo:input_type -> todo.v1.GetTodoRequest
-	4, // 4: todo.v1.TodoService.ListTodos:input_type -> todo.v1.ListTodosRequest
-	6, // 5: todo.v1.TodoService.UpdateTodo:input_type -> todo.v1.UpdateTodoRequest
-	7, // 6: todo.v1.TodoService.DeleteTodo:input_type -> todo.v1.DeleteTodoRequest
-	2, // 7: todo.v1.TodoService.CreateTodo:output_type -> todo.v1.CreateTodoResponse
-	0, // 8: todo.v1.TodoService.GetTodo:output_type -> todo.v1.Todo
-	5, // 9: todo.v1.TodoService.ListTodos:output_type -> todo.v1.ListTodosResponse
-	0, // 10: todo.v1.TodoService.UpdateTodo:output_type -> todo.v1.Todo
-	8, // 11: todo.v1.TodoService.DeleteTodo:output_type -> todo.v1.DeleteTodoResponse
-	7, // [7:12] is the sub-list for method output_type
-	2, // [2:7] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	0,  // 0: todo.v1.Todo.priority:type_name -> todo.v1.Priority
+	1,  // 1: todo.v1.Todo.recurrence_mode:type_name -> todo.v1.RecurrenceMode
+	0,  // 2: todo.v1.CreateTodoRequest.priority:type_name -> todo.v1.Priority
+	1,  // 3: todo.v1.CreateTodoRequest.recurrence_mode:type_name -> todo.v1.RecurrenceMode
+	8,  // 4: todo.v1.CreateTodoResponse.todo:type_name -> todo.v1.Todo
+	3,  // 5: todo.v1.TodoFilter.tag_match:type_name -> todo.v1.TagMatch
+	4,  // 6: todo.v1.TodoFilter.ownership:type_name -> todo.v1.Ownership
+	12, // 7: todo.v1.ListTodosRequest.filter:type_name -> todo.v1.TodoFilter
+	2,  // 8: todo.v1.ListTodosRequest.order_by:type_name -> todo.v1.TodoOrder
+	8,  // 9: todo.v1.ListTodosResponse.todos:type_name -> todo.v1.Todo
+	59, // 10: todo.v1.UpdateTodoRequest.update_mask:type_name -> google.protobuf.FieldMask
+	0,  // 11: todo.v1.UpdateTodoRequest.priority:type_name -> todo.v1.Priority
+	1,  // 12: todo.v1.UpdateTodoRequest.recurrence_mode:type_name -> todo.v1.RecurrenceMode
+	8,  // 13: todo.v1.ListTrashResponse.todos:type_name -> todo.v1.Todo
+	26, // 14: todo.v1.ListTagsResponse.tags:type_name -> todo.v1.Tag
+	8,  // 15: todo.v1.TodoNode.todo:type_name -> todo.v1.Todo
+	29, // 16: todo.v1.TodoNode.children:type_name -> todo.v1.TodoNode
+	60, // 17: todo.v1.ShareTodoRequest.role:type_name -> todo.v1.Role
+	61, // 18: todo.v1.ListCollaboratorsResponse.collaborators:type_name -> todo.v1.Collaborator
+	62, // 19: todo.v1.FieldChange.before:type_name -> google.protobuf.Value
+	62, // 20: todo.v1.FieldChange.after:type_name -> google.protobuf.Value
+	5,  // 21: todo.v1.TodoEvent.operation:type_name -> todo.v1.TodoEventOperation
+	37, // 22: todo.v1.TodoEvent.changes:type_name -> todo.v1.FieldChange
+	38, // 23: todo.v1.ListTodoHistoryResponse.events:type_name -> todo.v1.TodoEvent
+	12, // 24: todo.v1.WatchTodosRequest.filter:type_name -> todo.v1.TodoFilter
+	5,  // 25: todo.v1.WatchTodosResponse.operation:type_name -> todo.v1.TodoEventOperation
+	8,  // 26: todo.v1.WatchTodosResponse.todo:type_name -> todo.v1.Todo
+	38, // 27: todo.v1.WatchTodosResponse.event:type_name -> todo.v1.TodoEvent
+	9,  // 28: todo.v1.BatchCreateTodosRequest.requests:type_name -> todo.v1.CreateTodoRequest
+	15, // 29: todo.v1.BatchUpdateTodosRequest.requests:type_name -> todo.v1.UpdateTodoRequest
+	16, // 30: todo.v1.BatchDeleteTodosRequest.requests:type_name -> todo.v1.DeleteTodoRequest
+	8,  // 31: todo.v1.BatchTodoResult.todo:type_name -> todo.v1.Todo
+	46, // 32: todo.v1.BatchTodosResponse.results:type_name -> todo.v1.BatchTodoResult
+	12, // 33: todo.v1.BulkUpdateByFilterRequest.filter:type_name -> todo.v1.TodoFilter
+	0,  // 34: todo.v1.BulkUpdateByFilterRequest.priority:type_name -> todo.v1.Priority
+	12, // 35: todo.v1.BulkDeleteByFilterRequest.filter:type_name -> todo.v1.TodoFilter
+	8,  // 36: todo.v1.BulkResponse.sample:type_name -> todo.v1.Todo
+	12, // 37: todo.v1.ExportTodosRequest.filter:type_name -> todo.v1.TodoFilter
+	6,  // 38: todo.v1.ExportTodosRequest.format:type_name -> todo.v1.TransferFormat
+	6,  // 39: todo.v1.ImportOptions.format:type_name -> todo.v1.TransferFormat
+	7,  // 40: todo.v1.ImportOptions.mode:type_name -> todo.v1.ImportMode
+	53, // 41: todo.v1.ImportTodosRequest.options:type_name -> todo.v1.ImportOptions
+	55, // 42: todo.v1.ImportTodosResponse.errors:type_name -> todo.v1.ImportRowError
+	12, // 43: todo.v1.ExportICalendarRequest.filter:type_name -> todo.v1.TodoFilter
+	9,  // 44: todo.v1.TodoService.CreateTodo:input_type -> todo.v1.CreateTodoRequest
+	11, // 45: todo.v1.TodoService.GetTodo:input_type -> todo.v1.GetTodoRequest
+	13, // 46: todo.v1.TodoService.ListTodos:input_type -> todo.v1.ListTodosRequest
+	15, // 47: todo.v1.TodoService.UpdateTodo:input_type -> todo.v1.UpdateTodoRequest
+	16, // 48: todo.v1.TodoService.DeleteTodo:input_type -> todo.v1.DeleteTodoRequest
+	18, // 49: todo.v1.TodoService.RestoreTodo:input_type -> todo.v1.RestoreTodoRequest
+	19, // 50: todo.v1.TodoService.ListTrash:input_type -> todo.v1.ListTrashRequest
+	21, // 51: todo.v1.TodoService.PurgeTodo:input_type -> todo.v1.PurgeTodoRequest
+	23, // 52: todo.v1.TodoService.AddTags:input_type -> todo.v1.AddTagsRequest
+	24, // 53: todo.v1.TodoService.RemoveTags:input_type -> todo.v1.RemoveTagsRequest
+	25, // 54: todo.v1.TodoService.ListTags:input_type -> todo.v1.ListTagsRequest
+	28, // 55: todo.v1.TodoService.GetTodoTree:input_type -> todo.v1.GetTodoTreeRequest
+	30, // 56: todo.v1.TodoService.AddDependency:input_type -> todo.v1.AddDependencyRequest
+	31, // 57: todo.v1.TodoService.RemoveDependency:input_type -> todo.v1.RemoveDependencyRequest
+	32, // 58: todo.v1.TodoService.ShareTodo:input_type -> todo.v1.ShareTodoRequest
+	33, // 59: todo.v1.TodoService.UnshareTodo:input_type -> todo.v1.UnshareTodoRequest
+	35, // 60: todo.v1.TodoService.ListCollaborators:input_type -> todo.v1.ListCollaboratorsRequest
+	39, // 61: todo.v1.TodoService.ListTodoHistory:input_type -> todo.v1.ListTodoHistoryRequest
+	41, // 62: todo.v1.TodoService.WatchTodos:input_type -> todo.v1.WatchTodosRequest
+	43, // 63: todo.v1.TodoService.BatchCreateTodos:input_type -> todo.v1.BatchCreateTodosRequest
+	44, // 64: todo.v1.TodoService.BatchUpdateTodos:input_type -> todo.v1.BatchUpdateTodosRequest
+	45, // 65: todo.v1.TodoService.BatchDeleteTodos:input_type -> todo.v1.BatchDeleteTodosRequest
+	48, // 66: todo.v1.TodoService.BulkUpdateByFilter:input_type -> todo.v1.BulkUpdateByFilterRequest
+	49, // 67: todo.v1.TodoService.BulkDeleteByFilter:input_type -> todo.v1.BulkDeleteByFilterRequest
+	51, // 68: todo.v1.TodoService.ExportTodos:input_type -> todo.v1.ExportTodosRequest
+	54, // 69: todo.v1.TodoService.ImportTodos:input_type -> todo.v1.ImportTodosRequest
+	57, // 70: todo.v1.TodoService.ExportICalendar:input_type -> todo.v1.ExportICalendarRequest
+	58, // 71: todo.v1.TodoService.ImportICalendar:input_type -> todo.v1.ImportICalendarRequest
+	10, // 72: todo.v1.TodoService.CreateTodo:output_type -> todo.v1.CreateTodoResponse
+	8,  // 73: todo.v1.TodoService.GetTodo:output_type -> todo.v1.Todo
+	14, // 74: todo.v1.TodoService.ListTodos:output_type -> todo.v1.ListTodosResponse
+	8,  // 75: todo.v1.TodoService.UpdateTodo:output_type -> todo.v1.Todo
+	17, // 76: todo.v1.TodoService.DeleteTodo:output_type -> todo.v1.DeleteTodoResponse
+	8,  // 77: todo.v1.TodoService.RestoreTodo:output_type -> todo.v1.Todo
+	20, // 78: todo.v1.TodoService.ListTrash:output_type -> todo.v1.ListTrashResponse
+	22, // 79: todo.v1.TodoService.PurgeTodo:output_type -> todo.v1.PurgeTodoResponse
+	8,  // 80: todo.v1.TodoService.AddTags:output_type -> todo.v1.Todo
+	8,  // 81: todo.v1.TodoService.RemoveTags:output_type -> todo.v1.Todo
+	27, // 82: todo.v1.TodoService.ListTags:output_type -> todo.v1.ListTagsResponse
+	29, // 83: todo.v1.TodoService.GetTodoTree:output_type -> todo.v1.TodoNode
+	8,  // 84: todo.v1.TodoService.AddDependency:output_type -> todo.v1.Todo
+	8,  // 85: todo.v1.TodoService.RemoveDependency:output_type -> todo.v1.Todo
+	61, // 86: todo.v1.TodoService.ShareTodo:output_type -> todo.v1.Collaborator
+	34, // 87: todo.v1.TodoService.UnshareTodo:output_type -> todo.v1.UnshareTodoResponse
+	36, // 88: todo.v1.TodoService.ListCollaborators:output_type -> todo.v1.ListCollaboratorsResponse
+	40, // 89: todo.v1.TodoService.ListTodoHistory:output_type -> todo.v1.ListTodoHistoryResponse
+	42, // 90: todo.v1.TodoService.WatchTodos:output_type -> todo.v1.WatchTodosResponse
+	47, // 91: todo.v1.TodoService.BatchCreateTodos:output_type -> todo.v1.BatchTodosResponse
+	47, // 92: todo.v1.TodoService.BatchUpdateTodos:output_type -> todo.v1.BatchTodosResponse
+	47, // 93: todo.v1.TodoService.BatchDeleteTodos:output_type -> todo.v1.BatchTodosResponse
+	50, // 94: todo.v1.TodoService.BulkUpdateByFilter:output_type -> todo.v1.BulkResponse
+	50, // 95: todo.v1.TodoService.BulkDeleteByFilter:output_type -> todo.v1.BulkResponse
+	52, // 96: todo.v1.TodoService.ExportTodos:output_type -> todo.v1.ExportTodosResponse
+	56, // 97: todo.v1.TodoService.ImportTodos:output_type -> todo.v1.ImportTodosResponse
+	52, // 98: todo.v1.TodoService.ExportICalendar:output_type -> todo.v1.ExportTodosResponse
+	56, // 99: todo.v1.TodoService.ImportICalendar:output_type -> todo.v1.ImportTodosResponse
+	72, // [72:100] is the sub-list for method output_type
+	44, // [44:72] is the sub-list for method input_type
+	44, // [44:44] is the sub-list for extension type_name
+	44, // [44:44] is the sub-list for extension extendee
+	0,  // [0:44] is the sub-list for field type_name
 }
 
 func init() { file_todo_v1_todo_proto_init() }
@@ -589,18 +4420,29 @@ func file_todo_v1_todo_proto_init() {
 	if File_todo_v1_todo_proto != nil {
 		return
 	}
+	file_todo_v1_sharing_proto_init()
+	file_todo_v1_todo_proto_msgTypes[0].OneofWrappers = []any{}
+	file_todo_v1_todo_proto_msgTypes[1].OneofWrappers = []any{}
+	file_todo_v1_todo_proto_msgTypes[4].OneofWrappers = []any{}
+	file_todo_v1_todo_proto_msgTypes[7].OneofWrappers = []any{}
+	file_todo_v1_todo_proto_msgTypes[40].OneofWrappers = []any{}
+	file_todo_v1_todo_proto_msgTypes[46].OneofWrappers = []any{
+		(*ImportTodosRequest_Options)(nil),
+		(*ImportTodosRequest_Chunk)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_todo_v1_todo_proto_rawDesc), len(file_todo_v1_todo_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   9,
+			NumEnums:      8,
+			NumMessages:   51,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_todo_v1_todo_proto_goTypes,
 		DependencyIndexes: file_todo_v1_todo_proto_depIdxs,
+		EnumInfos:         file_todo_v1_todo_proto_enumTypes,
 		MessageInfos:      file_todo_v1_todo_proto_msgTypes,
 	}.Build()
 	File_todo_v1_todo_proto = out.File
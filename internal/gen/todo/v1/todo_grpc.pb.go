@@ -19,11 +19,34 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	TodoService_CreateTodo_FullMethodName = "/todo.v1.TodoService/CreateTodo"
-	TodoService_GetTodo_FullMethodName    = "/todo.v1.TodoService/GetTodo"
-	TodoService_ListTodos_FullMethodName  = "/todo.v1.TodoService/ListTodos"
-	TodoService_UpdateTodo_FullMethodName = "/todo.v1.TodoService/UpdateTodo"
-	TodoService_DeleteTodo_FullMethodName = "/todo.v1.TodoService/DeleteTodo"
+	TodoService_CreateTodo_FullMethodName         = "/todo.v1.TodoService/CreateTodo"
+	TodoService_GetTodo_FullMethodName            = "/todo.v1.TodoService/GetTodo"
+	TodoService_ListTodos_FullMethodName          = "/todo.v1.TodoService/ListTodos"
+	TodoService_UpdateTodo_FullMethodName         = "/todo.v1.TodoService/UpdateTodo"
+	TodoService_DeleteTodo_FullMethodName         = "/todo.v1.TodoService/DeleteTodo"
+	TodoService_RestoreTodo_FullMethodName        = "/todo.v1.TodoService/RestoreTodo"
+	TodoService_ListTrash_FullMethodName          = "/todo.v1.TodoService/ListTrash"
+	TodoService_PurgeTodo_FullMethodName          = "/todo.v1.TodoService/PurgeTodo"
+	TodoService_AddTags_FullMethodName            = "/todo.v1.TodoService/AddTags"
+	TodoService_RemoveTags_FullMethodName         = "/todo.v1.TodoService/RemoveTags"
+	TodoService_ListTags_FullMethodName           = "/todo.v1.TodoService/ListTags"
+	TodoService_GetTodoTree_FullMethodName        = "/todo.v1.TodoService/GetTodoTree"
+	TodoService_AddDependency_FullMethodName      = "/todo.v1.TodoService/AddDependency"
+	TodoService_RemoveDependency_FullMethodName   = "/todo.v1.TodoService/RemoveDependency"
+	TodoService_ShareTodo_FullMethodName          = "/todo.v1.TodoService/ShareTodo"
+	TodoService_UnshareTodo_FullMethodName        = "/todo.v1.TodoService/UnshareTodo"
+	TodoService_ListCollaborators_FullMethodName  = "/todo.v1.TodoService/ListCollaborators"
+	TodoService_ListTodoHistory_FullMethodName    = "/todo.v1.TodoService/ListTodoHistory"
+	TodoService_WatchTodos_FullMethodName         = "/todo.v1.TodoService/WatchTodos"
+	TodoService_BatchCreateTodos_FullMethodName   = "/todo.v1.TodoService/BatchCreateTodos"
+	TodoService_BatchUpdateTodos_FullMethodName   = "/todo.v1.TodoService/BatchUpdateTodos"
+	TodoService_BatchDeleteTodos_FullMethodName   = "/todo.v1.TodoService/BatchDeleteTodos"
+	TodoService_BulkUpdateByFilter_FullMethodName = "/todo.v1.TodoService/BulkUpdateByFilter"
+	TodoService_BulkDeleteByFilter_FullMethodName = "/todo.v1.TodoService/BulkDeleteByFilter"
+	TodoService_ExportTodos_FullMethodName        = "/todo.v1.TodoService/ExportTodos"
+	TodoService_ImportTodos_FullMethodName        = "/todo.v1.TodoService/ImportTodos"
+	TodoService_ExportICalendar_FullMethodName    = "/todo.v1.TodoService/ExportICalendar"
+	TodoService_ImportICalendar_FullMethodName    = "/todo.v1.TodoService/ImportICalendar"
 )
 
 // TodoServiceClient is the client API for TodoService service.
@@ -36,12 +59,60 @@ type TodoServiceClient interface {
 	CreateTodo(ctx context.Context, in *CreateTodoRequest, opts ...grpc.CallOption) (*CreateTodoResponse, error)
 	// Получает задачу по идентификатору.
 	GetTodo(ctx context.Context, in *GetTodoRequest, opts ...grpc.CallOption) (*Todo, error)
-	// Возвращает список всех задач.
+	// Возвращает страницу задач с учётом фильтров и сортировки.
 	ListTodos(ctx context.Context, in *ListTodosRequest, opts ...grpc.CallOption) (*ListTodosResponse, error)
-	// Обновляет существующую задачу.
+	// Обновляет поля существующей задачи, перечисленные в update_mask.
 	UpdateTodo(ctx context.Context, in *UpdateTodoRequest, opts ...grpc.CallOption) (*Todo, error)
-	// Удаляет задачу по идентификатору.
+	// Перемещает задачу в корзину.
 	DeleteTodo(ctx context.Context, in *DeleteTodoRequest, opts ...grpc.CallOption) (*DeleteTodoResponse, error)
+	// Восстанавливает задачу из корзины.
+	RestoreTodo(ctx context.Context, in *RestoreTodoRequest, opts ...grpc.CallOption) (*Todo, error)
+	// Возвращает страницу задач из корзины, начиная с недавно удалённых.
+	ListTrash(ctx context.Context, in *ListTrashRequest, opts ...grpc.CallOption) (*ListTrashResponse, error)
+	// Окончательно удаляет задачу из корзины.
+	PurgeTodo(ctx context.Context, in *PurgeTodoRequest, opts ...grpc.CallOption) (*PurgeTodoResponse, error)
+	// Добавляет задаче метки.
+	AddTags(ctx context.Context, in *AddTagsRequest, opts ...grpc.CallOption) (*Todo, error)
+	// Снимает с задачи метки.
+	RemoveTags(ctx context.Context, in *RemoveTagsRequest, opts ...grpc.CallOption) (*Todo, error)
+	// Возвращает используемые метки с числом задач.
+	ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error)
+	// Возвращает задачу вместе со всеми подзадачами.
+	GetTodoTree(ctx context.Context, in *GetTodoTreeRequest, opts ...grpc.CallOption) (*TodoNode, error)
+	// Отмечает, что задача заблокирована другой задачей.
+	AddDependency(ctx context.Context, in *AddDependencyRequest, opts ...grpc.CallOption) (*Todo, error)
+	// Снимает блокировку задачи другой задачей.
+	RemoveDependency(ctx context.Context, in *RemoveDependencyRequest, opts ...grpc.CallOption) (*Todo, error)
+	// Выдаёт пользователю роль на задачу или меняет выданную; доступно владельцу.
+	ShareTodo(ctx context.Context, in *ShareTodoRequest, opts ...grpc.CallOption) (*Collaborator, error)
+	// Отзывает роль пользователя на задачу.
+	UnshareTodo(ctx context.Context, in *UnshareTodoRequest, opts ...grpc.CallOption) (*UnshareTodoResponse, error)
+	// Возвращает владельца задачи и пользователей с ролями на неё.
+	ListCollaborators(ctx context.Context, in *ListCollaboratorsRequest, opts ...grpc.CallOption) (*ListCollaboratorsResponse, error)
+	// Возвращает журнал изменений задачи от новых событий к старым. Журнал
+	// окончательно удалённой задачи доступен всем пользователям арендатора.
+	ListTodoHistory(ctx context.Context, in *ListTodoHistoryRequest, opts ...grpc.CallOption) (*ListTodoHistoryResponse, error)
+	// Передаёт изменения задач, подходящих под фильтр, по мере их появления.
+	WatchTodos(ctx context.Context, in *WatchTodosRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchTodosResponse], error)
+	// Создаёт несколько задач за один вызов.
+	BatchCreateTodos(ctx context.Context, in *BatchCreateTodosRequest, opts ...grpc.CallOption) (*BatchTodosResponse, error)
+	// Изменяет несколько задач за один вызов.
+	BatchUpdateTodos(ctx context.Context, in *BatchUpdateTodosRequest, opts ...grpc.CallOption) (*BatchTodosResponse, error)
+	// Перемещает несколько задач в корзину за один вызов.
+	BatchDeleteTodos(ctx context.Context, in *BatchDeleteTodosRequest, opts ...grpc.CallOption) (*BatchTodosResponse, error)
+	// Изменяет все задачи по фильтру, которые вызывающий может изменять.
+	BulkUpdateByFilter(ctx context.Context, in *BulkUpdateByFilterRequest, opts ...grpc.CallOption) (*BulkResponse, error)
+	// Перемещает в корзину все задачи по фильтру, которые вызывающий может изменять.
+	BulkDeleteByFilter(ctx context.Context, in *BulkDeleteByFilterRequest, opts ...grpc.CallOption) (*BulkResponse, error)
+	// Выгружает задачи по фильтру файлом NDJSON или CSV, разбитым на части.
+	ExportTodos(ctx context.Context, in *ExportTodosRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportTodosResponse], error)
+	// Загружает задачи из файла NDJSON или CSV; каждая строка проверяется отдельно.
+	ImportTodos(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportTodosRequest, ImportTodosResponse], error)
+	// Выгружает задачи по фильтру календарём iCalendar (VTODO), разбитым на части.
+	ExportICalendar(ctx context.Context, in *ExportICalendarRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportTodosResponse], error)
+	// Загружает задачи из календаря iCalendar: задача с уже известным UID
+	// изменяется, а не создаётся заново.
+	ImportICalendar(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportICalendarRequest, ImportTodosResponse], error)
 }
 
 type todoServiceClient struct {
@@ -102,6 +173,269 @@ func (c *todoServiceClient) DeleteTodo(ctx context.Context, in *DeleteTodoReques
 	return out, nil
 }
 
+func (c *todoServiceClient) RestoreTodo(ctx context.Context, in *RestoreTodoRequest, opts ...grpc.CallOption) (*Todo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Todo)
+	err := c.cc.Invoke(ctx, TodoService_RestoreTodo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) ListTrash(ctx context.Context, in *ListTrashRequest, opts ...grpc.CallOption) (*ListTrashResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTrashResponse)
+	err := c.cc.Invoke(ctx, TodoService_ListTrash_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) PurgeTodo(ctx context.Context, in *PurgeTodoRequest, opts ...grpc.CallOption) (*PurgeTodoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PurgeTodoResponse)
+	err := c.cc.Invoke(ctx, TodoService_PurgeTodo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) AddTags(ctx context.Context, in *AddTagsRequest, opts ...grpc.CallOption) (*Todo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Todo)
+	err := c.cc.Invoke(ctx, TodoService_AddTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) RemoveTags(ctx context.Context, in *RemoveTagsRequest, opts ...grpc.CallOption) (*Todo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Todo)
+	err := c.cc.Invoke(ctx, TodoService_RemoveTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTagsResponse)
+	err := c.cc.Invoke(ctx, TodoService_ListTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) GetTodoTree(ctx context.Context, in *GetTodoTreeRequest, opts ...grpc.CallOption) (*TodoNode, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TodoNode)
+	err := c.cc.Invoke(ctx, TodoService_GetTodoTree_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) AddDependency(ctx context.Context, in *AddDependencyRequest, opts ...grpc.CallOption) (*Todo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Todo)
+	err := c.cc.Invoke(ctx, TodoService_AddDependency_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) RemoveDependency(ctx context.Context, in *RemoveDependencyRequest, opts ...grpc.CallOption) (*Todo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Todo)
+	err := c.cc.Invoke(ctx, TodoService_RemoveDependency_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) ShareTodo(ctx context.Context, in *ShareTodoRequest, opts ...grpc.CallOption) (*Collaborator, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Collaborator)
+	err := c.cc.Invoke(ctx, TodoService_ShareTodo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) UnshareTodo(ctx context.Context, in *UnshareTodoRequest, opts ...grpc.CallOption) (*UnshareTodoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnshareTodoResponse)
+	err := c.cc.Invoke(ctx, TodoService_UnshareTodo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) ListCollaborators(ctx context.Context, in *ListCollaboratorsRequest, opts ...grpc.CallOption) (*ListCollaboratorsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCollaboratorsResponse)
+	err := c.cc.Invoke(ctx, TodoService_ListCollaborators_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) ListTodoHistory(ctx context.Context, in *ListTodoHistoryRequest, opts ...grpc.CallOption) (*ListTodoHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTodoHistoryResponse)
+	err := c.cc.Invoke(ctx, TodoService_ListTodoHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) WatchTodos(ctx context.Context, in *WatchTodosRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchTodosResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TodoService_ServiceDesc.Streams[0], TodoService_WatchTodos_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchTodosRequest, WatchTodosResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TodoService_WatchTodosClient = grpc.ServerStreamingClient[WatchTodosResponse]
+
+func (c *todoServiceClient) BatchCreateTodos(ctx context.Context, in *BatchCreateTodosRequest, opts ...grpc.CallOption) (*BatchTodosResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchTodosResponse)
+	err := c.cc.Invoke(ctx, TodoService_BatchCreateTodos_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) BatchUpdateTodos(ctx context.Context, in *BatchUpdateTodosRequest, opts ...grpc.CallOption) (*BatchTodosResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchTodosResponse)
+	err := c.cc.Invoke(ctx, TodoService_BatchUpdateTodos_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) BatchDeleteTodos(ctx context.Context, in *BatchDeleteTodosRequest, opts ...grpc.CallOption) (*BatchTodosResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchTodosResponse)
+	err := c.cc.Invoke(ctx, TodoService_BatchDeleteTodos_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) BulkUpdateByFilter(ctx context.Context, in *BulkUpdateByFilterRequest, opts ...grpc.CallOption) (*BulkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkResponse)
+	err := c.cc.Invoke(ctx, TodoService_BulkUpdateByFilter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) BulkDeleteByFilter(ctx context.Context, in *BulkDeleteByFilterRequest, opts ...grpc.CallOption) (*BulkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkResponse)
+	err := c.cc.Invoke(ctx, TodoService_BulkDeleteByFilter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) ExportTodos(ctx context.Context, in *ExportTodosRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportTodosResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TodoService_ServiceDesc.Streams[1], TodoService_ExportTodos_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportTodosRequest, ExportTodosResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TodoService_ExportTodosClient = grpc.ServerStreamingClient[ExportTodosResponse]
+
+func (c *todoServiceClient) ImportTodos(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportTodosRequest, ImportTodosResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TodoService_ServiceDesc.Streams[2], TodoService_ImportTodos_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportTodosRequest, ImportTodosResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TodoService_ImportTodosClient = grpc.ClientStreamingClient[ImportTodosRequest, ImportTodosResponse]
+
+func (c *todoServiceClient) ExportICalendar(ctx context.Context, in *ExportICalendarRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportTodosResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TodoService_ServiceDesc.Streams[3], TodoService_ExportICalendar_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportICalendarRequest, ExportTodosResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TodoService_ExportICalendarClient = grpc.ServerStreamingClient[ExportTodosResponse]
+
+func (c *todoServiceClient) ImportICalendar(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportICalendarRequest, ImportTodosResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TodoService_ServiceDesc.Streams[4], TodoService_ImportICalendar_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportICalendarRequest, ImportTodosResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TodoService_ImportICalendarClient = grpc.ClientStreamingClient[ImportICalendarRequest, ImportTodosResponse]
+
 // TodoServiceServer is the server API for TodoService service.
 // All implementations must embed UnimplementedTodoServiceServer
 // for forward compatibility.
@@ -112,12 +446,60 @@ type TodoServiceServer interface {
 	CreateTodo(context.Context, *CreateTodoRequest) (*CreateTodoResponse, error)
 	// Получает задачу по идентификатору.
 	GetTodo(context.Context, *GetTodoRequest) (*Todo, error)
-	// Возвращает список всех задач.
+	// Возвращает страницу задач с учётом фильтров и сортировки.
 	ListTodos(context.Context, *ListTodosRequest) (*ListTodosResponse, error)
-	// Обновляет существующую задачу.
+	// Обновляет поля существующей задачи, перечисленные в update_mask.
 	UpdateTodo(context.Context, *UpdateTodoRequest) (*Todo, error)
-	// Удаляет задачу по идентификатору.
+	// Перемещает задачу в корзину.
 	DeleteTodo(context.Context, *DeleteTodoRequest) (*DeleteTodoResponse, error)
+	// Восстанавливает задачу из корзины.
+	RestoreTodo(context.Context, *RestoreTodoRequest) (*Todo, error)
+	// Возвращает страницу задач из корзины, начиная с недавно удалённых.
+	ListTrash(context.Context, *ListTrashRequest) (*ListTrashResponse, error)
+	// Окончательно удаляет задачу из корзины.
+	PurgeTodo(context.Context, *PurgeTodoRequest) (*PurgeTodoResponse, error)
+	// Добавляет задаче метки.
+	AddTags(context.Context, *AddTagsRequest) (*Todo, error)
+	// Снимает с задачи метки.
+	RemoveTags(context.Context, *RemoveTagsRequest) (*Todo, error)
+	// Возвращает используемые метки с числом задач.
+	ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error)
+	// Возвращает задачу вместе со всеми подзадачами.
+	GetTodoTree(context.Context, *GetTodoTreeRequest) (*TodoNode, error)
+	// Отмечает, что задача заблокирована другой задачей.
+	AddDependency(context.Context, *AddDependencyRequest) (*Todo, error)
+	// Снимает блокировку задачи другой задачей.
+	RemoveDependency(context.Context, *RemoveDependencyRequest) (*Todo, error)
+	// Выдаёт пользователю роль на задачу или меняет выданную; доступно владельцу.
+	ShareTodo(context.Context, *ShareTodoRequest) (*Collaborator, error)
+	// Отзывает роль пользователя на задачу.
+	UnshareTodo(context.Context, *UnshareTodoRequest) (*UnshareTodoResponse, error)
+	// Возвращает владельца задачи и пользователей с ролями на неё.
+	ListCollaborators(context.Context, *ListCollaboratorsRequest) (*ListCollaboratorsResponse, error)
+	// Возвращает журнал изменений задачи от новых событий к старым. Журнал
+	// окончательно удалённой задачи доступен всем пользователям арендатора.
+	ListTodoHistory(context.Context, *ListTodoHistoryRequest) (*ListTodoHistoryResponse, error)
+	// Передаёт изменения задач, подходящих под фильтр, по мере их появления.
+	WatchTodos(*WatchTodosRequest, grpc.ServerStreamingServer[WatchTodosResponse]) error
+	// Создаёт несколько задач за один вызов.
+	BatchCreateTodos(context.Context, *BatchCreateTodosRequest) (*BatchTodosResponse, error)
+	// Изменяет несколько задач за один вызов.
+	BatchUpdateTodos(context.Context, *BatchUpdateTodosRequest) (*BatchTodosResponse, error)
+	// Перемещает несколько задач в корзину за один вызов.
+	BatchDeleteTodos(context.Context, *BatchDeleteTodosRequest) (*BatchTodosResponse, error)
+	// Изменяет все задачи по фильтру, которые вызывающий может изменять.
+	BulkUpdateByFilter(context.Context, *BulkUpdateByFilterRequest) (*BulkResponse, error)
+	// Перемещает в корзину все задачи по фильтру, которые вызывающий может изменять.
+	BulkDeleteByFilter(context.Context, *BulkDeleteByFilterRequest) (*BulkResponse, error)
+	// Выгружает задачи по фильтру файлом NDJSON или CSV, разбитым на части.
+	ExportTodos(*ExportTodosRequest, grpc.ServerStreamingServer[ExportTodosResponse]) error
+	// Загружает задачи из файла NDJSON или CSV; каждая строка проверяется отдельно.
+	ImportTodos(grpc.ClientStreamingServer[ImportTodosRequest, ImportTodosResponse]) error
+	// Выгружает задачи по фильтру календарём iCalendar (VTODO), разбитым на части.
+	ExportICalendar(*ExportICalendarRequest, grpc.ServerStreamingServer[ExportTodosResponse]) error
+	// Загружает задачи из календаря iCalendar: задача с уже известным UID
+	// изменяется, а не создаётся заново.
+	ImportICalendar(grpc.ClientStreamingServer[ImportICalendarRequest, ImportTodosResponse]) error
 	mustEmbedUnimplementedTodoServiceServer()
 }
 
@@ -143,6 +525,75 @@ func (UnimplementedTodoServiceServer) UpdateTodo(context.Context, *UpdateTodoReq
 func (UnimplementedTodoServiceServer) DeleteTodo(context.Context, *DeleteTodoRequest) (*DeleteTodoResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteTodo not implemented")
 }
+func (UnimplementedTodoServiceServer) RestoreTodo(context.Context, *RestoreTodoRequest) (*Todo, error) {
+	return nil, status.Error(codes.Unimplemented, "method RestoreTodo not implemented")
+}
+func (UnimplementedTodoServiceServer) ListTrash(context.Context, *ListTrashRequest) (*ListTrashResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListTrash not implemented")
+}
+func (UnimplementedTodoServiceServer) PurgeTodo(context.Context, *PurgeTodoRequest) (*PurgeTodoResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PurgeTodo not implemented")
+}
+func (UnimplementedTodoServiceServer) AddTags(context.Context, *AddTagsRequest) (*Todo, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddTags not implemented")
+}
+func (UnimplementedTodoServiceServer) RemoveTags(context.Context, *RemoveTagsRequest) (*Todo, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveTags not implemented")
+}
+func (UnimplementedTodoServiceServer) ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListTags not implemented")
+}
+func (UnimplementedTodoServiceServer) GetTodoTree(context.Context, *GetTodoTreeRequest) (*TodoNode, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTodoTree not implemented")
+}
+func (UnimplementedTodoServiceServer) AddDependency(context.Context, *AddDependencyRequest) (*Todo, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddDependency not implemented")
+}
+func (UnimplementedTodoServiceServer) RemoveDependency(context.Context, *RemoveDependencyRequest) (*Todo, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveDependency not implemented")
+}
+func (UnimplementedTodoServiceServer) ShareTodo(context.Context, *ShareTodoRequest) (*Collaborator, error) {
+	return nil, status.Error(codes.Unimplemented, "method ShareTodo not implemented")
+}
+func (UnimplementedTodoServiceServer) UnshareTodo(context.Context, *UnshareTodoRequest) (*UnshareTodoResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UnshareTodo not implemented")
+}
+func (UnimplementedTodoServiceServer) ListCollaborators(context.Context, *ListCollaboratorsRequest) (*ListCollaboratorsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListCollaborators not implemented")
+}
+func (UnimplementedTodoServiceServer) ListTodoHistory(context.Context, *ListTodoHistoryRequest) (*ListTodoHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListTodoHistory not implemented")
+}
+func (UnimplementedTodoServiceServer) WatchTodos(*WatchTodosRequest, grpc.ServerStreamingServer[WatchTodosResponse]) error {
+	return status.Error(codes.Unimplemented, "method WatchTodos not implemented")
+}
+func (UnimplementedTodoServiceServer) BatchCreateTodos(context.Context, *BatchCreateTodosRequest) (*BatchTodosResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BatchCreateTodos not implemented")
+}
+func (UnimplementedTodoServiceServer) BatchUpdateTodos(context.Context, *BatchUpdateTodosRequest) (*BatchTodosResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BatchUpdateTodos not implemented")
+}
+func (UnimplementedTodoServiceServer) BatchDeleteTodos(context.Context, *BatchDeleteTodosRequest) (*BatchTodosResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BatchDeleteTodos not implemented")
+}
+func (UnimplementedTodoServiceServer) BulkUpdateByFilter(context.Context, *BulkUpdateByFilterRequest) (*BulkResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BulkUpdateByFilter not implemented")
+}
+func (UnimplementedTodoServiceServer) BulkDeleteByFilter(context.Context, *BulkDeleteByFilterRequest) (*BulkResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BulkDeleteByFilter not implemented")
+}
+func (UnimplementedTodoServiceServer) ExportTodos(*ExportTodosRequest, grpc.ServerStreamingServer[ExportTodosResponse]) error {
+	return status.Error(codes.Unimplemented, "method ExportTodos not implemented")
+}
+func (UnimplementedTodoServiceServer) ImportTodos(grpc.ClientStreamingServer[ImportTodosRequest, ImportTodosResponse]) error {
+	return status.Error(codes.Unimplemented, "method ImportTodos not implemented")
+}
+func (UnimplementedTodoServiceServer) ExportICalendar(*ExportICalendarRequest, grpc.ServerStreamingServer[ExportTodosResponse]) error {
+	return status.Error(codes.Unimplemented, "method ExportICalendar not implemented")
+}
+func (UnimplementedTodoServiceServer) ImportICalendar(grpc.ClientStreamingServer[ImportICalendarRequest, ImportTodosResponse]) error {
+	return status.Error(codes.Unimplemented, "method ImportICalendar not implemented")
+}
 func (UnimplementedTodoServiceServer) mustEmbedUnimplementedTodoServiceServer() {}
 func (UnimplementedTodoServiceServer) testEmbeddedByValue()                     {}
 
@@ -254,6 +705,377 @@ func _TodoService_DeleteTodo_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TodoService_RestoreTodo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreTodoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).RestoreTodo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_RestoreTodo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).RestoreTodo(ctx, req.(*RestoreTodoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_ListTrash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTrashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).ListTrash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_ListTrash_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).ListTrash(ctx, req.(*ListTrashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_PurgeTodo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeTodoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).PurgeTodo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_PurgeTodo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).PurgeTodo(ctx, req.(*PurgeTodoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_AddTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).AddTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_AddTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).AddTags(ctx, req.(*AddTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_RemoveTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).RemoveTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_RemoveTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).RemoveTags(ctx, req.(*RemoveTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_ListTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).ListTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_ListTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).ListTags(ctx, req.(*ListTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_GetTodoTree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTodoTreeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).GetTodoTree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_GetTodoTree_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).GetTodoTree(ctx, req.(*GetTodoTreeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_AddDependency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddDependencyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).AddDependency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_AddDependency_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).AddDependency(ctx, req.(*AddDependencyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_RemoveDependency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveDependencyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).RemoveDependency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_RemoveDependency_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).RemoveDependency(ctx, req.(*RemoveDependencyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_ShareTodo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareTodoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).ShareTodo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_ShareTodo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).ShareTodo(ctx, req.(*ShareTodoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_UnshareTodo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnshareTodoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).UnshareTodo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_UnshareTodo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).UnshareTodo(ctx, req.(*UnshareTodoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_ListCollaborators_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCollaboratorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).ListCollaborators(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_ListCollaborators_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).ListCollaborators(ctx, req.(*ListCollaboratorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_ListTodoHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTodoHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).ListTodoHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_ListTodoHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).ListTodoHistory(ctx, req.(*ListTodoHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_WatchTodos_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchTodosRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TodoServiceServer).WatchTodos(m, &grpc.GenericServerStream[WatchTodosRequest, WatchTodosResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TodoService_WatchTodosServer = grpc.ServerStreamingServer[WatchTodosResponse]
+
+func _TodoService_BatchCreateTodos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateTodosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).BatchCreateTodos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_BatchCreateTodos_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).BatchCreateTodos(ctx, req.(*BatchCreateTodosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_BatchUpdateTodos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchUpdateTodosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).BatchUpdateTodos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_BatchUpdateTodos_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).BatchUpdateTodos(ctx, req.(*BatchUpdateTodosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_BatchDeleteTodos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchDeleteTodosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).BatchDeleteTodos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_BatchDeleteTodos_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).BatchDeleteTodos(ctx, req.(*BatchDeleteTodosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_BulkUpdateByFilter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkUpdateByFilterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).BulkUpdateByFilter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_BulkUpdateByFilter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).BulkUpdateByFilter(ctx, req.(*BulkUpdateByFilterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_BulkDeleteByFilter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkDeleteByFilterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).BulkDeleteByFilter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_BulkDeleteByFilter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).BulkDeleteByFilter(ctx, req.(*BulkDeleteByFilterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_ExportTodos_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportTodosRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TodoServiceServer).ExportTodos(m, &grpc.GenericServerStream[ExportTodosRequest, ExportTodosResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TodoService_ExportTodosServer = grpc.ServerStreamingServer[ExportTodosResponse]
+
+func _TodoService_ImportTodos_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TodoServiceServer).ImportTodos(&grpc.GenericServerStream[ImportTodosRequest, ImportTodosResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TodoService_ImportTodosServer = grpc.ClientStreamingServer[ImportTodosRequest, ImportTodosResponse]
+
+func _TodoService_ExportICalendar_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportICalendarRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TodoServiceServer).ExportICalendar(m, &grpc.GenericServerStream[ExportICalendarRequest, ExportTodosResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TodoService_ExportICalendarServer = grpc.ServerStreamingServer[ExportTodosResponse]
+
+func _TodoService_ImportICalendar_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TodoServiceServer).ImportICalendar(&grpc.GenericServerStream[ImportICalendarRequest, ImportTodosResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TodoService_ImportICalendarServer = grpc.ClientStreamingServer[ImportICalendarRequest, ImportTodosResponse]
+
 // TodoService_ServiceDesc is the grpc.ServiceDesc for TodoService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -281,7 +1103,105 @@ var TodoService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteTodo",
 			Handler:    _TodoService_DeleteTodo_Handler,
 		},
+		{
+			MethodName: "RestoreTodo",
+			Handler:    _TodoService_RestoreTodo_Handler,
+		},
+		{
+			MethodName: "ListTrash",
+			Handler:    _TodoService_ListTrash_Handler,
+		},
+		{
+			MethodName: "PurgeTodo",
+			Handler:    _TodoService_PurgeTodo_Handler,
+		},
+		{
+			MethodName: "AddTags",
+			Handler:    _TodoService_AddTags_Handler,
+		},
+		{
+			MethodName: "RemoveTags",
+			Handler:    _TodoService_RemoveTags_Handler,
+		},
+		{
+			MethodName: "ListTags",
+			Handler:    _TodoService_ListTags_Handler,
+		},
+		{
+			MethodName: "GetTodoTree",
+			Handler:    _TodoService_GetTodoTree_Handler,
+		},
+		{
+			MethodName: "AddDependency",
+			Handler:    _TodoService_AddDependency_Handler,
+		},
+		{
+			MethodName: "RemoveDependency",
+			Handler:    _TodoService_RemoveDependency_Handler,
+		},
+		{
+			MethodName: "ShareTodo",
+			Handler:    _TodoService_ShareTodo_Handler,
+		},
+		{
+			MethodName: "UnshareTodo",
+			Handler:    _TodoService_UnshareTodo_Handler,
+		},
+		{
+			MethodName: "ListCollaborators",
+			Handler:    _TodoService_ListCollaborators_Handler,
+		},
+		{
+			MethodName: "ListTodoHistory",
+			Handler:    _TodoService_ListTodoHistory_Handler,
+		},
+		{
+			MethodName: "BatchCreateTodos",
+			Handler:    _TodoService_BatchCreateTodos_Handler,
+		},
+		{
+			MethodName: "BatchUpdateTodos",
+			Handler:    _TodoService_BatchUpdateTodos_Handler,
+		},
+		{
+			MethodName: "BatchDeleteTodos",
+			Handler:    _TodoService_BatchDeleteTodos_Handler,
+		},
+		{
+			MethodName: "BulkUpdateByFilter",
+			Handler:    _TodoService_BulkUpdateByFilter_Handler,
+		},
+		{
+			MethodName: "BulkDeleteByFilter",
+			Handler:    _TodoService_BulkDeleteByFilter_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchTodos",
+			Handler:       _TodoService_WatchTodos_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportTodos",
+			Handler:       _TodoService_ExportTodos_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportTodos",
+			Handler:       _TodoService_ImportTodos_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportICalendar",
+			Handler:       _TodoService_ExportICalendar_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportICalendar",
+			Handler:       _TodoService_ImportICalendar_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "todo/v1/todo.proto",
 }
@@ -0,0 +1,99 @@
+// Package apikey содержит gRPC-обработчик для сервиса ключей API.
+package apikey
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apikeyrepo "todo/internal/apikey"
+	"todo/internal/auth"
+	gen "todo/internal/gen/todo/v1"
+	apikeysvc "todo/internal/service/apikey"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Handler реализует gRPC-методы сервиса ключей API.
+type Handler struct {
+	gen.UnimplementedApiKeyServiceServer
+	service *apikeysvc.Service
+}
+
+// NewHandler создаёт gRPC-обработчик ключей API.
+func NewHandler(service *apikeysvc.Service) *Handler {
+	return &Handler{service: service}
+}
+
+// CreateApiKey создаёт ключ и возвращает его секрет.
+func (h *Handler) CreateApiKey(ctx context.Context, req *gen.CreateApiKeyRequest) (*gen.CreateApiKeyResponse, error) {
+	var expiresAt *time.Time
+	if req.ExpiresAt != nil {
+		t := time.Unix(req.GetExpiresAt(), 0).UTC()
+		expiresAt = &t
+	}
+	rec, secret, err := h.service.Create(ctx, req.GetName(), apikeyrepo.Scope(req.GetScope()), expiresAt)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return &gen.CreateApiKeyResponse{ApiKey: recordToProto(rec), Secret: secret}, nil
+}
+
+// ListApiKeys возвращает ключи вызывающего.
+func (h *Handler) ListApiKeys(ctx context.Context, _ *gen.ListApiKeysRequest) (*gen.ListApiKeysResponse, error) {
+	items, err := h.service.List(ctx)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	out := make([]*gen.ApiKey, 0, len(items))
+	for _, rec := range items {
+		out = append(out, recordToProto(rec))
+	}
+	return &gen.ListApiKeysResponse{ApiKeys: out}, nil
+}
+
+// RevokeApiKey отзывает ключ.
+func (h *Handler) RevokeApiKey(ctx context.Context, req *gen.RevokeApiKeyRequest) (*gen.ApiKey, error) {
+	rec, err := h.service.Revoke(ctx, req.GetId())
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return recordToProto(rec), nil
+}
+
+func handleError(err error) error {
+	switch {
+	case errors.Is(err, apikeysvc.ErrValidation):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, apikeysvc.ErrPermissionDenied):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, apikeyrepo.ErrNotFound):
+		return status.Error(codes.NotFound, "api key not found")
+	case errors.Is(err, auth.ErrUnauthenticated):
+		return status.Error(codes.Unauthenticated, "authentication required")
+	default:
+		return status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+}
+
+func recordToProto(rec apikeyrepo.Record) *gen.ApiKey {
+	return &gen.ApiKey{
+		Id:         rec.ID,
+		Name:       rec.Name,
+		Prefix:     rec.Prefix,
+		Scope:      gen.ApiKeyScope(rec.Scope),
+		CreatedAt:  rec.CreatedAt.Unix(),
+		ExpiresAt:  optionalUnix(rec.ExpiresAt),
+		LastUsedAt: optionalUnix(rec.LastUsedAt),
+		RevokedAt:  optionalUnix(rec.RevokedAt),
+	}
+}
+
+func optionalUnix(t *time.Time) *int64 {
+	if t == nil {
+		return nil
+	}
+	v := t.Unix()
+	return &v
+}
@@ -0,0 +1,165 @@
+// Package attachment содержит gRPC-обработчик для сервиса вложений.
+package attachment
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"todo/internal/access"
+	attachmentrepo "todo/internal/attachment"
+	"todo/internal/auth"
+	"todo/internal/blob"
+	gen "todo/internal/gen/todo/v1"
+	attachmentsvc "todo/internal/service/attachment"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// chunkSize — размер части файла в потоке скачивания.
+const chunkSize = 64 << 10
+
+// Handler реализует gRPC-методы сервиса вложений.
+type Handler struct {
+	gen.UnimplementedAttachmentServiceServer
+	service *attachmentsvc.Service
+}
+
+// NewHandler создаёт gRPC-обработчик вложений.
+func NewHandler(service *attachmentsvc.Service) *Handler {
+	return &Handler{service: service}
+}
+
+// UploadAttachment принимает поток с метаданными и содержимым вложения.
+func (h *Handler) UploadAttachment(stream gen.AttachmentService_UploadAttachmentServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return status.Error(codes.InvalidArgument, "metadata is required")
+		}
+		return err
+	}
+	meta := first.GetMetadata()
+	if meta == nil {
+		return status.Error(codes.InvalidArgument, "first message must contain metadata")
+	}
+
+	rec, err := h.service.Upload(stream.Context(), meta.GetTodoId(), meta.GetFilename(), &chunkReader{stream: stream})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			return st.Err()
+		}
+		return handleError(err)
+	}
+	return stream.SendAndClose(recordToProto(rec))
+}
+
+// DownloadAttachment отдаёт метаданные вложения и затем его содержимое частями.
+func (h *Handler) DownloadAttachment(req *gen.DownloadAttachmentRequest, stream gen.AttachmentService_DownloadAttachmentServer) error {
+	rec, rc, err := h.service.Open(stream.Context(), req.GetId())
+	if err != nil {
+		return handleError(err)
+	}
+	defer func() {
+		_ = rc.Close()
+	}()
+
+	if err := stream.Send(&gen.DownloadAttachmentResponse{
+		Payload: &gen.DownloadAttachmentResponse_Attachment{Attachment: recordToProto(rec)},
+	}); err != nil {
+		return err
+	}
+
+	buf := make([]byte, chunkSize)
+	for {
+		n, err := rc.Read(buf)
+		if n > 0 {
+			if sendErr := stream.Send(&gen.DownloadAttachmentResponse{
+				Payload: &gen.DownloadAttachmentResponse_Chunk{Chunk: buf[:n]},
+			}); sendErr != nil {
+				return sendErr
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return handleError(err)
+		}
+	}
+}
+
+// ListAttachments возвращает вложения задачи.
+func (h *Handler) ListAttachments(ctx context.Context, req *gen.ListAttachmentsRequest) (*gen.ListAttachmentsResponse, error) {
+	items, err := h.service.List(ctx, req.GetTodoId())
+	if err != nil {
+		return nil, handleError(err)
+	}
+	out := make([]*gen.Attachment, 0, len(items))
+	for _, rec := range items {
+		out = append(out, recordToProto(rec))
+	}
+	return &gen.ListAttachmentsResponse{Attachments: out}, nil
+}
+
+// DeleteAttachment удаляет вложение.
+func (h *Handler) DeleteAttachment(ctx context.Context, req *gen.DeleteAttachmentRequest) (*gen.DeleteAttachmentResponse, error) {
+	if err := h.service.Delete(ctx, req.GetId()); err != nil {
+		return nil, handleError(err)
+	}
+	return &gen.DeleteAttachmentResponse{}, nil
+}
+
+// chunkReader представляет части из потока загрузки как io.Reader.
+type chunkReader struct {
+	stream gen.AttachmentService_UploadAttachmentServer
+	buf    []byte
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		msg, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		if msg.GetMetadata() != nil {
+			return 0, status.Error(codes.InvalidArgument, "metadata must be sent only once")
+		}
+		r.buf = msg.GetChunk()
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func handleError(err error) error {
+	switch {
+	case errors.Is(err, attachmentsvc.ErrValidation):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, attachmentrepo.ErrNotFound):
+		return status.Error(codes.NotFound, "attachment not found")
+	case errors.Is(err, access.ErrPermissionDenied):
+		return status.Error(codes.PermissionDenied, "permission denied")
+	case errors.Is(err, attachmentrepo.ErrTodoNotFound), errors.Is(err, access.ErrTodoNotFound):
+		return status.Error(codes.NotFound, "todo not found")
+	case errors.Is(err, blob.ErrNotFound):
+		return status.Errorf(codes.Internal, "attachment content is missing: %v", err)
+	case errors.Is(err, auth.ErrUnauthenticated):
+		return status.Error(codes.Unauthenticated, "authentication required")
+	default:
+		return status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+}
+
+func recordToProto(rec attachmentrepo.Record) *gen.Attachment {
+	return &gen.Attachment{
+		Id:          rec.ID,
+		TodoId:      rec.TodoID,
+		Filename:    rec.Filename,
+		ContentType: rec.ContentType,
+		Size:        rec.Size,
+		Sha256:      rec.SHA256,
+		CreatedAt:   rec.CreatedAt.Unix(),
+	}
+}
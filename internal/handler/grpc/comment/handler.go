@@ -0,0 +1,98 @@
+// Package comment содержит gRPC-обработчик для сервиса комментариев.
+package comment
+
+import (
+	"context"
+	"errors"
+
+	"todo/internal/access"
+	"todo/internal/auth"
+	commentrepo "todo/internal/comment"
+	gen "todo/internal/gen/todo/v1"
+	commentsvc "todo/internal/service/comment"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Handler реализует gRPC-методы сервиса комментариев.
+type Handler struct {
+	gen.UnimplementedCommentServiceServer
+	service *commentsvc.Service
+}
+
+// NewHandler создаёт gRPC-обработчик комментариев.
+func NewHandler(service *commentsvc.Service) *Handler {
+	return &Handler{service: service}
+}
+
+// AddComment добавляет комментарий к задаче.
+func (h *Handler) AddComment(ctx context.Context, req *gen.AddCommentRequest) (*gen.Comment, error) {
+	rec, err := h.service.Add(ctx, req.GetTodoId(), req.GetAuthor(), req.GetBody())
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return recordToProto(rec), nil
+}
+
+// ListComments возвращает страницу комментариев задачи.
+func (h *Handler) ListComments(ctx context.Context, req *gen.ListCommentsRequest) (*gen.ListCommentsResponse, error) {
+	res, err := h.service.List(ctx, req.GetTodoId(), int(req.GetPageSize()), req.GetPageToken())
+	if err != nil {
+		return nil, handleError(err)
+	}
+	out := make([]*gen.Comment, 0, len(res.Items))
+	for _, rec := range res.Items {
+		out = append(out, recordToProto(rec))
+	}
+	return &gen.ListCommentsResponse{Comments: out, NextPageToken: res.NextPageToken}, nil
+}
+
+// EditComment заменяет текст комментария.
+func (h *Handler) EditComment(ctx context.Context, req *gen.EditCommentRequest) (*gen.Comment, error) {
+	rec, err := h.service.Edit(ctx, req.GetId(), req.GetBody())
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return recordToProto(rec), nil
+}
+
+// DeleteComment удаляет комментарий.
+func (h *Handler) DeleteComment(ctx context.Context, req *gen.DeleteCommentRequest) (*gen.DeleteCommentResponse, error) {
+	if err := h.service.Delete(ctx, req.GetId()); err != nil {
+		return nil, handleError(err)
+	}
+	return &gen.DeleteCommentResponse{}, nil
+}
+
+func handleError(err error) error {
+	switch {
+	case errors.Is(err, commentsvc.ErrValidation):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, commentrepo.ErrNotFound):
+		return status.Error(codes.NotFound, "comment not found")
+	case errors.Is(err, access.ErrPermissionDenied):
+		return status.Error(codes.PermissionDenied, "permission denied")
+	case errors.Is(err, commentrepo.ErrTodoNotFound), errors.Is(err, access.ErrTodoNotFound):
+		return status.Error(codes.NotFound, "todo not found")
+	case errors.Is(err, auth.ErrUnauthenticated):
+		return status.Error(codes.Unauthenticated, "authentication required")
+	default:
+		return status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+}
+
+func recordToProto(rec commentrepo.Record) *gen.Comment {
+	out := &gen.Comment{
+		Id:        rec.ID,
+		TodoId:    rec.TodoID,
+		Author:    rec.Author,
+		Body:      rec.Body,
+		CreatedAt: rec.CreatedAt.Unix(),
+	}
+	if rec.EditedAt != nil {
+		edited := rec.EditedAt.Unix()
+		out.EditedAt = &edited
+	}
+	return out
+}
@@ -0,0 +1,154 @@
+// Package project содержит gRPC-обработчик для сервиса проектов.
+package project
+
+import (
+	"context"
+	"errors"
+
+	"todo/internal/access"
+	"todo/internal/auth"
+	gen "todo/internal/gen/todo/v1"
+	projectrepo "todo/internal/project"
+	projectsvc "todo/internal/service/project"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Handler реализует gRPC-методы сервиса проектов.
+type Handler struct {
+	gen.UnimplementedProjectServiceServer
+	service *projectsvc.Service
+}
+
+// NewHandler создаёт gRPC-обработчик проектов.
+func NewHandler(service *projectsvc.Service) *Handler {
+	return &Handler{service: service}
+}
+
+// CreateProject создаёт новый проект.
+func (h *Handler) CreateProject(ctx context.Context, req *gen.CreateProjectRequest) (*gen.Project, error) {
+	rec, err := h.service.Create(ctx, req.GetName(), req.GetDescription())
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return recordToProto(rec), nil
+}
+
+// GetProject возвращает проект по идентификатору.
+func (h *Handler) GetProject(ctx context.Context, req *gen.GetProjectRequest) (*gen.Project, error) {
+	rec, err := h.service.Get(ctx, req.GetId())
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return recordToProto(rec), nil
+}
+
+// ListProjects возвращает все проекты.
+func (h *Handler) ListProjects(ctx context.Context, _ *gen.ListProjectsRequest) (*gen.ListProjectsResponse, error) {
+	recs, err := h.service.List(ctx)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	out := make([]*gen.Project, 0, len(recs))
+	for _, rec := range recs {
+		out = append(out, recordToProto(rec))
+	}
+	return &gen.ListProjectsResponse{Projects: out}, nil
+}
+
+// UpdateProject изменяет поля проекта, перечисленные в update_mask.
+func (h *Handler) UpdateProject(ctx context.Context, req *gen.UpdateProjectRequest) (*gen.Project, error) {
+	rec, err := h.service.Update(ctx, req.GetId(), req.GetName(), req.GetDescription(), req.GetUpdateMask().GetPaths())
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return recordToProto(rec), nil
+}
+
+// DeleteProject удаляет проект.
+func (h *Handler) DeleteProject(ctx context.Context, req *gen.DeleteProjectRequest) (*gen.DeleteProjectResponse, error) {
+	if err := h.service.Delete(ctx, req.GetId(), deleteModeFromProto(req.GetMode())); err != nil {
+		return nil, handleError(err)
+	}
+	return &gen.DeleteProjectResponse{}, nil
+}
+
+// ShareProject выдаёт пользователю роль на проект.
+func (h *Handler) ShareProject(ctx context.Context, req *gen.ShareProjectRequest) (*gen.Collaborator, error) {
+	grant, err := h.service.Share(ctx, req.GetId(), req.GetUserId(), access.Role(req.GetRole()))
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return grantToProto(grant), nil
+}
+
+// UnshareProject отзывает роль пользователя на проект.
+func (h *Handler) UnshareProject(ctx context.Context, req *gen.UnshareProjectRequest) (*gen.UnshareProjectResponse, error) {
+	if err := h.service.Unshare(ctx, req.GetId(), req.GetUserId()); err != nil {
+		return nil, handleError(err)
+	}
+	return &gen.UnshareProjectResponse{}, nil
+}
+
+// ListProjectCollaborators возвращает участников проекта.
+func (h *Handler) ListProjectCollaborators(ctx context.Context, req *gen.ListProjectCollaboratorsRequest) (*gen.ListProjectCollaboratorsResponse, error) {
+	grants, err := h.service.Collaborators(ctx, req.GetId())
+	if err != nil {
+		return nil, handleError(err)
+	}
+	out := make([]*gen.Collaborator, 0, len(grants))
+	for _, grant := range grants {
+		out = append(out, grantToProto(grant))
+	}
+	return &gen.ListProjectCollaboratorsResponse{Collaborators: out}, nil
+}
+
+func handleError(err error) error {
+	switch {
+	case errors.Is(err, projectsvc.ErrValidation):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, access.ErrPermissionDenied):
+		return status.Error(codes.PermissionDenied, "permission denied")
+	case errors.Is(err, projectrepo.ErrNotFound), errors.Is(err, access.ErrProjectNotFound):
+		return status.Error(codes.NotFound, "project not found")
+	case errors.Is(err, access.ErrGrantNotFound):
+		return status.Error(codes.NotFound, "collaborator not found")
+	case errors.Is(err, auth.ErrUnauthenticated):
+		return status.Error(codes.Unauthenticated, "authentication required")
+	default:
+		return status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+}
+
+func grantToProto(grant access.Grant) *gen.Collaborator {
+	return &gen.Collaborator{
+		UserId:    grant.UserID,
+		Role:      gen.Role(grant.Role),
+		CreatedAt: grant.CreatedAt.Unix(),
+	}
+}
+
+func deleteModeFromProto(mode gen.DeleteProjectMode) projectrepo.DeleteMode {
+	switch mode {
+	case gen.DeleteProjectMode_DELETE_PROJECT_MODE_MOVE_TO_INBOX:
+		return projectrepo.DeleteMoveToInbox
+	case gen.DeleteProjectMode_DELETE_PROJECT_MODE_CASCADE:
+		return projectrepo.DeleteCascade
+	default:
+		// Неизвестный режим отклонит сервис.
+		return projectrepo.DeleteMode(mode)
+	}
+}
+
+func recordToProto(rec projectrepo.Record) *gen.Project {
+	return &gen.Project{
+		Id:          rec.ID,
+		Name:        rec.Name,
+		Description: rec.Description,
+		CreatedAt:   rec.CreatedAt.Unix(),
+		UpdatedAt:   rec.UpdatedAt.Unix(),
+		TodoCount:   rec.TodoCount,
+		OwnerId:     rec.OwnerID,
+	}
+}
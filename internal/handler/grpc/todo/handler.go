@@ -4,13 +4,19 @@ package todo
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sort"
+	"time"
 
+	"todo/internal/access"
+	"todo/internal/auth"
 	gen "todo/internal/gen/todo/v1"
 	todosvc "todo/internal/service/todo"
 	todorepo "todo/internal/todo"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 // Handler реализует gRPC-методы сервиса задач.
@@ -26,7 +32,7 @@ func NewHandler(service *todosvc.Service) *Handler {
 
 // CreateTodo создаёт новую задачу.
 func (h *Handler) CreateTodo(ctx context.Context, req *gen.CreateTodoRequest) (*gen.CreateTodoResponse, error) {
-	rec, err := h.service.Create(ctx, req.GetTitle(), req.GetDescription())
+	rec, err := h.service.Create(ctx, createParamsFromProto(req))
 	if err != nil {
 		return nil, handleError(err)
 	}
@@ -42,55 +48,576 @@ func (h *Handler) GetTodo(ctx context.Context, req *gen.GetTodoRequest) (*gen.To
 	return recordToProto(rec), nil
 }
 
-// ListTodos возвращает список задач.
-func (h *Handler) ListTodos(ctx context.Context, _ *gen.ListTodosRequest) (*gen.ListTodosResponse, error) {
-	recs, err := h.service.List(ctx)
+// ListTodos возвращает страницу задач.
+func (h *Handler) ListTodos(ctx context.Context, req *gen.ListTodosRequest) (*gen.ListTodosResponse, error) {
+	order, err := orderFromProto(req.GetOrderBy())
 	if err != nil {
 		return nil, handleError(err)
 	}
-	out := make([]*gen.Todo, 0, len(recs))
-	for _, rec := range recs {
-		rec := rec
+	res, err := h.service.List(ctx, todosvc.ListParams{
+		PageSize:  int(req.GetPageSize()),
+		PageToken: req.GetPageToken(),
+		Filter:    filterFromProto(req.GetFilter()),
+		Due:       dueFromProto(req.GetFilter()),
+		Order:     order,
+	})
+	if err != nil {
+		return nil, handleError(err)
+	}
+	out := make([]*gen.Todo, 0, len(res.Items))
+	for _, rec := range res.Items {
 		out = append(out, recordToProto(rec))
 	}
-	return &gen.ListTodosResponse{Todos: out}, nil
+	return &gen.ListTodosResponse{Todos: out, NextPageToken: res.NextPageToken}, nil
 }
 
-// UpdateTodo изменяет существующую задачу.
+// UpdateTodo изменяет поля задачи, перечисленные в update_mask.
 func (h *Handler) UpdateTodo(ctx context.Context, req *gen.UpdateTodoRequest) (*gen.Todo, error) {
-	rec, err := h.service.Update(ctx, req.GetId(), req.GetTitle(), req.GetDescription(), req.GetCompleted())
+	rec, err := h.service.Update(ctx, updateParamsFromProto(req))
 	if err != nil {
 		return nil, handleError(err)
 	}
 	return recordToProto(rec), nil
 }
 
-// DeleteTodo удаляет задачу.
+// DeleteTodo перемещает задачу в корзину.
 func (h *Handler) DeleteTodo(ctx context.Context, req *gen.DeleteTodoRequest) (*gen.DeleteTodoResponse, error) {
-	if err := h.service.Delete(ctx, req.GetId()); err != nil {
+	if err := h.service.Delete(ctx, req.GetId(), req.GetExpectedVersion()); err != nil {
 		return nil, handleError(err)
 	}
 	return &gen.DeleteTodoResponse{}, nil
 }
 
+// RestoreTodo восстанавливает задачу из корзины.
+func (h *Handler) RestoreTodo(ctx context.Context, req *gen.RestoreTodoRequest) (*gen.Todo, error) {
+	rec, err := h.service.Restore(ctx, req.GetId())
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return recordToProto(rec), nil
+}
+
+// ListTrash возвращает страницу задач из корзины.
+func (h *Handler) ListTrash(ctx context.Context, req *gen.ListTrashRequest) (*gen.ListTrashResponse, error) {
+	res, err := h.service.ListTrash(ctx, int(req.GetPageSize()), req.GetPageToken())
+	if err != nil {
+		return nil, handleError(err)
+	}
+	out := make([]*gen.Todo, 0, len(res.Items))
+	for _, rec := range res.Items {
+		out = append(out, recordToProto(rec))
+	}
+	return &gen.ListTrashResponse{Todos: out, NextPageToken: res.NextPageToken}, nil
+}
+
+// PurgeTodo окончательно удаляет задачу из корзины.
+func (h *Handler) PurgeTodo(ctx context.Context, req *gen.PurgeTodoRequest) (*gen.PurgeTodoResponse, error) {
+	if err := h.service.Purge(ctx, req.GetId()); err != nil {
+		return nil, handleError(err)
+	}
+	return &gen.PurgeTodoResponse{}, nil
+}
+
+// AddTags добавляет задаче метки.
+func (h *Handler) AddTags(ctx context.Context, req *gen.AddTagsRequest) (*gen.Todo, error) {
+	rec, err := h.service.AddTags(ctx, req.GetId(), req.GetTags())
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return recordToProto(rec), nil
+}
+
+// RemoveTags снимает с задачи метки.
+func (h *Handler) RemoveTags(ctx context.Context, req *gen.RemoveTagsRequest) (*gen.Todo, error) {
+	rec, err := h.service.RemoveTags(ctx, req.GetId(), req.GetTags())
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return recordToProto(rec), nil
+}
+
+// ListTags возвращает используемые метки с числом задач.
+func (h *Handler) ListTags(ctx context.Context, _ *gen.ListTagsRequest) (*gen.ListTagsResponse, error) {
+	tags, err := h.service.ListTags(ctx)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	out := make([]*gen.Tag, 0, len(tags))
+	for _, tag := range tags {
+		out = append(out, &gen.Tag{Name: tag.Name, TodoCount: tag.Count})
+	}
+	return &gen.ListTagsResponse{Tags: out}, nil
+}
+
+// GetTodoTree возвращает задачу со всеми подзадачами.
+func (h *Handler) GetTodoTree(ctx context.Context, req *gen.GetTodoTreeRequest) (*gen.TodoNode, error) {
+	root, err := h.service.Tree(ctx, req.GetId())
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return nodeToProto(root), nil
+}
+
+// AddDependency отмечает, что задача заблокирована другой задачей.
+func (h *Handler) AddDependency(ctx context.Context, req *gen.AddDependencyRequest) (*gen.Todo, error) {
+	rec, err := h.service.AddDependency(ctx, req.GetId(), req.GetBlockerId())
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return recordToProto(rec), nil
+}
+
+// RemoveDependency снимает блокировку задачи другой задачей.
+func (h *Handler) RemoveDependency(ctx context.Context, req *gen.RemoveDependencyRequest) (*gen.Todo, error) {
+	rec, err := h.service.RemoveDependency(ctx, req.GetId(), req.GetBlockerId())
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return recordToProto(rec), nil
+}
+
+// ShareTodo выдаёт пользователю роль на задачу.
+func (h *Handler) ShareTodo(ctx context.Context, req *gen.ShareTodoRequest) (*gen.Collaborator, error) {
+	grant, err := h.service.Share(ctx, req.GetId(), req.GetUserId(), access.Role(req.GetRole()))
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return grantToProto(grant), nil
+}
+
+// UnshareTodo отзывает роль пользователя на задачу.
+func (h *Handler) UnshareTodo(ctx context.Context, req *gen.UnshareTodoRequest) (*gen.UnshareTodoResponse, error) {
+	if err := h.service.Unshare(ctx, req.GetId(), req.GetUserId()); err != nil {
+		return nil, handleError(err)
+	}
+	return &gen.UnshareTodoResponse{}, nil
+}
+
+// ListCollaborators возвращает участников задачи.
+func (h *Handler) ListCollaborators(ctx context.Context, req *gen.ListCollaboratorsRequest) (*gen.ListCollaboratorsResponse, error) {
+	grants, err := h.service.Collaborators(ctx, req.GetId())
+	if err != nil {
+		return nil, handleError(err)
+	}
+	out := make([]*gen.Collaborator, 0, len(grants))
+	for _, grant := range grants {
+		out = append(out, grantToProto(grant))
+	}
+	return &gen.ListCollaboratorsResponse{Collaborators: out}, nil
+}
+
+// ListTodoHistory возвращает страницу журнала изменений задачи.
+func (h *Handler) ListTodoHistory(ctx context.Context, req *gen.ListTodoHistoryRequest) (*gen.ListTodoHistoryResponse, error) {
+	res, err := h.service.History(ctx, req.GetId(), int(req.GetPageSize()), req.GetPageToken())
+	if err != nil {
+		return nil, handleError(err)
+	}
+	out := &gen.ListTodoHistoryResponse{
+		Events:        make([]*gen.TodoEvent, 0, len(res.Items)),
+		NextPageToken: res.NextPageToken,
+	}
+	for _, ev := range res.Items {
+		pb, err := eventToProto(ev)
+		if err != nil {
+			return nil, handleError(err)
+		}
+		out.Events = append(out.Events, pb)
+	}
+	return out, nil
+}
+
+// WatchTodos передаёт клиенту изменения задач, пока он не отключится.
+func (h *Handler) WatchTodos(req *gen.WatchTodosRequest, stream gen.TodoService_WatchTodosServer) error {
+	err := h.service.Watch(stream.Context(), todosvc.WatchParams{
+		Filter:   filterFromProto(req.GetFilter()),
+		Due:      dueFromProto(req.GetFilter()),
+		AfterSeq: req.GetAfterSeq(),
+	}, func(ev todosvc.WatchEvent) error {
+		if ev.Left {
+			return stream.Send(&gen.WatchTodosResponse{
+				Seq:        ev.Seq,
+				Event:      &gen.TodoEvent{Seq: ev.Seq, TodoId: ev.TodoID},
+				LeftFilter: true,
+			})
+		}
+		event, err := eventToProto(ev.Event)
+		if err != nil {
+			return err
+		}
+		resp := &gen.WatchTodosResponse{
+			Seq:       ev.Seq,
+			Operation: operationToProto(ev.Operation),
+			Event:     event,
+		}
+		if ev.Record != nil {
+			resp.Todo = recordToProto(*ev.Record)
+		}
+		return stream.Send(resp)
+	})
+	if err != nil {
+		if stream.Context().Err() != nil {
+			return status.FromContextError(stream.Context().Err()).Err()
+		}
+		return handleError(err)
+	}
+	return nil
+}
+
+// BatchCreateTodos создаёт пакет задач.
+func (h *Handler) BatchCreateTodos(ctx context.Context, req *gen.BatchCreateTodosRequest) (*gen.BatchTodosResponse, error) {
+	items := make([]todosvc.CreateParams, 0, len(req.GetRequests()))
+	for _, item := range req.GetRequests() {
+		items = append(items, createParamsFromProto(item))
+	}
+	results, err := h.service.BatchCreate(ctx, items, req.GetAllOrNothing())
+	if err != nil {
+		return nil, handleBatchError(err)
+	}
+	return batchResultsToProto(results, true), nil
+}
+
+// BatchUpdateTodos изменяет пакет задач.
+func (h *Handler) BatchUpdateTodos(ctx context.Context, req *gen.BatchUpdateTodosRequest) (*gen.BatchTodosResponse, error) {
+	items := make([]todosvc.UpdateParams, 0, len(req.GetRequests()))
+	for _, item := range req.GetRequests() {
+		items = append(items, updateParamsFromProto(item))
+	}
+	results, err := h.service.BatchUpdate(ctx, items, req.GetAllOrNothing())
+	if err != nil {
+		return nil, handleBatchError(err)
+	}
+	return batchResultsToProto(results, true), nil
+}
+
+// BatchDeleteTodos перемещает пакет задач в корзину.
+func (h *Handler) BatchDeleteTodos(ctx context.Context, req *gen.BatchDeleteTodosRequest) (*gen.BatchTodosResponse, error) {
+	items := make([]todosvc.DeleteParams, 0, len(req.GetRequests()))
+	for _, item := range req.GetRequests() {
+		items = append(items, todosvc.DeleteParams{ID: item.GetId(), ExpectedVersion: item.GetExpectedVersion()})
+	}
+	results, err := h.service.BatchDelete(ctx, items, req.GetAllOrNothing())
+	if err != nil {
+		return nil, handleBatchError(err)
+	}
+	return batchResultsToProto(results, false), nil
+}
+
+// BulkUpdateByFilter изменяет задачи по фильтру.
+func (h *Handler) BulkUpdateByFilter(ctx context.Context, req *gen.BulkUpdateByFilterRequest) (*gen.BulkResponse, error) {
+	params := todosvc.BulkUpdateParams{
+		BulkParams: bulkParamsFromProto(req.GetFilter(), req.GetDryRun(), req.GetSampleSize()),
+		Completed:  req.Completed,
+		ProjectID:  req.ProjectId,
+	}
+	if req.Priority != nil {
+		priority := todorepo.Priority(req.GetPriority())
+		params.Priority = &priority
+	}
+	res, err := h.service.BulkUpdate(ctx, params)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return bulkResultToProto(res), nil
+}
+
+// BulkDeleteByFilter перемещает задачи по фильтру в корзину.
+func (h *Handler) BulkDeleteByFilter(ctx context.Context, req *gen.BulkDeleteByFilterRequest) (*gen.BulkResponse, error) {
+	res, err := h.service.BulkDelete(ctx, bulkParamsFromProto(req.GetFilter(), req.GetDryRun(), req.GetSampleSize()))
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return bulkResultToProto(res), nil
+}
+
+func bulkParamsFromProto(f *gen.TodoFilter, dryRun bool, sampleSize int32) todosvc.BulkParams {
+	return todosvc.BulkParams{
+		Filter:     filterFromProto(f),
+		Due:        dueFromProto(f),
+		DryRun:     dryRun,
+		SampleSize: int(sampleSize),
+	}
+}
+
+func bulkResultToProto(res todosvc.BulkResult) *gen.BulkResponse {
+	out := &gen.BulkResponse{AffectedCount: res.Count}
+	for _, rec := range res.Sample {
+		out.Sample = append(out.Sample, recordToProto(rec))
+	}
+	return out
+}
+
+// handleBatchError сохраняет в ответе номер элемента, на котором прервался
+// атомарный пакет.
+func handleBatchError(err error) error {
+	var itemErr *todorepo.ItemError
+	if !errors.As(err, &itemErr) {
+		return handleError(err)
+	}
+	st := status.Convert(handleError(itemErr.Err))
+	return status.Errorf(st.Code(), "item %d: %s", itemErr.Index, st.Message())
+}
+
+func batchResultsToProto(results []todosvc.BatchResult, withTodo bool) *gen.BatchTodosResponse {
+	out := make([]*gen.BatchTodoResult, 0, len(results))
+	for _, res := range results {
+		if res.Err != nil {
+			st := status.Convert(handleError(res.Err))
+			out = append(out, &gen.BatchTodoResult{Code: int32(st.Code()), Message: st.Message()})
+			continue
+		}
+		item := &gen.BatchTodoResult{}
+		if withTodo {
+			item.Todo = recordToProto(res.Record)
+		}
+		out = append(out, item)
+	}
+	return &gen.BatchTodosResponse{Results: out}
+}
+
 func handleError(err error) error {
 	switch {
-	case errors.Is(err, todosvc.ErrValidation):
+	case errors.Is(err, todosvc.ErrValidation), errors.Is(err, todorepo.ErrParentCycle):
 		return status.Error(codes.InvalidArgument, err.Error())
-	case errors.Is(err, todorepo.ErrNotFound):
+	case errors.Is(err, todosvc.ErrFailedPrecondition), errors.Is(err, todorepo.ErrOpenSubtasks),
+		errors.Is(err, todorepo.ErrBlocked):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, access.ErrPermissionDenied):
+		return status.Error(codes.PermissionDenied, "permission denied")
+	case errors.Is(err, todorepo.ErrNotFound), errors.Is(err, access.ErrTodoNotFound):
 		return status.Error(codes.NotFound, "todo not found")
+	case errors.Is(err, access.ErrGrantNotFound):
+		return status.Error(codes.NotFound, "collaborator not found")
+	case errors.Is(err, todorepo.ErrProjectNotFound), errors.Is(err, access.ErrProjectNotFound):
+		return status.Error(codes.NotFound, "project not found")
+	case errors.Is(err, todorepo.ErrVersionConflict):
+		return status.Error(codes.Aborted, "todo was modified concurrently")
+	case errors.Is(err, todorepo.ErrAlreadyExists):
+		return status.Error(codes.AlreadyExists, "todo already exists")
+	case errors.Is(err, auth.ErrUnauthenticated):
+		return status.Error(codes.Unauthenticated, "authentication required")
 	default:
 		return status.Errorf(codes.Internal, "internal error: %v", err)
 	}
 }
 
 func recordToProto(rec todorepo.Record) *gen.Todo {
-	return &gen.Todo{
-		Id:          rec.ID,
-		Title:       rec.Title,
-		Description: rec.Description,
-		Completed:   rec.Completed,
-		CreatedAt:   rec.CreatedAt.Unix(),
-		UpdatedAt:   rec.UpdatedAt.Unix(),
+	out := &gen.Todo{
+		Id:             rec.ID,
+		Title:          rec.Title,
+		Description:    rec.Description,
+		Completed:      rec.Completed,
+		CreatedAt:      rec.CreatedAt.Unix(),
+		UpdatedAt:      rec.UpdatedAt.Unix(),
+		Version:        rec.Version,
+		DueAt:          optionalTimeToUnix(rec.DueAt),
+		StartAt:        optionalTimeToUnix(rec.StartAt),
+		AllDay:         rec.AllDay,
+		TimeZone:       rec.TimeZone,
+		Priority:       gen.Priority(rec.Priority),
+		Tags:           rec.Tags,
+		Blocked:        rec.Blocked,
+		Recurrence:     rec.Recurrence,
+		RecurrenceMode: gen.RecurrenceMode(rec.RecurrenceMode),
+		CommentCount:   rec.CommentCount,
+		OwnerId:        rec.OwnerID,
+		IcalUid:        rec.ICalUID,
+	}
+	if rec.ProjectID != nil {
+		out.ProjectId = *rec.ProjectID
+	}
+	if rec.ParentID != nil {
+		out.ParentId = *rec.ParentID
+	}
+	if rec.SeriesID != nil {
+		out.SeriesId = *rec.SeriesID
+	}
+	if rec.DeletedAt != nil {
+		out.DeletedAt = rec.DeletedAt.Unix()
+	}
+	return out
+}
+
+func nodeToProto(node *todosvc.TreeNode) *gen.TodoNode {
+	out := &gen.TodoNode{
+		Todo:           recordToProto(node.Record),
+		Children:       make([]*gen.TodoNode, 0, len(node.Children)),
+		CompletedCount: int32(node.Completed),
+		TotalCount:     int32(node.Total),
+	}
+	for _, child := range node.Children {
+		out.Children = append(out.Children, nodeToProto(child))
+	}
+	return out
+}
+
+func eventToProto(ev todorepo.Event) (*gen.TodoEvent, error) {
+	out := &gen.TodoEvent{
+		Seq:       ev.Seq,
+		TodoId:    ev.TodoID,
+		Actor:     ev.Actor,
+		Operation: operationToProto(ev.Operation),
+		CreatedAt: ev.CreatedAt.Unix(),
+	}
+	fields := make([]string, 0, len(ev.Changes))
+	for field := range ev.Changes {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		change := ev.Changes[field]
+		before, err := structpb.NewValue(change.Before)
+		if err != nil {
+			return nil, fmt.Errorf("convert %s: %w", field, err)
+		}
+		after, err := structpb.NewValue(change.After)
+		if err != nil {
+			return nil, fmt.Errorf("convert %s: %w", field, err)
+		}
+		out.Changes = append(out.Changes, &gen.FieldChange{Field: field, Before: before, After: after})
+	}
+	return out, nil
+}
+
+func operationToProto(op todorepo.Operation) gen.TodoEventOperation {
+	switch op {
+	case todorepo.OpCreate:
+		return gen.TodoEventOperation_TODO_EVENT_OPERATION_CREATE
+	case todorepo.OpUpdate:
+		return gen.TodoEventOperation_TODO_EVENT_OPERATION_UPDATE
+	case todorepo.OpDelete:
+		return gen.TodoEventOperation_TODO_EVENT_OPERATION_DELETE
+	case todorepo.OpRestore:
+		return gen.TodoEventOperation_TODO_EVENT_OPERATION_RESTORE
+	case todorepo.OpPurge:
+		return gen.TodoEventOperation_TODO_EVENT_OPERATION_PURGE
+	default:
+		return gen.TodoEventOperation_TODO_EVENT_OPERATION_UNSPECIFIED
+	}
+}
+
+func grantToProto(grant access.Grant) *gen.Collaborator {
+	return &gen.Collaborator{
+		UserId:    grant.UserID,
+		Role:      gen.Role(grant.Role),
+		CreatedAt: grant.CreatedAt.Unix(),
+	}
+}
+
+func filterFromProto(f *gen.TodoFilter) todorepo.ListFilter {
+	if f == nil {
+		return todorepo.ListFilter{}
+	}
+	return todorepo.ListFilter{
+		Completed:     f.Completed,
+		CreatedAfter:  unixToTime(f.GetCreatedAfter()),
+		CreatedBefore: unixToTime(f.GetCreatedBefore()),
+		UpdatedAfter:  unixToTime(f.GetUpdatedAfter()),
+		UpdatedBefore: unixToTime(f.GetUpdatedBefore()),
+		TitlePrefix:   f.GetTitlePrefix(),
+		Tags:          f.GetTags(),
+		TagsMatchAll:  f.GetTagMatch() == gen.TagMatch_TAG_MATCH_ALL,
+		ProjectID:     f.ProjectId,
+		ParentID:      f.ParentId,
+		SeriesID:      f.GetSeriesId(),
+		Actionable:    f.GetActionable(),
+		Ownership:     todorepo.Ownership(f.GetOwnership()),
+	}
+}
+
+func createParamsFromProto(req *gen.CreateTodoRequest) todosvc.CreateParams {
+	return todosvc.CreateParams{
+		Title:       req.GetTitle(),
+		Description: req.GetDescription(),
+		Schedule: todosvc.Schedule{
+			DueAt:    optionalUnixToTime(req.DueAt),
+			StartAt:  optionalUnixToTime(req.StartAt),
+			AllDay:   req.GetAllDay(),
+			TimeZone: req.GetTimeZone(),
+		},
+		Priority:       todorepo.Priority(req.GetPriority()),
+		Tags:           req.GetTags(),
+		ProjectID:      req.GetProjectId(),
+		ParentID:       req.GetParentId(),
+		Recurrence:     req.GetRecurrence(),
+		RecurrenceMode: todorepo.RecurrenceMode(req.GetRecurrenceMode()),
+	}
+}
+
+func updateParamsFromProto(req *gen.UpdateTodoRequest) todosvc.UpdateParams {
+	return todosvc.UpdateParams{
+		ID:          req.GetId(),
+		Title:       req.GetTitle(),
+		Description: req.GetDescription(),
+		Completed:   req.GetCompleted(),
+		Schedule: todosvc.Schedule{
+			DueAt:    optionalUnixToTime(req.DueAt),
+			StartAt:  optionalUnixToTime(req.StartAt),
+			AllDay:   req.GetAllDay(),
+			TimeZone: req.GetTimeZone(),
+		},
+		Priority:        todorepo.Priority(req.GetPriority()),
+		ProjectID:       req.GetProjectId(),
+		ParentID:        req.GetParentId(),
+		Recurrence:      req.GetRecurrence(),
+		RecurrenceMode:  todorepo.RecurrenceMode(req.GetRecurrenceMode()),
+		Tags:            req.GetTags(),
+		Paths:           req.GetUpdateMask().GetPaths(),
+		ExpectedVersion: req.GetExpectedVersion(),
+	}
+}
+
+func dueFromProto(f *gen.TodoFilter) todosvc.DueFilter {
+	return todosvc.DueFilter{
+		Overdue:    f.GetOverdue(),
+		Today:      f.GetDueToday(),
+		WithinDays: int(f.GetDueWithinDays()),
+		TimeZone:   f.GetTimeZone(),
+	}
+}
+
+func orderFromProto(o gen.TodoOrder) (todorepo.Order, error) {
+	switch o {
+	case gen.TodoOrder_TODO_ORDER_UNSPECIFIED, gen.TodoOrder_TODO_ORDER_PRIORITY:
+		return todorepo.OrderPriority, nil
+	case gen.TodoOrder_TODO_ORDER_CREATED_AT_DESC:
+		return todorepo.OrderCreatedDesc, nil
+	case gen.TodoOrder_TODO_ORDER_CREATED_AT_ASC:
+		return todorepo.OrderCreatedAsc, nil
+	case gen.TodoOrder_TODO_ORDER_UPDATED_AT_DESC:
+		return todorepo.OrderUpdatedDesc, nil
+	case gen.TodoOrder_TODO_ORDER_UPDATED_AT_ASC:
+		return todorepo.OrderUpdatedAsc, nil
+	case gen.TodoOrder_TODO_ORDER_TITLE_ASC:
+		return todorepo.OrderTitleAsc, nil
+	case gen.TodoOrder_TODO_ORDER_TITLE_DESC:
+		return todorepo.OrderTitleDesc, nil
+	default:
+		return 0, fmt.Errorf("%w: unknown order_by %d", todosvc.ErrValidation, o)
+	}
+}
+
+// unixToTime переводит unix timestamp в time.Time; 0 означает «не задано».
+func unixToTime(v int64) time.Time {
+	if v == 0 {
+		return time.Time{}
+	}
+	return time.Unix(v, 0).UTC()
+}
+
+// optionalUnixToTime переводит необязательный unix timestamp в *time.Time.
+func optionalUnixToTime(v *int64) *time.Time {
+	if v == nil {
+		return nil
+	}
+	t := time.Unix(*v, 0).UTC()
+	return &t
+}
+
+// optionalTimeToUnix переводит *time.Time в необязательный unix timestamp.
+func optionalTimeToUnix(t *time.Time) *int64 {
+	if t == nil {
+		return nil
 	}
+	v := t.Unix()
+	return &v
 }
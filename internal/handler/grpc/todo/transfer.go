@@ -0,0 +1,151 @@
+package todo
+
+import (
+	"bufio"
+	"errors"
+	"io"
+
+	gen "todo/internal/gen/todo/v1"
+	todosvc "todo/internal/service/todo"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// chunkSize — размер части файла в потоках выгрузки.
+const chunkSize = 64 << 10
+
+// ExportTodos выгружает задачи по фильтру частями файла.
+func (h *Handler) ExportTodos(req *gen.ExportTodosRequest, stream gen.TodoService_ExportTodosServer) error {
+	return exportChunks(stream, func(w io.Writer) error {
+		return h.service.Export(stream.Context(), todosvc.ExportParams{
+			Filter: filterFromProto(req.GetFilter()),
+			Due:    dueFromProto(req.GetFilter()),
+			Format: todosvc.Format(req.GetFormat()),
+		}, w)
+	})
+}
+
+// ExportICalendar выгружает задачи по фильтру календарём iCalendar частями файла.
+func (h *Handler) ExportICalendar(req *gen.ExportICalendarRequest, stream gen.TodoService_ExportICalendarServer) error {
+	return exportChunks(stream, func(w io.Writer) error {
+		return h.service.ExportICalendar(stream.Context(), filterFromProto(req.GetFilter()), dueFromProto(req.GetFilter()), w)
+	})
+}
+
+// ImportTodos принимает поток с параметрами и содержимым файла и загружает задачи.
+func (h *Handler) ImportTodos(stream gen.TodoService_ImportTodosServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return status.Error(codes.InvalidArgument, "options are required")
+		}
+		return err
+	}
+	opts := first.GetOptions()
+	if opts == nil {
+		return status.Error(codes.InvalidArgument, "first message must contain options")
+	}
+
+	res, err := h.service.Import(stream.Context(), todosvc.ImportParams{
+		Format: todosvc.Format(opts.GetFormat()),
+		Mode:   todosvc.ImportMode(opts.GetMode()),
+	}, &chunkReader{next: func() ([]byte, error) {
+		msg, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		if msg.GetOptions() != nil {
+			return nil, status.Error(codes.InvalidArgument, "options must be sent only once")
+		}
+		return msg.GetChunk(), nil
+	}})
+	if err != nil {
+		return streamError(err)
+	}
+	return stream.SendAndClose(importResultToProto(res))
+}
+
+// ImportICalendar принимает поток с содержимым календаря и загружает из него задачи.
+func (h *Handler) ImportICalendar(stream gen.TodoService_ImportICalendarServer) error {
+	res, err := h.service.ImportICalendar(stream.Context(), &chunkReader{next: func() ([]byte, error) {
+		msg, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		return msg.GetChunk(), nil
+	}})
+	if err != nil {
+		return streamError(err)
+	}
+	return stream.SendAndClose(importResultToProto(res))
+}
+
+// exportChunks вызывает export с буферизованным писателем, отправляющим
+// данные частями по chunkSize.
+func exportChunks(stream chunkSender, export func(io.Writer) error) error {
+	w := bufio.NewWriterSize(&chunkWriter{stream: stream}, chunkSize)
+	err := export(w)
+	if err == nil {
+		err = w.Flush()
+	}
+	if err != nil {
+		return streamError(err)
+	}
+	return nil
+}
+
+// streamError возвращает статус ошибки потока как есть, а ошибки сервиса
+// переводит в статусы.
+func streamError(err error) error {
+	if st, ok := status.FromError(err); ok {
+		return st.Err()
+	}
+	return handleError(err)
+}
+
+func importResultToProto(res todosvc.ImportResult) *gen.ImportTodosResponse {
+	out := &gen.ImportTodosResponse{Created: res.Created, Updated: res.Updated, Failed: res.Failed}
+	for _, rowErr := range res.Errors {
+		st := status.Convert(handleError(rowErr.Err))
+		out.Errors = append(out.Errors, &gen.ImportRowError{Row: rowErr.Row, Code: int32(st.Code()), Message: st.Message()})
+	}
+	return out
+}
+
+// chunkSender — поток выгрузки, принимающий части файла.
+type chunkSender interface {
+	Send(*gen.ExportTodosResponse) error
+}
+
+// chunkWriter отправляет каждую запись отдельной частью потока.
+type chunkWriter struct {
+	stream chunkSender
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	if err := w.stream.Send(&gen.ExportTodosResponse{Chunk: p}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// chunkReader читает содержимое файла из сообщений потока загрузки;
+// next возвращает содержимое очередного сообщения.
+type chunkReader struct {
+	next func() ([]byte, error)
+	buf  []byte
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		chunk, err := r.next()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = chunk
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
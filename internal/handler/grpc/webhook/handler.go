@@ -0,0 +1,131 @@
+// Package webhook содержит gRPC-обработчик для сервиса вебхуков.
+package webhook
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"todo/internal/auth"
+	gen "todo/internal/gen/todo/v1"
+	webhooksvc "todo/internal/service/webhook"
+	webhookrepo "todo/internal/webhook"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Handler реализует gRPC-методы сервиса вебхуков.
+type Handler struct {
+	gen.UnimplementedWebhookServiceServer
+	service *webhooksvc.Service
+}
+
+// NewHandler создаёт gRPC-обработчик вебхуков.
+func NewHandler(service *webhooksvc.Service) *Handler {
+	return &Handler{service: service}
+}
+
+// CreateWebhook регистрирует вебхук и возвращает его секрет.
+func (h *Handler) CreateWebhook(ctx context.Context, req *gen.CreateWebhookRequest) (*gen.CreateWebhookResponse, error) {
+	rec, secret, err := h.service.Create(ctx, req.GetUrl())
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return &gen.CreateWebhookResponse{Webhook: recordToProto(rec), Secret: secret}, nil
+}
+
+// ListWebhooks возвращает вебхуки вызывающего.
+func (h *Handler) ListWebhooks(ctx context.Context, _ *gen.ListWebhooksRequest) (*gen.ListWebhooksResponse, error) {
+	items, err := h.service.List(ctx)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	out := make([]*gen.Webhook, 0, len(items))
+	for _, rec := range items {
+		out = append(out, recordToProto(rec))
+	}
+	return &gen.ListWebhooksResponse{Webhooks: out}, nil
+}
+
+// DeleteWebhook удаляет вебхук.
+func (h *Handler) DeleteWebhook(ctx context.Context, req *gen.DeleteWebhookRequest) (*gen.DeleteWebhookResponse, error) {
+	if err := h.service.Delete(ctx, req.GetId()); err != nil {
+		return nil, handleError(err)
+	}
+	return &gen.DeleteWebhookResponse{}, nil
+}
+
+// ListDeliveries возвращает журнал доставок на вебхук.
+func (h *Handler) ListDeliveries(ctx context.Context, req *gen.ListDeliveriesRequest) (*gen.ListDeliveriesResponse, error) {
+	res, err := h.service.Deliveries(ctx, req.GetWebhookId(), int(req.GetPageSize()), req.GetPageToken())
+	if err != nil {
+		return nil, handleError(err)
+	}
+	out := make([]*gen.Delivery, 0, len(res.Items))
+	for _, d := range res.Items {
+		out = append(out, deliveryToProto(d))
+	}
+	return &gen.ListDeliveriesResponse{Deliveries: out, NextPageToken: res.NextPageToken}, nil
+}
+
+func handleError(err error) error {
+	switch {
+	case errors.Is(err, webhooksvc.ErrValidation):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, webhookrepo.ErrNotFound):
+		return status.Error(codes.NotFound, "webhook not found")
+	case errors.Is(err, auth.ErrUnauthenticated):
+		return status.Error(codes.Unauthenticated, "authentication required")
+	default:
+		return status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+}
+
+func recordToProto(rec webhookrepo.Record) *gen.Webhook {
+	return &gen.Webhook{
+		Id:        rec.ID,
+		Url:       rec.URL,
+		CreatedAt: rec.CreatedAt.Unix(),
+	}
+}
+
+func deliveryToProto(d webhookrepo.Delivery) *gen.Delivery {
+	out := &gen.Delivery{
+		Id:             d.ID,
+		WebhookId:      d.WebhookID,
+		EventId:        d.EventID,
+		EventType:      d.EventType,
+		Status:         statusToProto(d.Status),
+		Attempts:       int32(d.Attempts),
+		LastAttemptAt:  optionalUnix(d.LastAttemptAt),
+		LastStatusCode: int32(d.LastStatusCode),
+		LastError:      d.LastError,
+		CreatedAt:      d.CreatedAt.Unix(),
+	}
+	if d.Status == webhookrepo.StatusPending {
+		out.NextAttemptAt = optionalUnix(&d.NextAttemptAt)
+	}
+	return out
+}
+
+func statusToProto(s webhookrepo.Status) gen.DeliveryStatus {
+	switch s {
+	case webhookrepo.StatusPending:
+		return gen.DeliveryStatus_DELIVERY_STATUS_PENDING
+	case webhookrepo.StatusSucceeded:
+		return gen.DeliveryStatus_DELIVERY_STATUS_SUCCEEDED
+	case webhookrepo.StatusDead:
+		return gen.DeliveryStatus_DELIVERY_STATUS_DEAD
+	default:
+		return gen.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED
+	}
+}
+
+func optionalUnix(t *time.Time) *int64 {
+	if t == nil {
+		return nil
+	}
+	v := t.Unix()
+	return &v
+}
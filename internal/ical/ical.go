@@ -0,0 +1,75 @@
+// Package ical читает и пишет задачи iCalendar (RFC 5545, компонент VTODO).
+//
+// Поддерживаются свойства UID, SUMMARY, DESCRIPTION, STATUS, COMPLETED,
+// CREATED, LAST-MODIFIED, DUE, DTSTART, PRIORITY, CATEGORIES и RRULE;
+// остальные свойства и компоненты пропускаются. Часовые пояса задаются
+// именами базы IANA: при записи для каждого TZID добавляется VTIMEZONE, а
+// при чтении TZID разрешается через time.LoadLocation.
+package ical
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrInvalid возвращается для данных, которые не удалось разобрать.
+var ErrInvalid = errors.New("invalid icalendar")
+
+// Status — значение STATUS задачи.
+type Status string
+
+// Статусы VTODO.
+const (
+	StatusNeedsAction Status = "NEEDS-ACTION"
+	StatusInProcess   Status = "IN-PROCESS"
+	StatusCompleted   Status = "COMPLETED"
+	StatusCancelled   Status = "CANCELLED"
+)
+
+// Todo — компонент VTODO.
+type Todo struct {
+	UID         string
+	Summary     string
+	Description string
+	Status      Status
+	// Completed, Created и LastModified всегда хранятся в UTC.
+	Completed    *time.Time
+	Created      *time.Time
+	LastModified *time.Time
+	// Due и Start — значения DUE и DTSTART.
+	Due   *time.Time
+	Start *time.Time
+	// AllDay означает значения-даты (VALUE=DATE). При записи дата берётся
+	// в TimeZone, при чтении возвращается полночью UTC того же дня: дата не
+	// привязана к поясу.
+	AllDay bool
+	// TimeZone — имя пояса IANA, в котором записаны сроки; пустое или UTC
+	// означает время в UTC.
+	TimeZone string
+	// Priority — от 1 (высший) до 9 (низший); 0 означает «не задан».
+	Priority   int
+	Categories []string
+	// RRule — значение RRULE без префикса «RRULE:».
+	RRule string
+}
+
+const (
+	// dateLayout и dateTimeLayout — форматы DATE и DATE-TIME без пояса.
+	dateLayout     = "20060102"
+	dateTimeLayout = "20060102T150405"
+	// utcLayout — формат DATE-TIME в UTC.
+	utcLayout = dateTimeLayout + "Z"
+)
+
+// location возвращает пояс по имени IANA; пустое имя означает UTC.
+// Начальная косая черта, которой некоторые календари помечают
+// глобальные TZID, отбрасывается.
+func location(name string) (*time.Location, error) {
+	if len(name) > 0 && name[0] == '/' {
+		name = name[1:]
+	}
+	if name == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(name)
+}
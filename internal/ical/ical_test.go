@@ -0,0 +1,225 @@
+package ical
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func ptr(t time.Time) *time.Time {
+	return &t
+}
+
+func roundTrip(t *testing.T, todos ...Todo) (string, []Todo) {
+	t.Helper()
+	var buf bytes.Buffer
+	w := NewWriter(&buf, time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC))
+	for _, todo := range todos {
+		if err := w.Write(todo); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	r := NewReader(strings.NewReader(buf.String()))
+	var out []Todo
+	for {
+		todo, _, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Next: %v", err)
+		}
+		out = append(out, todo)
+	}
+	return buf.String(), out
+}
+
+func TestRoundTrip(t *testing.T) {
+	in := Todo{
+		UID:          "4f1c2b3a-0000-4000-8000-000000000001",
+		Summary:      "Buy milk; eggs, bread \\ more",
+		Description:  "first line\nsecond line",
+		Status:       StatusCompleted,
+		Completed:    ptr(time.Date(2026, 10, 17, 8, 30, 0, 0, time.UTC)),
+		Created:      ptr(time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)),
+		LastModified: ptr(time.Date(2026, 10, 17, 8, 30, 0, 0, time.UTC)),
+		Due:          ptr(time.Date(2026, 10, 20, 18, 0, 0, 0, time.UTC)),
+		Priority:     1,
+		Categories:   []string{"home", "a, b"},
+		RRule:        "FREQ=WEEKLY;BYDAY=MO",
+	}
+	_, out := roundTrip(t, in)
+	if len(out) != 1 {
+		t.Fatalf("got %d todos, want 1", len(out))
+	}
+	if !reflect.DeepEqual(out[0], in) {
+		t.Errorf("round trip:\n got %+v\nwant %+v", out[0], in)
+	}
+}
+
+func TestWriteFoldsLongLines(t *testing.T) {
+	summary := strings.Repeat("Задача с длинным названием ", 10)
+	text, out := roundTrip(t, Todo{UID: "long", Summary: summary})
+	for _, line := range strings.Split(strings.TrimSuffix(text, "\r\n"), "\r\n") {
+		if len(line) > maxLineOctets {
+			t.Errorf("line of %d octets: %q", len(line), line)
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("line splits a character: %q", line)
+		}
+	}
+	if len(out) != 1 || out[0].Summary != summary {
+		t.Fatalf("unexpected todos after folding: %+v", out)
+	}
+}
+
+func TestTimeZones(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	zoned := Todo{
+		UID:      "zoned",
+		Start:    ptr(time.Date(2026, 10, 18, 9, 0, 0, 0, berlin)),
+		Due:      ptr(time.Date(2026, 10, 26, 9, 0, 0, 0, berlin)),
+		TimeZone: "Europe/Berlin",
+	}
+	allDay := Todo{
+		UID:      "all-day",
+		Due:      ptr(time.Date(2026, 10, 18, 0, 0, 0, 0, berlin)),
+		AllDay:   true,
+		TimeZone: "Europe/Berlin",
+	}
+	text, out := roundTrip(t, zoned, allDay)
+
+	for _, want := range []string{
+		"DTSTART;TZID=Europe/Berlin:20261018T090000\r\n",
+		"DUE;TZID=Europe/Berlin:20261026T090000\r\n",
+		"DUE;VALUE=DATE:20261018\r\n",
+		"BEGIN:VTIMEZONE\r\nTZID:Europe/Berlin\r\n",
+		"BEGIN:STANDARD\r\nDTSTART:20261025T030000\r\nTZOFFSETFROM:+0200\r\nTZOFFSETTO:+0100\r\n",
+		"BEGIN:DAYLIGHT\r\nDTSTART:20260329T020000\r\nTZOFFSETFROM:+0100\r\nTZOFFSETTO:+0200\r\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("output does not contain %q:\n%s", want, text)
+		}
+	}
+	if strings.Count(text, "BEGIN:VTIMEZONE") != 1 {
+		t.Errorf("expected one VTIMEZONE, got:\n%s", text)
+	}
+
+	if len(out) != 2 {
+		t.Fatalf("got %d todos, want 2", len(out))
+	}
+	if !out[0].Due.Equal(*zoned.Due) || !out[0].Start.Equal(*zoned.Start) || out[0].TimeZone != "Europe/Berlin" {
+		t.Errorf("zoned todo: got %+v", out[0])
+	}
+	if !out[1].AllDay || !out[1].Due.Equal(time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("all-day todo: got %+v", out[1])
+	}
+}
+
+func TestReadForeignCalendar(t *testing.T) {
+	const cal = "BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"PRODID:-//Other//EN\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:event\r\n" +
+		"SUMMARY:Not a todo\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VTODO\r\n" +
+		"UID:first@example.com\r\n" +
+		"SUMMARY:Folded\r\n" +
+		"  summary\r\n" +
+		"\twith tab\r\n" +
+		"DUE;TZID=\"/America/New_York\":20261018T170000\r\n" +
+		"CATEGORIES:work,urgent\\, really\r\n" +
+		"CATEGORIES:extra\r\n" +
+		"BEGIN:VALARM\r\n" +
+		"ACTION:DISPLAY\r\n" +
+		"DESCRIPTION:Alarm text\r\n" +
+		"END:VALARM\r\n" +
+		"X-UNKNOWN;FOO=bar:ignored\r\n" +
+		"END:VTODO\r\n" +
+		"BEGIN:VTODO\r\n" +
+		"UID:broken\r\n" +
+		"DUE;TZID=Mars/Olympus:20261018T170000\r\n" +
+		"END:VTODO\r\n" +
+		"BEGIN:VTODO\n" +
+		"UID:floating\n" +
+		"SUMMARY:Plain LF\n" +
+		"DTSTART:20261018T080000\n" +
+		"STATUS:needs-action\n" +
+		"END:VTODO\n" +
+		"END:VCALENDAR\r\n"
+
+	r := NewReader(strings.NewReader(cal))
+	first, line, err := r.Next()
+	if err != nil {
+		t.Fatalf("first todo: %v", err)
+	}
+	newYork, _ := time.LoadLocation("America/New_York")
+	if line != 8 || first.UID != "first@example.com" || first.Summary != "Folded summarywith tab" ||
+		first.Description != "" || first.TimeZone != "America/New_York" ||
+		!first.Due.Equal(time.Date(2026, 10, 18, 17, 0, 0, 0, newYork)) ||
+		!reflect.DeepEqual(first.Categories, []string{"work", "urgent, really", "extra"}) {
+		t.Errorf("first todo: line %d, %+v", line, first)
+	}
+
+	if _, line, err := r.Next(); !errors.Is(err, ErrInvalid) || line != 22 {
+		t.Errorf("expected ErrInvalid for an unknown TZID at line 22, got %d, %v", line, err)
+	}
+
+	third, _, err := r.Next()
+	if err != nil {
+		t.Fatalf("third todo: %v", err)
+	}
+	if third.UID != "floating" || third.Status != StatusNeedsAction ||
+		!third.Start.Equal(time.Date(2026, 10, 18, 8, 0, 0, 0, time.UTC)) {
+		t.Errorf("third todo: %+v", third)
+	}
+	if _, _, err := r.Next(); !errors.Is(err, io.EOF) {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+}
+
+func TestReadInvalid(t *testing.T) {
+	for _, cal := range []string{
+		"BEGIN:VTODO\r\nUID:x\r\n",
+		"BEGIN:VTODO\r\nNO-COLON\r\nEND:VTODO\r\n",
+		"BEGIN:VTODO\r\nPRIORITY:10\r\nEND:VTODO\r\n",
+		"BEGIN:VTODO\r\nDUE:2026-10-18\r\nEND:VTODO\r\n",
+		"BEGIN:VTODO\r\nDUE;TZID=\"Europe/Berlin:20261018T090000\r\nEND:VTODO\r\n",
+	} {
+		if _, _, err := NewReader(strings.NewReader(cal)).Next(); !errors.Is(err, ErrInvalid) {
+			t.Errorf("Next(%q) = %v, want ErrInvalid", cal, err)
+		}
+	}
+}
+
+func TestEscapeText(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"plain", "plain"},
+		{"a;b,c\\d", `a\;b\,c\\d`},
+		{"line\r\nbreak", `line\nbreak`},
+	}
+	for _, tt := range tests {
+		if got := escapeText(tt.in); got != tt.want {
+			t.Errorf("escapeText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+		if got := unescapeText(escapeText(tt.in)); got != strings.ReplaceAll(tt.in, "\r", "") {
+			t.Errorf("unescapeText(escapeText(%q)) = %q", tt.in, got)
+		}
+	}
+}
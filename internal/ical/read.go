@@ -0,0 +1,309 @@
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxLineBytes ограничивает длину физической строки календаря.
+const maxLineBytes = 1 << 20
+
+// Reader читает задачи из календаря потоком, по одной.
+type Reader struct {
+	sc *bufio.Scanner
+	// lineNo — номер последней прочитанной физической строки.
+	lineNo int
+	// pending — прочитанная наперёд физическая строка: по ней видно,
+	// продолжается ли предыдущая.
+	pending    string
+	hasPending bool
+}
+
+// NewReader возвращает Reader, читающий календарь из r.
+func NewReader(r io.Reader) *Reader {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64<<10), maxLineBytes)
+	return &Reader{sc: sc}
+}
+
+// Next возвращает очередную задачу и номер строки её BEGIN:VTODO или
+// io.EOF, когда задач больше нет. Ошибка в содержимом задачи оборачивает
+// ErrInvalid; после неё чтение продолжается со следующей задачи.
+// Остальные компоненты календаря пропускаются.
+func (r *Reader) Next() (Todo, int, error) {
+	for {
+		line, no, err := r.readLine()
+		if err != nil {
+			return Todo{}, 0, err
+		}
+		if strings.EqualFold(line, "BEGIN:VTODO") {
+			t, err := r.readTodo()
+			return t, no, err
+		}
+	}
+}
+
+// readTodo читает свойства задачи до END:VTODO. Ошибка свойства не
+// прерывает чтение, чтобы следующий вызов Next начал со следующей задачи.
+func (r *Reader) readTodo() (Todo, error) {
+	var (
+		t     Todo
+		first error
+		// depth — вложенность компонентов внутри задачи, например VALARM.
+		depth int
+	)
+	for {
+		line, no, err := r.readLine()
+		if errors.Is(err, io.EOF) {
+			return Todo{}, fmt.Errorf("%w: VTODO is not terminated", ErrInvalid)
+		}
+		if err != nil {
+			return Todo{}, err
+		}
+		cl, err := parseContentLine(line)
+		if err != nil {
+			if first == nil {
+				first = fmt.Errorf("line %d: %w", no, err)
+			}
+			continue
+		}
+		switch {
+		case cl.name == "BEGIN":
+			depth++
+			continue
+		case cl.name == "END" && depth > 0:
+			depth--
+			continue
+		case cl.name == "END":
+			if first != nil {
+				return Todo{}, first
+			}
+			return t, nil
+		case depth > 0:
+			continue
+		}
+		if err := t.set(cl); err != nil && first == nil {
+			first = fmt.Errorf("line %d: %w", no, err)
+		}
+	}
+}
+
+// readLine возвращает очередную строку содержимого со снятыми переносами и
+// номер её первой физической строки. Пустые строки пропускаются.
+func (r *Reader) readLine() (string, int, error) {
+	var (
+		b     strings.Builder
+		start int
+	)
+	for {
+		if !r.hasPending {
+			if !r.sc.Scan() {
+				if err := r.sc.Err(); err != nil {
+					if errors.Is(err, bufio.ErrTooLong) {
+						return "", 0, fmt.Errorf("%w: line %d is longer than %d bytes", ErrInvalid, r.lineNo+1, maxLineBytes)
+					}
+					return "", 0, err
+				}
+				if start > 0 {
+					return b.String(), start, nil
+				}
+				return "", 0, io.EOF
+			}
+			r.lineNo++
+			r.pending, r.hasPending = strings.TrimSuffix(r.sc.Text(), "\r"), true
+		}
+		s := r.pending
+		if start > 0 {
+			if s == "" || (s[0] != ' ' && s[0] != '\t') {
+				return b.String(), start, nil
+			}
+			b.WriteString(s[1:])
+			r.hasPending = false
+			continue
+		}
+		r.hasPending = false
+		if s == "" {
+			continue
+		}
+		b.WriteString(s)
+		start = r.lineNo
+	}
+}
+
+// contentLine — разобранная строка содержимого NAME;PARAM=VALUE:VALUE.
+type contentLine struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+func parseContentLine(line string) (contentLine, error) {
+	i := strings.IndexAny(line, ";:")
+	if i <= 0 {
+		return contentLine{}, fmt.Errorf("%w: malformed content line", ErrInvalid)
+	}
+	cl := contentLine{name: strings.ToUpper(line[:i]), params: make(map[string]string)}
+	rest := line[i:]
+	for rest[0] == ';' {
+		rest = rest[1:]
+		eq := strings.IndexByte(rest, '=')
+		if eq <= 0 {
+			return contentLine{}, fmt.Errorf("%w: malformed parameter of %s", ErrInvalid, cl.name)
+		}
+		name := strings.ToUpper(rest[:eq])
+		rest = rest[eq+1:]
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.IndexByte(rest[1:], '"')
+			if end < 0 {
+				return contentLine{}, fmt.Errorf("%w: unterminated quoted parameter of %s", ErrInvalid, cl.name)
+			}
+			value, rest = rest[1:1+end], rest[end+2:]
+		} else {
+			end := strings.IndexAny(rest, ";:")
+			if end < 0 {
+				return contentLine{}, fmt.Errorf("%w: malformed parameter of %s", ErrInvalid, cl.name)
+			}
+			value, rest = rest[:end], rest[end:]
+		}
+		cl.params[name] = value
+		if rest == "" {
+			return contentLine{}, fmt.Errorf("%w: %s has no value", ErrInvalid, cl.name)
+		}
+	}
+	if rest[0] != ':' {
+		return contentLine{}, fmt.Errorf("%w: malformed content line", ErrInvalid)
+	}
+	cl.value = rest[1:]
+	return cl, nil
+}
+
+// set переносит свойство в задачу; неизвестные свойства пропускаются.
+func (t *Todo) set(cl contentLine) error {
+	switch cl.name {
+	case "UID":
+		t.UID = unescapeText(cl.value)
+	case "SUMMARY":
+		t.Summary = unescapeText(cl.value)
+	case "DESCRIPTION":
+		t.Description = unescapeText(cl.value)
+	case "STATUS":
+		t.Status = Status(strings.ToUpper(cl.value))
+	case "COMPLETED", "CREATED", "LAST-MODIFIED":
+		v, _, _, err := parseTime(cl)
+		if err != nil {
+			return err
+		}
+		v = v.UTC()
+		switch cl.name {
+		case "COMPLETED":
+			t.Completed = &v
+		case "CREATED":
+			t.Created = &v
+		default:
+			t.LastModified = &v
+		}
+	case "DUE", "DTSTART":
+		v, allDay, tz, err := parseTime(cl)
+		if err != nil {
+			return err
+		}
+		if cl.name == "DUE" {
+			t.Due = &v
+		} else {
+			t.Start = &v
+		}
+		t.AllDay = allDay
+		if tz != "" {
+			t.TimeZone = tz
+		}
+	case "PRIORITY":
+		p, err := strconv.Atoi(cl.value)
+		if err != nil || p < 0 || p > 9 {
+			return fmt.Errorf("%w: PRIORITY must be between 0 and 9", ErrInvalid)
+		}
+		t.Priority = p
+	case "CATEGORIES":
+		for _, c := range splitText(cl.value) {
+			if c = unescapeText(c); c != "" {
+				t.Categories = append(t.Categories, c)
+			}
+		}
+	case "RRULE":
+		t.RRule = cl.value
+	}
+	return nil
+}
+
+// parseTime разбирает DATE или DATE-TIME: в UTC, с TZID или «плавающее»
+// время, которое считается временем в UTC. Возвращает также, было ли
+// значение датой, и имя пояса из TZID.
+func parseTime(cl contentLine) (time.Time, bool, string, error) {
+	tz := strings.TrimPrefix(cl.params["TZID"], "/")
+	loc, err := location(tz)
+	if err != nil {
+		return time.Time{}, false, "", fmt.Errorf("%w: unknown TZID %q", ErrInvalid, cl.params["TZID"])
+	}
+	var (
+		v      time.Time
+		allDay bool
+	)
+	switch {
+	case strings.EqualFold(cl.params["VALUE"], "DATE") || len(cl.value) == len(dateLayout):
+		// Дата не привязана к поясу: она означает день в поясе задачи.
+		v, err = time.ParseInLocation(dateLayout, cl.value, time.UTC)
+		allDay, tz = true, ""
+	case strings.HasSuffix(cl.value, "Z"):
+		v, err = time.Parse(utcLayout, cl.value)
+		tz = ""
+	default:
+		v, err = time.ParseInLocation(dateTimeLayout, cl.value, loc)
+	}
+	if err != nil {
+		return time.Time{}, false, "", fmt.Errorf("%w: malformed %s value %q", ErrInvalid, cl.name, cl.value)
+	}
+	return v, allDay, tz, nil
+}
+
+// splitText делит список TEXT по неэкранированным запятым.
+func splitText(s string) []string {
+	var (
+		out   []string
+		start int
+	)
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case ',':
+			out = append(out, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(out, s[start:])
+}
+
+// unescapeText снимает экранирование значения типа TEXT.
+func unescapeText(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '\\' && i+1 < len(s) {
+			i++
+			c = s[i]
+			if c == 'n' || c == 'N' {
+				c = '\n'
+			}
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
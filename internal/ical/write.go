@@ -0,0 +1,251 @@
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ProdID — PRODID создаваемых календарей.
+const ProdID = "-//todo//todo//EN"
+
+// maxLineOctets — предельная длина строки без CRLF; длинные строки переносятся.
+const maxLineOctets = 75
+
+// Writer пишет календарь потоком: заголовок VCALENDAR — перед первой
+// задачей, окончание — в Close.
+type Writer struct {
+	w       *bufio.Writer
+	stamp   time.Time
+	started bool
+	// zones — TZID, для которых уже записан VTIMEZONE.
+	zones map[string]bool
+	err   error
+}
+
+// NewWriter возвращает Writer; stamp становится DTSTAMP всех задач.
+func NewWriter(w io.Writer, stamp time.Time) *Writer {
+	return &Writer{w: bufio.NewWriter(w), stamp: stamp.UTC(), zones: make(map[string]bool)}
+}
+
+// Write добавляет в календарь задачу.
+func (w *Writer) Write(t Todo) error {
+	loc, err := location(t.TimeZone)
+	if err != nil {
+		return fmt.Errorf("%w: unknown time zone %q", ErrInvalid, t.TimeZone)
+	}
+	tzid := ""
+	if loc != time.UTC && !t.AllDay && (t.Due != nil || t.Start != nil) {
+		tzid = loc.String()
+	}
+	w.begin()
+	if tzid != "" && !w.zones[tzid] {
+		around := t.Due
+		if around == nil {
+			around = t.Start
+		}
+		w.timezone(tzid, loc, *around)
+		w.zones[tzid] = true
+	}
+
+	w.line("BEGIN:VTODO")
+	w.line("UID:" + escapeText(t.UID))
+	w.line("DTSTAMP:" + w.stamp.Format(utcLayout))
+	w.utcProp("CREATED", t.Created)
+	w.utcProp("LAST-MODIFIED", t.LastModified)
+	if t.Summary != "" {
+		w.line("SUMMARY:" + escapeText(t.Summary))
+	}
+	if t.Description != "" {
+		w.line("DESCRIPTION:" + escapeText(t.Description))
+	}
+	if t.Status != "" {
+		w.line("STATUS:" + string(t.Status))
+	}
+	w.utcProp("COMPLETED", t.Completed)
+	w.timeProp("DTSTART", t.Start, t.AllDay, loc, tzid)
+	w.timeProp("DUE", t.Due, t.AllDay, loc, tzid)
+	if t.Priority > 0 {
+		w.line("PRIORITY:" + strconv.Itoa(t.Priority))
+	}
+	if len(t.Categories) > 0 {
+		escaped := make([]string, len(t.Categories))
+		for i, c := range t.Categories {
+			escaped[i] = escapeText(c)
+		}
+		w.line("CATEGORIES:" + strings.Join(escaped, ","))
+	}
+	if t.RRule != "" {
+		w.line("RRULE:" + t.RRule)
+	}
+	w.line("END:VTODO")
+	return w.err
+}
+
+// Close завершает календарь и сбрасывает буфер. Календарь без задач тоже
+// записывается целиком.
+func (w *Writer) Close() error {
+	w.begin()
+	w.line("END:VCALENDAR")
+	if w.err != nil {
+		return w.err
+	}
+	return w.w.Flush()
+}
+
+func (w *Writer) begin() {
+	if w.started {
+		return
+	}
+	w.started = true
+	w.line("BEGIN:VCALENDAR")
+	w.line("VERSION:2.0")
+	w.line("PRODID:" + ProdID)
+}
+
+// line записывает строку содержимого, перенося её по 75 октетов без
+// разрыва символов UTF-8.
+func (w *Writer) line(s string) {
+	if w.err != nil {
+		return
+	}
+	limit := maxLineOctets
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		if _, w.err = w.w.WriteString(s[:cut] + "\r\n "); w.err != nil {
+			return
+		}
+		s = s[cut:]
+		// Строка продолжения начинается с пробела, который тоже считается.
+		limit = maxLineOctets - 1
+	}
+	_, w.err = w.w.WriteString(s + "\r\n")
+}
+
+func (w *Writer) utcProp(name string, t *time.Time) {
+	if t != nil {
+		w.line(name + ":" + t.UTC().Format(utcLayout))
+	}
+}
+
+// timeProp записывает DUE или DTSTART: дату для задач на весь день,
+// местное время с TZID для задач в поясе и время в UTC для остальных.
+func (w *Writer) timeProp(name string, t *time.Time, allDay bool, loc *time.Location, tzid string) {
+	switch {
+	case t == nil:
+	case allDay:
+		w.line(name + ";VALUE=DATE:" + t.In(loc).Format(dateLayout))
+	case tzid != "":
+		w.line(name + ";TZID=" + paramValue(tzid) + ":" + t.In(loc).Format(dateTimeLayout))
+	default:
+		w.line(name + ":" + t.UTC().Format(utcLayout))
+	}
+}
+
+// timezone записывает VTIMEZONE с переходами пояса за несколько лет вокруг
+// around: этого достаточно календарям, которые не знают имён IANA.
+func (w *Writer) timezone(tzid string, loc *time.Location, around time.Time) {
+	from := time.Date(around.Year()-1, time.January, 1, 0, 0, 0, 0, loc)
+	to := time.Date(around.Year()+3, time.January, 1, 0, 0, 0, 0, loc)
+
+	w.line("BEGIN:VTIMEZONE")
+	w.line("TZID:" + tzid)
+	_, offset := from.Zone()
+	w.observance(from, offset)
+	for _, at := range transitions(from, to) {
+		_, prev := at.Add(-time.Second).Zone()
+		w.observance(at, prev)
+	}
+	w.line("END:VTIMEZONE")
+}
+
+// observance записывает STANDARD или DAYLIGHT, вступающий в силу в at;
+// prevOffset — смещение пояса до at.
+func (w *Writer) observance(at time.Time, prevOffset int) {
+	kind := "STANDARD"
+	if at.IsDST() {
+		kind = "DAYLIGHT"
+	}
+	name, offset := at.Zone()
+	w.line("BEGIN:" + kind)
+	// DTSTART наблюдения — местное время по смещению, действовавшему до него.
+	w.line("DTSTART:" + at.In(time.FixedZone("", prevOffset)).Format(dateTimeLayout))
+	w.line("TZOFFSETFROM:" + formatOffset(prevOffset))
+	w.line("TZOFFSETTO:" + formatOffset(offset))
+	if name != "" {
+		w.line("TZNAME:" + escapeText(name))
+	}
+	w.line("END:" + kind)
+}
+
+// transitions возвращает моменты смены смещения пояса в [from, to).
+func transitions(from, to time.Time) []time.Time {
+	var out []time.Time
+	for day := from; day.Before(to); {
+		next := day.Add(24 * time.Hour)
+		_, a := day.Zone()
+		_, b := next.Zone()
+		if a != b {
+			// Ищем первую секунду с новым смещением.
+			lo, hi := day, next
+			for hi.Sub(lo) > time.Second {
+				mid := lo.Add(hi.Sub(lo) / 2)
+				if _, off := mid.Zone(); off == a {
+					lo = mid
+				} else {
+					hi = mid
+				}
+			}
+			out = append(out, hi)
+		}
+		day = next
+	}
+	return out
+}
+
+func formatOffset(seconds int) string {
+	sign := '+'
+	if seconds < 0 {
+		sign = '-'
+		seconds = -seconds
+	}
+	s := fmt.Sprintf("%c%02d%02d", sign, seconds/3600, seconds%3600/60)
+	if rest := seconds % 60; rest != 0 {
+		s += fmt.Sprintf("%02d", rest)
+	}
+	return s
+}
+
+// escapeText экранирует значение типа TEXT.
+func escapeText(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch r {
+		case '\\', ';', ',':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// paramValue заключает значение параметра в кавычки, если оно содержит
+// разделители.
+func paramValue(s string) string {
+	if strings.ContainsAny(s, ":;,") {
+		return `"` + s + `"`
+	}
+	return s
+}
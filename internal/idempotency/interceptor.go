@@ -0,0 +1,219 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+	"time"
+	"unicode/utf8"
+
+	"todo/internal/auth"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// Header — метаданные, в которых клиент передаёт ключ идемпотентности.
+const Header = "idempotency-key"
+
+// maxKeyLength ограничивает длину ключа в символах.
+const maxKeyLength = 255
+
+// Store хранит ключи идемпотентности вызывающих.
+type Store interface {
+	Reserve(ctx context.Context, key, method, fingerprint string, lease time.Duration) (Record, bool, error)
+	Complete(ctx context.Context, key, token string, response []byte, ttl time.Duration) error
+	Release(ctx context.Context, key, token string) error
+}
+
+// Config описывает хранение ключей идемпотентности.
+type Config struct {
+	// TTL — сколько хранится успешный ответ.
+	TTL time.Duration
+	// Lease — на сколько ключ занимается, пока выполняется первый вызов, если
+	// до срока вызова остаётся меньше; если сервер упадёт, не сохранив ответ,
+	// ключ освободится сам.
+	Lease time.Duration
+}
+
+// UnaryInterceptor делает изменяющие unary-вызовы с метаданными
+// idempotency-key идемпотентными: успешный ответ хранится cfg.TTL, и повтор
+// с тем же ключом и тем же запросом получает его без повторного выполнения.
+// Повтор с другим запросом отклоняется с FailedPrecondition, а пока первый
+// вызов выполняется — с Aborted. Ошибки не сохраняются: после неё вызов
+// можно повторить с тем же ключом. Ключи принадлежат вызывающему, поэтому
+// перехватчик должен идти после аутентификации.
+func UnaryInterceptor(store Store, cfg Config) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if auth.ReadMethod(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		if _, ok := auth.FromContext(ctx); !ok {
+			return handler(ctx, req)
+		}
+		key, ok, err := keyFromContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return handler(ctx, req)
+		}
+		msg, ok := req.(proto.Message)
+		if !ok {
+			return handler(ctx, req)
+		}
+		fp, err := fingerprint(info.FullMethod, msg)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "fingerprint request: %v", err)
+		}
+
+		rec, reserved, err := store.Reserve(ctx, key, info.FullMethod, fp, leaseFor(ctx, cfg.Lease))
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "reserve idempotency key: %v", err)
+		}
+		if !reserved {
+			return replay(rec, fp)
+		}
+
+		// Ключ освобождается и ответ сохраняется, даже если клиент уже отключился.
+		detached := context.WithoutCancel(ctx)
+		resp, err := handler(ctx, req)
+		if err != nil {
+			release(detached, store, key, rec.Token)
+			return nil, err
+		}
+		if err := complete(detached, store, key, rec.Token, resp, cfg.TTL); err != nil {
+			// Вызов уже выполнен: его ответ важнее, чем сохранение ключа.
+			log.Printf("store idempotent response for %s: %v", info.FullMethod, err)
+			if !errors.Is(err, ErrLeaseLost) {
+				release(detached, store, key, rec.Token)
+			}
+		}
+		return resp, nil
+	}
+}
+
+// StreamInterceptor отклоняет потоковые вызовы с метаданными
+// idempotency-key: запрос такого вызова приходит частями, поэтому повтор
+// нельзя сверить с первым вызовом, а ответ — сохранить целиком.
+func StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		_, ok, err := keyFromContext(ss.Context())
+		if err != nil {
+			return err
+		}
+		if ok {
+			return status.Errorf(codes.InvalidArgument, "%s is not supported for streaming calls", Header)
+		}
+		return handler(srv, ss)
+	}
+}
+
+// keyFromContext возвращает ключ идемпотентности из метаданных вызова
+// и false, если ключ не передан.
+func keyFromContext(ctx context.Context) (string, bool, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(Header)
+	if len(values) == 0 {
+		return "", false, nil
+	}
+	key := values[0]
+	switch {
+	case len(values) > 1:
+		return "", false, status.Errorf(codes.InvalidArgument, "exactly one %s is allowed", Header)
+	case key == "":
+		return "", false, status.Errorf(codes.InvalidArgument, "%s must not be empty", Header)
+	case utf8.RuneCountInString(key) > maxKeyLength:
+		return "", false, status.Errorf(codes.InvalidArgument, "%s is longer than %d characters", Header, maxKeyLength)
+	}
+	return key, true, nil
+}
+
+// leaseFor продлевает lease до срока вызова, чтобы ключ не освободился,
+// пока вызов ещё может выполняться.
+func leaseFor(ctx context.Context, lease time.Duration) time.Duration {
+	if deadline, ok := ctx.Deadline(); ok {
+		return max(lease, time.Until(deadline))
+	}
+	return lease
+}
+
+func release(ctx context.Context, store Store, key, token string) {
+	if err := store.Release(ctx, key, token); err != nil && !errors.Is(err, ErrLeaseLost) {
+		log.Printf("release idempotency key: %v", err)
+	}
+}
+
+// fingerprint — SHA-256 метода и детерминированно сериализованного запроса.
+func fingerprint(method string, req proto.Message) (string, error) {
+	body, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	h.Write([]byte(method))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func complete(ctx context.Context, store Store, key, token string, resp any, ttl time.Duration) error {
+	msg, ok := resp.(proto.Message)
+	if !ok {
+		return status.Errorf(codes.Internal, "response %T is not a protobuf message", resp)
+	}
+	wrapped, err := anypb.New(msg)
+	if err != nil {
+		return err
+	}
+	data, err := proto.Marshal(wrapped)
+	if err != nil {
+		return err
+	}
+	return store.Complete(ctx, key, token, data, ttl)
+}
+
+// replay возвращает сохранённый ответ, если ключ прислан с тем же запросом.
+func replay(rec Record, fingerprint string) (any, error) {
+	if rec.Fingerprint != fingerprint {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s was already used with a different request", Header)
+	}
+	if rec.Response == nil {
+		return nil, status.Errorf(codes.Aborted, "a request with this %s is still in progress", Header)
+	}
+	var wrapped anypb.Any
+	if err := proto.Unmarshal(rec.Response, &wrapped); err != nil {
+		return nil, status.Errorf(codes.Internal, "decode stored response: %v", err)
+	}
+	resp, err := wrapped.UnmarshalNew()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "decode stored response: %v", err)
+	}
+	return resp, nil
+}
+
+// RunPurger раз в interval удаляет истёкшие ключи. Блокируется до отмены ctx.
+func RunPurger(ctx context.Context, repo *Repository, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		n, err := repo.PurgeExpired(ctx, time.Now())
+		switch {
+		case err != nil && ctx.Err() == nil:
+			log.Printf("purge idempotency keys: %v", err)
+		case n > 0:
+			log.Printf("purged %d idempotency keys", n)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
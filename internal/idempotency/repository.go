@@ -0,0 +1,153 @@
+// Package idempotency хранит ключи идемпотентности изменяющих вызовов и
+// повторяет сохранённый ответ, если клиент прислал вызов с тем же ключом ещё раз.
+package idempotency
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"todo/internal/auth"
+)
+
+// ErrLeaseLost сообщает, что ключ больше не занят вызовом с этой меткой:
+// его lease истёк, и ключ освобождён или занят другим вызовом.
+var ErrLeaseLost = errors.New("idempotency key lease lost")
+
+// tokenBytes — энтропия метки резервирования.
+const tokenBytes = 16
+
+// Record — сохранённый ключ вызывающего.
+type Record struct {
+	Method      string
+	Fingerprint string
+	// Response пуст, пока первый вызов с этим ключом выполняется.
+	Response []byte
+	// Token — метка резервирования; задана, только если ключ занял этот вызов.
+	Token     string
+	ExpiresAt time.Time
+}
+
+// Repository инкапсулирует доступ к таблице ключей идемпотентности.
+type Repository struct {
+	db *sql.DB
+}
+
+// NewRepository создает новый репозиторий ключей идемпотентности.
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Reserve занимает ключ вызывающего на lease, если ключ свободен или истёк,
+// и возвращает true и запись с меткой резервирования, которую принимают
+// Complete и Release. Иначе ничего не меняет и возвращает сохранённую запись.
+func (r *Repository) Reserve(ctx context.Context, key, method, fingerprint string, lease time.Duration) (Record, bool, error) {
+	principal, err := auth.Require(ctx)
+	if err != nil {
+		return Record{}, false, err
+	}
+	raw := make([]byte, tokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return Record{}, false, err
+	}
+	token := hex.EncodeToString(raw)
+	for {
+		now := time.Now().UTC()
+		var reserved bool
+		err := r.db.QueryRowContext(ctx, `
+insert into idempotency_keys (tenant_id, subject, key, method, fingerprint, token, created_at, expires_at)
+values ($1, $2, $3, $4, $5, $6, $7, $8)
+on conflict (tenant_id, subject, key) do update
+set method = excluded.method, fingerprint = excluded.fingerprint, response = null, token = excluded.token,
+    created_at = excluded.created_at, expires_at = excluded.expires_at
+where idempotency_keys.expires_at <= excluded.created_at
+returning true`,
+			principal.TenantID, principal.Subject, key, method, fingerprint, token, now, now.Add(lease),
+		).Scan(&reserved)
+		if err == nil {
+			return Record{Method: method, Fingerprint: fingerprint, Token: token, ExpiresAt: now.Add(lease)}, true, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return Record{}, false, err
+		}
+
+		var rec Record
+		err = r.db.QueryRowContext(ctx, `
+select method, fingerprint, response, expires_at
+from idempotency_keys
+where tenant_id = $1 and subject = $2 and key = $3 and expires_at > $4`,
+			principal.TenantID, principal.Subject, key, now,
+		).Scan(&rec.Method, &rec.Fingerprint, &rec.Response, &rec.ExpiresAt)
+		if errors.Is(err, sql.ErrNoRows) {
+			// Ключ истёк или освободился между запросами — пробуем занять снова.
+			continue
+		}
+		if err != nil {
+			return Record{}, false, err
+		}
+		return rec, false, nil
+	}
+}
+
+// Complete сохраняет ответ на вызов, занявший ключ с меткой token; ключ
+// хранится ещё ttl. Если ключ больше не занят этой меткой, возвращает
+// ErrLeaseLost и ничего не меняет.
+func (r *Repository) Complete(ctx context.Context, key, token string, response []byte, ttl time.Duration) error {
+	principal, err := auth.Require(ctx)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	res, err := r.db.ExecContext(ctx, `
+update idempotency_keys
+set response = $5, expires_at = $6
+where tenant_id = $1 and subject = $2 and key = $3 and token = $4 and response is null and expires_at > $7`,
+		principal.TenantID, principal.Subject, key, token, response, now.Add(ttl), now)
+	if err != nil {
+		return err
+	}
+	return leaseHeld(res)
+}
+
+// Release освобождает ключ, занятый с меткой token, на который ещё не
+// сохранён ответ, чтобы клиент мог повторить неудавшийся вызов с тем же
+// ключом. Ключ, занятый уже другим вызовом, не трогает.
+func (r *Repository) Release(ctx context.Context, key, token string) error {
+	principal, err := auth.Require(ctx)
+	if err != nil {
+		return err
+	}
+	res, err := r.db.ExecContext(ctx, `
+delete from idempotency_keys
+where tenant_id = $1 and subject = $2 and key = $3 and token = $4 and response is null`,
+		principal.TenantID, principal.Subject, key, token)
+	if err != nil {
+		return err
+	}
+	return leaseHeld(res)
+}
+
+// leaseHeld возвращает ErrLeaseLost, если запрос не затронул ключ.
+func leaseHeld(res sql.Result) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+// PurgeExpired удаляет ключи всех арендаторов, истёкшие до before, и
+// возвращает их число.
+func (r *Repository) PurgeExpired(ctx context.Context, before time.Time) (int64, error) {
+	res, err := r.db.ExecContext(ctx, `delete from idempotency_keys where expires_at <= $1`, before.UTC())
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,216 @@
+// Package jwt проверяет подписанные JWT (RFC 7519) с алгоритмами HS256,
+// RS256 и ES256.
+package jwt
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrMalformed возвращается, если токен не разбирается.
+	ErrMalformed = errors.New("malformed token")
+	// ErrSignature возвращается, если подпись не совпала или ключ не найден.
+	ErrSignature = errors.New("invalid token signature")
+	// ErrClaims возвращается, если утверждения токена не прошли проверку.
+	ErrClaims = errors.New("invalid token claims")
+)
+
+// Claims содержит проверенные утверждения токена.
+type Claims struct {
+	Subject   string
+	Issuer    string
+	Audience  []string
+	ExpiresAt time.Time
+	// Extra хранит все утверждения токена, включая нестандартные.
+	Extra map[string]any
+}
+
+// String возвращает строковое утверждение name или пустую строку.
+func (c Claims) String(name string) string {
+	s, _ := c.Extra[name].(string)
+	return s
+}
+
+// Config задаёт ключи и ожидаемые утверждения.
+type Config struct {
+	// HMACSecret включает HS256.
+	HMACSecret []byte
+	// Keys — открытые ключи RS256 и ES256 по идентификатору kid.
+	Keys map[string]crypto.PublicKey
+	// Issuer и Audience, если заданы, должны совпасть с iss и одним из aud.
+	Issuer   string
+	Audience string
+	// Leeway — допустимое расхождение часов при проверке exp и nbf.
+	Leeway time.Duration
+}
+
+// Verifier проверяет токены.
+type Verifier struct {
+	cfg Config
+}
+
+// NewVerifier создаёт проверяющего; нужен хотя бы один ключ.
+func NewVerifier(cfg Config) (*Verifier, error) {
+	if len(cfg.HMACSecret) == 0 && len(cfg.Keys) == 0 {
+		return nil, errors.New("jwt: no verification keys configured")
+	}
+	return &Verifier{cfg: cfg}, nil
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// Verify проверяет подпись и утверждения токена на момент now.
+func (v *Verifier) Verify(token string, now time.Time) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Claims{}, ErrMalformed
+	}
+	rawHeader, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return Claims{}, ErrMalformed
+	}
+	var h header
+	if err := json.Unmarshal(rawHeader, &h); err != nil {
+		return Claims{}, ErrMalformed
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return Claims{}, ErrMalformed
+	}
+	if err := v.verifySignature(h, parts[0]+"."+parts[1], sig); err != nil {
+		return Claims{}, err
+	}
+
+	rawPayload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return Claims{}, ErrMalformed
+	}
+	dec := json.NewDecoder(bytes.NewReader(rawPayload))
+	dec.UseNumber()
+	var extra map[string]any
+	if err := dec.Decode(&extra); err != nil {
+		return Claims{}, ErrMalformed
+	}
+	return v.checkClaims(extra, now)
+}
+
+// verifySignature проверяет подпись выбранным алгоритмом. Алгоритм должен
+// соответствовать типу ключа, иначе открытый ключ RSA можно было бы выдать
+// за секрет HMAC.
+func (v *Verifier) verifySignature(h header, signed string, sig []byte) error {
+	digest := sha256.Sum256([]byte(signed))
+	switch h.Alg {
+	case "HS256":
+		if len(v.cfg.HMACSecret) == 0 {
+			return fmt.Errorf("%w: HS256 is not enabled", ErrSignature)
+		}
+		mac := hmac.New(sha256.New, v.cfg.HMACSecret)
+		mac.Write([]byte(signed))
+		if !hmac.Equal(sig, mac.Sum(nil)) {
+			return ErrSignature
+		}
+		return nil
+	case "RS256":
+		key, ok := v.cfg.Keys[h.Kid].(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("%w: unknown RSA key %q", ErrSignature, h.Kid)
+		}
+		if rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig) != nil {
+			return ErrSignature
+		}
+		return nil
+	case "ES256":
+		key, ok := v.cfg.Keys[h.Kid].(*ecdsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("%w: unknown EC key %q", ErrSignature, h.Kid)
+		}
+		// Подпись JWS — это r и s фиксированной длины подряд, а не DER.
+		if len(sig) != 64 {
+			return ErrSignature
+		}
+		r := new(big.Int).SetBytes(sig[:32])
+		s := new(big.Int).SetBytes(sig[32:])
+		if !ecdsa.Verify(key, digest[:], r, s) {
+			return ErrSignature
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: unsupported algorithm %q", ErrSignature, h.Alg)
+	}
+}
+
+func (v *Verifier) checkClaims(extra map[string]any, now time.Time) (Claims, error) {
+	claims := Claims{Extra: extra}
+	claims.Subject, _ = extra["sub"].(string)
+	claims.Issuer, _ = extra["iss"].(string)
+	switch aud := extra["aud"].(type) {
+	case string:
+		claims.Audience = []string{aud}
+	case []any:
+		for _, a := range aud {
+			if s, ok := a.(string); ok {
+				claims.Audience = append(claims.Audience, s)
+			}
+		}
+	}
+
+	exp, ok := numericDate(extra["exp"])
+	if !ok {
+		return Claims{}, fmt.Errorf("%w: exp is required", ErrClaims)
+	}
+	claims.ExpiresAt = exp
+	if !now.Before(exp.Add(v.cfg.Leeway)) {
+		return Claims{}, fmt.Errorf("%w: token expired", ErrClaims)
+	}
+	if nbf, ok := numericDate(extra["nbf"]); ok && now.Add(v.cfg.Leeway).Before(nbf) {
+		return Claims{}, fmt.Errorf("%w: token is not valid yet", ErrClaims)
+	}
+	if claims.Subject == "" {
+		return Claims{}, fmt.Errorf("%w: sub is required", ErrClaims)
+	}
+	if v.cfg.Issuer != "" && claims.Issuer != v.cfg.Issuer {
+		return Claims{}, fmt.Errorf("%w: unexpected issuer %q", ErrClaims, claims.Issuer)
+	}
+	if v.cfg.Audience != "" && !contains(claims.Audience, v.cfg.Audience) {
+		return Claims{}, fmt.Errorf("%w: token is not intended for %q", ErrClaims, v.cfg.Audience)
+	}
+	return claims, nil
+}
+
+// numericDate разбирает NumericDate — число секунд с начала эпохи.
+func numericDate(v any) (time.Time, bool) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return time.Time{}, false
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return time.Time{}, false
+	}
+	sec := int64(f)
+	return time.Unix(sec, int64((f-float64(sec))*1e9)), true
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
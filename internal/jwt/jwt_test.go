@@ -0,0 +1,186 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+var now = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func TestVerifyHS256(t *testing.T) {
+	secret := []byte("s3cret")
+	v, err := NewVerifier(Config{HMACSecret: secret, Issuer: "https://issuer", Audience: "todo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := sign(t, map[string]any{"alg": "HS256"}, validClaims(), func(signed []byte) []byte {
+		mac := hmac.New(sha256.New, secret)
+		mac.Write(signed)
+		return mac.Sum(nil)
+	})
+
+	claims, err := v.Verify(token, now)
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if claims.Subject != "alice" || claims.String("tenant_id") != "acme" {
+		t.Fatalf("unexpected claims %+v", claims)
+	}
+
+	tampered := token[:len(token)-2] + "AA"
+	if _, err := v.Verify(tampered, now); !errors.Is(err, ErrSignature) {
+		t.Fatalf("expected ErrSignature for a tampered token, got %v", err)
+	}
+}
+
+func TestVerifyRS256AndES256(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwks := fmt.Sprintf(`{"keys":[
+		{"kty":"RSA","kid":"r1","use":"sig","n":%q,"e":"AQAB"},
+		{"kty":"EC","kid":"e1","crv":"P-256","x":%q,"y":%q}
+	]}`, b64(rsaKey.N.Bytes()), b64(ecKey.X.FillBytes(make([]byte, 32))), b64(ecKey.Y.FillBytes(make([]byte, 32))))
+	keys, err := ParseJWKS([]byte(jwks))
+	if err != nil {
+		t.Fatalf("parse jwks: %v", err)
+	}
+	v, err := NewVerifier(Config{Keys: keys, Audience: "todo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rsToken := sign(t, map[string]any{"alg": "RS256", "kid": "r1"}, validClaims(), func(signed []byte) []byte {
+		digest := sha256.Sum256(signed)
+		sig, err := rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sig
+	})
+	if _, err := v.Verify(rsToken, now); err != nil {
+		t.Fatalf("verify RS256: %v", err)
+	}
+
+	esToken := sign(t, map[string]any{"alg": "ES256", "kid": "e1"}, validClaims(), func(signed []byte) []byte {
+		digest := sha256.Sum256(signed)
+		r, s, err := ecdsa.Sign(rand.Reader, ecKey, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig := make([]byte, 64)
+		r.FillBytes(sig[:32])
+		s.FillBytes(sig[32:])
+		return sig
+	})
+	if _, err := v.Verify(esToken, now); err != nil {
+		t.Fatalf("verify ES256: %v", err)
+	}
+
+	// Ключ RS256 не должен приниматься под другим kid или алгоритмом.
+	wrongKid := sign(t, map[string]any{"alg": "RS256", "kid": "e1"}, validClaims(), func([]byte) []byte { return []byte("x") })
+	if _, err := v.Verify(wrongKid, now); !errors.Is(err, ErrSignature) {
+		t.Fatalf("expected ErrSignature for a mismatched key, got %v", err)
+	}
+	hsToken := sign(t, map[string]any{"alg": "HS256"}, validClaims(), func([]byte) []byte { return []byte("x") })
+	if _, err := v.Verify(hsToken, now); !errors.Is(err, ErrSignature) {
+		t.Fatalf("expected HS256 to be rejected without a secret, got %v", err)
+	}
+}
+
+func TestVerifyClaims(t *testing.T) {
+	secret := []byte("s3cret")
+	v, err := NewVerifier(Config{HMACSecret: secret, Issuer: "https://issuer", Audience: "todo", Leeway: time.Minute})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hs := func(signed []byte) []byte {
+		mac := hmac.New(sha256.New, secret)
+		mac.Write(signed)
+		return mac.Sum(nil)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(map[string]any)
+		ok     bool
+	}{
+		{name: "within leeway after expiry", modify: func(c map[string]any) { c["exp"] = now.Add(-30 * time.Second).Unix() }, ok: true},
+		{name: "expired", modify: func(c map[string]any) { c["exp"] = now.Add(-2 * time.Minute).Unix() }},
+		{name: "missing exp", modify: func(c map[string]any) { delete(c, "exp") }},
+		{name: "not yet valid", modify: func(c map[string]any) { c["nbf"] = now.Add(time.Hour).Unix() }},
+		{name: "wrong issuer", modify: func(c map[string]any) { c["iss"] = "https://evil" }},
+		{name: "wrong audience", modify: func(c map[string]any) { c["aud"] = "other" }},
+		{name: "audience list", modify: func(c map[string]any) { c["aud"] = []string{"other", "todo"} }, ok: true},
+		{name: "missing subject", modify: func(c map[string]any) { delete(c, "sub") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := validClaims()
+			tt.modify(claims)
+			_, err := v.Verify(sign(t, map[string]any{"alg": "HS256"}, claims, hs), now)
+			if tt.ok && err != nil {
+				t.Fatalf("expected the token to be accepted, got %v", err)
+			}
+			if !tt.ok && !errors.Is(err, ErrClaims) {
+				t.Fatalf("expected ErrClaims, got %v", err)
+			}
+		})
+	}
+}
+
+func TestParseJWKSRejectsWeakKeys(t *testing.T) {
+	weak := `{"keys":[{"kty":"RSA","kid":"r1","n":"AQAB","e":"AQAB"}]}`
+	if _, err := ParseJWKS([]byte(weak)); err == nil {
+		t.Fatal("expected a short RSA modulus to be rejected")
+	}
+	offCurve := fmt.Sprintf(`{"keys":[{"kty":"EC","kid":"e1","crv":"P-256","x":%q,"y":%q}]}`,
+		b64([]byte{1}), b64([]byte{2}))
+	if _, err := ParseJWKS([]byte(offCurve)); err == nil {
+		t.Fatal("expected a point off the curve to be rejected")
+	}
+}
+
+func validClaims() map[string]any {
+	return map[string]any{
+		"sub":       "alice",
+		"iss":       "https://issuer",
+		"aud":       "todo",
+		"exp":       now.Add(time.Hour).Unix(),
+		"tenant_id": "acme",
+	}
+}
+
+func sign(t *testing.T, header, claims map[string]any, signFn func([]byte) []byte) string {
+	t.Helper()
+	h, err := json.Marshal(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed := b64(h) + "." + b64(c)
+	return signed + "." + b64(signFn([]byte(signed)))
+}
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,237 @@
+// Package project содержит репозиторий для работы с проектами в базе данных.
+package project
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"todo/internal/access"
+	"todo/internal/auth"
+)
+
+// ErrNotFound возвращается, если проект не найден.
+var ErrNotFound = errors.New("project not found")
+
+// Repository инкапсулирует доступ к таблице проектов арендатора вызывающего.
+type Repository struct {
+	db *sql.DB
+}
+
+// Record представляет запись проекта.
+type Record struct {
+	ID          string
+	Name        string
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	// OwnerID — пользователь, создавший проект.
+	OwnerID string
+	// TodoCount — число задач проекта вне корзины.
+	TodoCount int64
+}
+
+// recordColumns перечисляет колонки, из которых собирается Record.
+const recordColumns = `id, name, description, created_at, updated_at, owner_id,
+	(select count(*) from todos t where t.project_id = projects.id and t.deleted_at is null)`
+
+func scanRecord(row interface{ Scan(dest ...any) error }) (Record, error) {
+	var rec Record
+	err := row.Scan(&rec.ID, &rec.Name, &rec.Description, &rec.CreatedAt, &rec.UpdatedAt, &rec.OwnerID, &rec.TodoCount)
+	return rec, err
+}
+
+// NewRepository создает новый репозиторий проектов.
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Create добавляет новый проект.
+func (r *Repository) Create(ctx context.Context, name, description string) (Record, error) {
+	principal, err := auth.Require(ctx)
+	if err != nil {
+		return Record{}, err
+	}
+	query := `
+insert into projects (name, description, created_at, updated_at, tenant_id, owner_id)
+values ($1, $2, $3, $3, $4, $5)
+returning ` + recordColumns
+
+	return scanRecord(r.db.QueryRowContext(ctx, query,
+		name, description, time.Now().UTC(), principal.TenantID, principal.Subject))
+}
+
+// Get возвращает проект по идентификатору.
+func (r *Repository) Get(ctx context.Context, id string) (Record, error) {
+	principal, err := auth.Require(ctx)
+	if err != nil {
+		return Record{}, err
+	}
+	query := `
+select ` + recordColumns + `
+from projects
+where id = $1 and ` + access.ProjectVisible("projects", "$2", "$3")
+
+	rec, err := scanRecord(r.db.QueryRowContext(ctx, query, id, principal.TenantID, principal.Subject))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Record{}, ErrNotFound
+		}
+		return Record{}, err
+	}
+	return rec, nil
+}
+
+// List возвращает видимые вызывающему проекты в алфавитном порядке.
+func (r *Repository) List(ctx context.Context) ([]Record, error) {
+	principal, err := auth.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query := `
+select ` + recordColumns + `
+from projects
+where ` + access.ProjectVisible("projects", "$1", "$2") + `
+order by name, id`
+
+	rows, err := r.db.QueryContext(ctx, query, principal.TenantID, principal.Subject)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var items []Record
+	for rows.Next() {
+		rec, err := scanRecord(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, rec)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// Patch перечисляет изменяемые поля проекта; nil означает «оставить как есть».
+type Patch struct {
+	Name        *string
+	Description *string
+}
+
+// Update изменяет поля проекта, заданные в patch.
+func (r *Repository) Update(ctx context.Context, id string, patch Patch) (Record, error) {
+	principal, err := auth.Require(ctx)
+	if err != nil {
+		return Record{}, err
+	}
+	args := []any{id, time.Now().UTC(), principal.TenantID, principal.Subject}
+	sets := []string{"updated_at = $2"}
+	if patch.Name != nil {
+		args = append(args, *patch.Name)
+		sets = append(sets, "name = $"+strconv.Itoa(len(args)))
+	}
+	if patch.Description != nil {
+		args = append(args, *patch.Description)
+		sets = append(sets, "description = $"+strconv.Itoa(len(args)))
+	}
+	query := `
+update projects
+set ` + strings.Join(sets, ", ") + `
+where id = $1 and ` + access.ProjectVisible("projects", "$3", "$4") + `
+returning ` + recordColumns
+
+	rec, err := scanRecord(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Record{}, ErrNotFound
+		}
+		return Record{}, err
+	}
+	return rec, nil
+}
+
+// DeleteMode определяет судьбу задач удаляемого проекта.
+type DeleteMode int
+
+// Поддерживаемые режимы удаления проекта.
+const (
+	// DeleteMoveToInbox переносит задачи во «Входящие».
+	DeleteMoveToInbox DeleteMode = iota
+	// DeleteCascade перемещает задачи проекта в корзину.
+	DeleteCascade
+)
+
+// Delete удаляет проект. Задачи проекта в одной транзакции с удалением
+// открепляются от него и, в режиме DeleteCascade, попадают в корзину;
+// версия каждой из них увеличивается.
+func (r *Repository) Delete(ctx context.Context, id string, mode DeleteMode) error {
+	principal, err := auth.Require(ctx)
+	if err != nil {
+		return err
+	}
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	// Блокировка ленты арендатора берётся раньше блокировок строк, как и
+	// в транзакциях задач.
+	if _, err := tx.ExecContext(ctx, `select todo_events_lock($1)`, principal.TenantID); err != nil {
+		return err
+	}
+	var locked string
+	if err := tx.QueryRowContext(ctx,
+		`select id from projects where id = $1 and `+access.ProjectVisible("projects", "$2", "$3")+` for update`,
+		id, principal.TenantID, principal.Subject).Scan(&locked); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		return err
+	}
+
+	now := time.Now().UTC()
+	// Журнал задач пишется до их изменения, пока видны прежние значения.
+	cascade := mode == DeleteCascade
+	if _, err := tx.ExecContext(ctx, `
+insert into todo_events (todo_id, tenant_id, actor, operation, changes, created_at)
+select id, tenant_id, $2,
+    case when $3 and deleted_at is null then 'delete' else 'update' end,
+    jsonb_build_object('project_id', jsonb_build_object('before', project_id, 'after', null)) ||
+        case when $3 and deleted_at is null
+            then jsonb_build_object('deleted_at', jsonb_build_object('before', null, 'after', $4::text))
+            else '{}'::jsonb
+        end,
+    $5
+from todos
+where project_id = $1`, id, principal.Subject, cascade, now.Format(time.RFC3339Nano), now); err != nil {
+		return err
+	}
+	sets := "project_id = null, version = version + 1, updated_at = $2"
+	if cascade {
+		sets += ", deleted_at = coalesce(deleted_at, $2)"
+		// Комментарии активных задач уходят в корзину вместе с ними.
+		if _, err := tx.ExecContext(ctx, `
+update comments set deleted_at = $2
+where deleted_at is null
+  and todo_id in (select id from todos where project_id = $1 and deleted_at is null)`, id, now); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `update todos set `+sets+` where project_id = $1`, id, now); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `delete from projects where id = $1`, id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,245 @@
+package rrule
+
+import (
+	"sort"
+	"time"
+)
+
+// searchHorizon ограничивает поиск следующего повторения: правило, у которого
+// за столько лет не нашлось ни одного повторения, считается исчерпанным.
+const searchHorizon = 100
+
+// After возвращает первое повторение строго после t для серии, начинающейся
+// в dtstart. Повторения вычисляются в часовом поясе dtstart и сохраняют его
+// время суток, поэтому переход на летнее время не сдвигает их. Как и в RFC 5545,
+// dtstart всегда считается первым повторением. Второе значение ложно, если
+// серия закончилась.
+func (r Rule) After(dtstart, t time.Time) (time.Time, bool) {
+	var found time.Time
+	ok := false
+	r.each(dtstart, t.AddDate(searchHorizon, 0, 0), func(occ time.Time) bool {
+		if occ.After(t) {
+			found, ok = occ, true
+			return false
+		}
+		return true
+	})
+	return found, ok
+}
+
+// All возвращает не больше limit первых повторений серии.
+func (r Rule) All(dtstart time.Time, limit int) []time.Time {
+	var out []time.Time
+	if limit <= 0 {
+		return out
+	}
+	r.each(dtstart, dtstart.AddDate(searchHorizon, 0, 0), func(occ time.Time) bool {
+		out = append(out, occ)
+		return len(out) < limit
+	})
+	return out
+}
+
+// each перебирает повторения по порядку, пока fn возвращает true, серия не
+// закончилась или период не ушёл дальше horizon.
+func (r Rule) each(dtstart, horizon time.Time, fn func(time.Time) bool) {
+	loc := dtstart.Location()
+	hour, minute, sec := dtstart.Clock()
+	count := 1
+	if r.past(dtstart) || !fn(dtstart) {
+		return
+	}
+	for period := 0; ; period++ {
+		start, days := r.period(dtstart, period)
+		if start.After(horizon) {
+			return
+		}
+		for _, day := range days {
+			occ := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, sec, dtstart.Nanosecond(), loc)
+			if !occ.After(dtstart) {
+				continue
+			}
+			if r.past(occ) {
+				return
+			}
+			count++
+			if r.Count > 0 && count > r.Count {
+				return
+			}
+			if !fn(occ) {
+				return
+			}
+		}
+	}
+}
+
+// past сообщает, что момент лежит за границей UNTIL.
+func (r Rule) past(occ time.Time) bool {
+	if r.Until.IsZero() {
+		return false
+	}
+	if r.UntilDate {
+		y, m, d := occ.Date()
+		return civil(y, m, d).After(r.Until)
+	}
+	return occ.After(r.Until)
+}
+
+// period возвращает начало периода с номером n и подходящие дни в нём.
+// Даты представлены полночью UTC, чтобы арифметика дней не зависела от поясов.
+func (r Rule) period(dtstart time.Time, n int) (time.Time, []time.Time) {
+	y, m, d := dtstart.Date()
+	first := civil(y, m, d)
+	step := n * r.Interval
+
+	var start time.Time
+	var days []time.Time
+	switch r.Freq {
+	case Daily:
+		start = first.AddDate(0, 0, step)
+		days = r.filter([]time.Time{start}, start, start)
+	case Weekly:
+		offset := (int(first.Weekday()) - int(r.WeekStart) + 7) % 7
+		start = first.AddDate(0, 0, 7*step-offset)
+		candidates := span(start, start.AddDate(0, 0, 7))
+		if len(r.ByDay) == 0 {
+			candidates = sameWeekday(candidates, first.Weekday())
+		}
+		days = r.filter(candidates, start, start)
+	case Monthly:
+		start = civil(y, m+time.Month(step), 1)
+		days = r.filter(span(start, start.AddDate(0, 1, 0)), start, first)
+	case Yearly:
+		start = civil(y+step, time.January, 1)
+		days = r.filter(span(start, start.AddDate(1, 0, 0)), start, first)
+	}
+	return start, setPositions(days, r.BySetPos)
+}
+
+// filter оставляет дни периода, подходящие под BY-части. Если у MONTHLY или
+// YEARLY не задано ни BYMONTHDAY, ни BYDAY, день берётся из dtstart.
+func (r Rule) filter(days []time.Time, periodStart, first time.Time) []time.Time {
+	out := days[:0:0]
+	for _, day := range days {
+		if len(r.ByMonth) > 0 && !contains(r.ByMonth, day.Month()) {
+			continue
+		}
+		if len(r.ByMonthDay) > 0 && !r.matchMonthDay(day) {
+			continue
+		}
+		if len(r.ByDay) > 0 && !r.matchWeekday(day, periodStart) {
+			continue
+		}
+		if (r.Freq == Monthly || r.Freq == Yearly) && len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 {
+			if day.Day() != first.Day() {
+				continue
+			}
+			if r.Freq == Yearly && len(r.ByMonth) == 0 && day.Month() != first.Month() {
+				continue
+			}
+		}
+		out = append(out, day)
+	}
+	return out
+}
+
+func (r Rule) matchMonthDay(day time.Time) bool {
+	last := daysIn(day.Year(), day.Month())
+	for _, md := range r.ByMonthDay {
+		if md == day.Day() || (md < 0 && last+md+1 == day.Day()) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchWeekday проверяет BYDAY. Номер дня считается внутри месяца, если правило
+// месячное или задан BYMONTH, и внутри года для YEARLY без BYMONTH.
+func (r Rule) matchWeekday(day, periodStart time.Time) bool {
+	scopeStart := periodStart
+	var scopeEnd time.Time
+	if r.Freq == Monthly || (r.Freq == Yearly && len(r.ByMonth) > 0) {
+		scopeStart = civil(day.Year(), day.Month(), 1)
+		scopeEnd = scopeStart.AddDate(0, 1, 0)
+	} else {
+		scopeEnd = scopeStart.AddDate(1, 0, 0)
+	}
+	for _, w := range r.ByDay {
+		if w.Day != day.Weekday() {
+			continue
+		}
+		if w.N == 0 {
+			return true
+		}
+		if w.N > 0 && int(day.Sub(scopeStart).Hours()/24)/7+1 == w.N {
+			return true
+		}
+		if w.N < 0 && int(scopeEnd.Sub(day).Hours()/24-1)/7+1 == -w.N {
+			return true
+		}
+	}
+	return false
+}
+
+// setPositions применяет BYSETPOS к упорядоченному набору дней периода.
+func setPositions(days []time.Time, positions []int) []time.Time {
+	if len(positions) == 0 || len(days) == 0 {
+		return days
+	}
+	picked := make(map[int]bool, len(positions))
+	for _, pos := range positions {
+		i := pos - 1
+		if pos < 0 {
+			i = len(days) + pos
+		}
+		if i >= 0 && i < len(days) {
+			picked[i] = true
+		}
+	}
+	idx := make([]int, 0, len(picked))
+	for i := range picked {
+		idx = append(idx, i)
+	}
+	sort.Ints(idx)
+	out := make([]time.Time, len(idx))
+	for j, i := range idx {
+		out[j] = days[i]
+	}
+	return out
+}
+
+func civil(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func daysIn(y int, m time.Month) int {
+	return civil(y, m+1, 0).Day()
+}
+
+// span возвращает дни полуинтервала [from, to).
+func span(from, to time.Time) []time.Time {
+	var out []time.Time
+	for d := from; d.Before(to); d = d.AddDate(0, 0, 1) {
+		out = append(out, d)
+	}
+	return out
+}
+
+func sameWeekday(days []time.Time, wd time.Weekday) []time.Time {
+	out := days[:0:0]
+	for _, d := range days {
+		if d.Weekday() == wd {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+func contains[T comparable](items []T, v T) bool {
+	for _, item := range items {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,291 @@
+// Package rrule разбирает и разворачивает правила повторения RFC 5545 (RRULE).
+//
+// Поддерживаются частоты DAILY, WEEKLY, MONTHLY и YEARLY и части INTERVAL,
+// COUNT, UNTIL, BYDAY, BYMONTHDAY, BYMONTH, BYSETPOS и WKST. Частоты с шагом
+// меньше дня и прочие BY-части не поддерживаются.
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalid возвращается для правил, которые не удалось разобрать.
+var ErrInvalid = errors.New("invalid rrule")
+
+// Frequency задаёт базовый период повторения.
+type Frequency int
+
+// Поддерживаемые частоты.
+const (
+	Daily Frequency = iota + 1
+	Weekly
+	Monthly
+	Yearly
+)
+
+var frequencyNames = map[Frequency]string{
+	Daily:   "DAILY",
+	Weekly:  "WEEKLY",
+	Monthly: "MONTHLY",
+	Yearly:  "YEARLY",
+}
+
+func (f Frequency) String() string {
+	return frequencyNames[f]
+}
+
+// Weekday — элемент BYDAY: день недели и, для MONTHLY и YEARLY, его номер
+// в периоде. N = 0 означает каждый такой день, отрицательные номера
+// отсчитываются с конца периода.
+type Weekday struct {
+	N   int
+	Day time.Weekday
+}
+
+var weekdayNames = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+func (w Weekday) String() string {
+	if w.N == 0 {
+		return weekdayNames[w.Day]
+	}
+	return strconv.Itoa(w.N) + weekdayNames[w.Day]
+}
+
+// Rule — разобранное правило повторения.
+type Rule struct {
+	Freq     Frequency
+	Interval int
+	// Count ограничивает число повторений, считая первое; 0 — без ограничения.
+	Count int
+	// Until — последний допустимый момент повторения; нулевое значение — без ограничения.
+	Until time.Time
+	// UntilDate означает, что UNTIL задан датой: допустим весь её день.
+	UntilDate  bool
+	ByDay      []Weekday
+	ByMonthDay []int
+	ByMonth    []time.Month
+	BySetPos   []int
+	// WeekStart — первый день недели для WEEKLY с INTERVAL больше 1.
+	WeekStart time.Weekday
+}
+
+// Parse разбирает значение RRULE, например "FREQ=MONTHLY;BYDAY=-1FR".
+// Префикс "RRULE:" допускается.
+func Parse(s string) (Rule, error) {
+	s = strings.TrimSpace(s)
+	if len(s) >= 6 && strings.EqualFold(s[:6], "RRULE:") {
+		s = s[6:]
+	}
+	r := Rule{Interval: 1, WeekStart: time.Monday}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return Rule{}, fmt.Errorf("%w: malformed part %q", ErrInvalid, part)
+		}
+		key = strings.ToUpper(key)
+		value = strings.ToUpper(value)
+		if seen[key] {
+			return Rule{}, fmt.Errorf("%w: duplicate %s", ErrInvalid, key)
+		}
+		seen[key] = true
+		if err := r.set(key, value); err != nil {
+			return Rule{}, err
+		}
+	}
+	if err := r.validate(); err != nil {
+		return Rule{}, err
+	}
+	return r, nil
+}
+
+func (r *Rule) set(key, value string) error {
+	var err error
+	switch key {
+	case "FREQ":
+		r.Freq, err = parseFrequency(value)
+	case "INTERVAL":
+		r.Interval, err = parseInt(key, value, 1, 1<<16)
+	case "COUNT":
+		r.Count, err = parseInt(key, value, 1, 1<<20)
+	case "UNTIL":
+		r.Until, r.UntilDate, err = parseUntil(value)
+	case "BYDAY":
+		r.ByDay, err = parseList(value, parseWeekday)
+	case "BYMONTHDAY":
+		r.ByMonthDay, err = parseList(value, func(v string) (int, error) {
+			return parseNonZero(key, v, 31)
+		})
+	case "BYMONTH":
+		r.ByMonth, err = parseList(value, func(v string) (time.Month, error) {
+			m, err := parseInt(key, v, 1, 12)
+			return time.Month(m), err
+		})
+	case "BYSETPOS":
+		r.BySetPos, err = parseList(value, func(v string) (int, error) {
+			return parseNonZero(key, v, 366)
+		})
+	case "WKST":
+		var w Weekday
+		if w, err = parseWeekday(value); err == nil && w.N != 0 {
+			err = fmt.Errorf("%w: WKST must be a plain weekday", ErrInvalid)
+		}
+		r.WeekStart = w.Day
+	default:
+		return fmt.Errorf("%w: unsupported part %s", ErrInvalid, key)
+	}
+	return err
+}
+
+func (r *Rule) validate() error {
+	if r.Freq == 0 {
+		return fmt.Errorf("%w: FREQ is required", ErrInvalid)
+	}
+	if r.Count > 0 && !r.Until.IsZero() {
+		return fmt.Errorf("%w: COUNT and UNTIL are mutually exclusive", ErrInvalid)
+	}
+	if r.Freq == Weekly && len(r.ByMonthDay) > 0 {
+		return fmt.Errorf("%w: BYMONTHDAY is not allowed with FREQ=WEEKLY", ErrInvalid)
+	}
+	for _, w := range r.ByDay {
+		if w.N != 0 && r.Freq != Monthly && r.Freq != Yearly {
+			return fmt.Errorf("%w: numbered BYDAY requires FREQ=MONTHLY or FREQ=YEARLY", ErrInvalid)
+		}
+		if w.N != 0 && r.Freq == Monthly && (w.N > 5 || w.N < -5) {
+			return fmt.Errorf("%w: BYDAY %s is out of range for a month", ErrInvalid, w)
+		}
+	}
+	if len(r.BySetPos) > 0 && len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 && len(r.ByMonth) == 0 {
+		return fmt.Errorf("%w: BYSETPOS requires another BY part", ErrInvalid)
+	}
+	return nil
+}
+
+// String возвращает правило в каноническом виде: части в фиксированном порядке,
+// значения по умолчанию опущены.
+func (r Rule) String() string {
+	parts := []string{"FREQ=" + r.Freq.String()}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		if r.UntilDate {
+			parts = append(parts, "UNTIL="+r.Until.Format(dateLayout))
+		} else {
+			parts = append(parts, "UNTIL="+r.Until.UTC().Format(dateTimeLayout))
+		}
+	}
+	if len(r.ByMonth) > 0 {
+		parts = append(parts, "BYMONTH="+joinList(r.ByMonth, func(m time.Month) string { return strconv.Itoa(int(m)) }))
+	}
+	if len(r.ByMonthDay) > 0 {
+		parts = append(parts, "BYMONTHDAY="+joinList(r.ByMonthDay, strconv.Itoa))
+	}
+	if len(r.ByDay) > 0 {
+		parts = append(parts, "BYDAY="+joinList(r.ByDay, Weekday.String))
+	}
+	if len(r.BySetPos) > 0 {
+		parts = append(parts, "BYSETPOS="+joinList(r.BySetPos, strconv.Itoa))
+	}
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+weekdayNames[r.WeekStart])
+	}
+	return strings.Join(parts, ";")
+}
+
+const (
+	dateLayout     = "20060102"
+	dateTimeLayout = "20060102T150405Z"
+)
+
+func parseFrequency(v string) (Frequency, error) {
+	for f, name := range frequencyNames {
+		if name == v {
+			return f, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: unsupported FREQ %s", ErrInvalid, v)
+}
+
+func parseInt(key, v string, min, max int) (int, error) {
+	n, err := strconv.Atoi(v)
+	if err != nil || n < min || n > max {
+		return 0, fmt.Errorf("%w: %s must be between %d and %d", ErrInvalid, key, min, max)
+	}
+	return n, nil
+}
+
+func parseNonZero(key, v string, max int) (int, error) {
+	n, err := strconv.Atoi(v)
+	if err != nil || n == 0 || n > max || n < -max {
+		return 0, fmt.Errorf("%w: %s must be between 1 and %d or -%d and -1", ErrInvalid, key, max, max)
+	}
+	return n, nil
+}
+
+// parseUntil принимает дату (20261231) или момент в UTC (20261231T235959Z).
+// Плавающее время без Z трактуется как UTC.
+func parseUntil(v string) (time.Time, bool, error) {
+	if t, err := time.Parse(dateLayout, v); err == nil {
+		return t, true, nil
+	}
+	if t, err := time.Parse(dateTimeLayout, v); err == nil {
+		return t, false, nil
+	}
+	if t, err := time.Parse(strings.TrimSuffix(dateTimeLayout, "Z"), v); err == nil {
+		return t, false, nil
+	}
+	return time.Time{}, false, fmt.Errorf("%w: malformed UNTIL %s", ErrInvalid, v)
+}
+
+func parseWeekday(v string) (Weekday, error) {
+	if len(v) < 2 {
+		return Weekday{}, fmt.Errorf("%w: malformed weekday %q", ErrInvalid, v)
+	}
+	num, name := v[:len(v)-2], v[len(v)-2:]
+	w := Weekday{Day: -1}
+	for i, n := range weekdayNames {
+		if n == name {
+			w.Day = time.Weekday(i)
+		}
+	}
+	if w.Day < 0 {
+		return Weekday{}, fmt.Errorf("%w: unknown weekday %q", ErrInvalid, name)
+	}
+	if num != "" {
+		n, err := parseNonZero("BYDAY", strings.TrimPrefix(num, "+"), 53)
+		if err != nil {
+			return Weekday{}, err
+		}
+		w.N = n
+	}
+	return w, nil
+}
+
+func parseList[T any](v string, parse func(string) (T, error)) ([]T, error) {
+	items := strings.Split(v, ",")
+	out := make([]T, 0, len(items))
+	for _, item := range items {
+		x, err := parse(item)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, x)
+	}
+	return out, nil
+}
+
+func joinList[T any](items []T, format func(T) string) string {
+	out := make([]string, len(items))
+	for i, item := range items {
+		out[i] = format(item)
+	}
+	return strings.Join(out, ",")
+}
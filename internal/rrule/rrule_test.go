@@ -0,0 +1,167 @@
+package rrule
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseCanonical(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"FREQ=DAILY", "FREQ=DAILY"},
+		{"RRULE:freq=weekly;byday=MO,WE;interval=2", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE"},
+		{"FREQ=MONTHLY;BYDAY=-1FR;COUNT=3", "FREQ=MONTHLY;COUNT=3;BYDAY=-1FR"},
+		{"FREQ=YEARLY;UNTIL=20301231;BYMONTH=2;BYMONTHDAY=-1", "FREQ=YEARLY;UNTIL=20301231;BYMONTH=2;BYMONTHDAY=-1"},
+		{"FREQ=WEEKLY;INTERVAL=1;WKST=SU;UNTIL=20300101T120000Z", "FREQ=WEEKLY;UNTIL=20300101T120000Z;WKST=SU"},
+	}
+	for _, tt := range tests {
+		r, err := Parse(tt.in)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.in, err)
+		}
+		if got := r.String(); got != tt.want {
+			t.Errorf("Parse(%q).String() = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, in := range []string{
+		"",
+		"INTERVAL=2",
+		"FREQ=HOURLY",
+		"FREQ=DAILY;COUNT=0",
+		"FREQ=DAILY;COUNT=2;UNTIL=20300101",
+		"FREQ=DAILY;BYDAY=1MO",
+		"FREQ=WEEKLY;BYMONTHDAY=1",
+		"FREQ=MONTHLY;BYDAY=6MO",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=DAILY;FREQ=WEEKLY",
+		"FREQ=DAILY;BYHOUR=9",
+		"FREQ=DAILY;BYSETPOS=1",
+	} {
+		if _, err := Parse(in); !errors.Is(err, ErrInvalid) {
+			t.Errorf("Parse(%q) error = %v, want ErrInvalid", in, err)
+		}
+	}
+}
+
+func TestAll(t *testing.T) {
+	day := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 9, 30, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		rule    string
+		dtstart time.Time
+		want    []time.Time
+	}{
+		{
+			rule:    "FREQ=DAILY;INTERVAL=2;COUNT=3",
+			dtstart: day(2026, time.March, 30),
+			want:    []time.Time{day(2026, time.March, 30), day(2026, time.April, 1), day(2026, time.April, 3)},
+		},
+		{
+			rule:    "FREQ=WEEKLY;BYDAY=TU,TH",
+			dtstart: day(2026, time.October, 1), // четверг
+			want:    []time.Time{day(2026, time.October, 1), day(2026, time.October, 6), day(2026, time.October, 8), day(2026, time.October, 13)},
+		},
+		{
+			rule:    "FREQ=WEEKLY;INTERVAL=2;UNTIL=20261101",
+			dtstart: day(2026, time.October, 5),
+			want:    []time.Time{day(2026, time.October, 5), day(2026, time.October, 19)},
+		},
+		{
+			rule:    "FREQ=MONTHLY;BYDAY=2TU",
+			dtstart: day(2026, time.January, 13),
+			want:    []time.Time{day(2026, time.January, 13), day(2026, time.February, 10), day(2026, time.March, 10)},
+		},
+		{
+			rule:    "FREQ=MONTHLY;BYDAY=-1FR",
+			dtstart: day(2026, time.January, 30),
+			want:    []time.Time{day(2026, time.January, 30), day(2026, time.February, 27), day(2026, time.March, 27)},
+		},
+		{
+			rule:    "FREQ=MONTHLY",
+			dtstart: day(2026, time.January, 31),
+			want:    []time.Time{day(2026, time.January, 31), day(2026, time.March, 31), day(2026, time.May, 31)},
+		},
+		{
+			rule:    "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1",
+			dtstart: day(2026, time.January, 30),
+			want:    []time.Time{day(2026, time.January, 30), day(2026, time.February, 27), day(2026, time.March, 31)},
+		},
+		{
+			rule:    "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=29",
+			dtstart: day(2024, time.February, 29),
+			want:    []time.Time{day(2024, time.February, 29), day(2028, time.February, 29)},
+		},
+		{
+			rule:    "FREQ=YEARLY;BYDAY=1MO",
+			dtstart: day(2026, time.January, 5),
+			want:    []time.Time{day(2026, time.January, 5), day(2027, time.January, 4)},
+		},
+	}
+	for _, tt := range tests {
+		r, err := Parse(tt.rule)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.rule, err)
+		}
+		got := r.All(tt.dtstart, len(tt.want)+1)
+		if r.Count == 0 && r.Until.IsZero() {
+			got = got[:len(tt.want)]
+		}
+		if len(got) != len(tt.want) {
+			t.Fatalf("%s: got %v, want %v", tt.rule, got, tt.want)
+		}
+		for i := range got {
+			if !got[i].Equal(tt.want[i]) {
+				t.Errorf("%s: occurrence %d = %v, want %v", tt.rule, i, got[i], tt.want[i])
+			}
+		}
+	}
+}
+
+func TestAfterKeepsWallClockAcrossDST(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("load location: %v", err)
+	}
+	r, err := Parse("FREQ=DAILY")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dtstart := time.Date(2026, time.March, 27, 9, 0, 0, 0, loc)
+	next, ok := r.After(dtstart, time.Date(2026, time.March, 28, 9, 0, 0, 0, loc))
+	if !ok {
+		t.Fatal("expected another occurrence")
+	}
+	if want := time.Date(2026, time.March, 29, 9, 0, 0, 0, loc); !next.Equal(want) {
+		t.Errorf("After = %v, want %v", next, want)
+	}
+}
+
+func TestAfterExhausted(t *testing.T) {
+	r, err := Parse("FREQ=WEEKLY;COUNT=2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dtstart := time.Date(2026, time.October, 5, 9, 0, 0, 0, time.UTC)
+	if _, ok := r.After(dtstart, dtstart.AddDate(0, 0, 7)); ok {
+		t.Error("expected the series to be exhausted after COUNT occurrences")
+	}
+	if _, ok := mustParse(t, "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30").After(dtstart, dtstart); ok {
+		t.Error("expected no occurrences for an impossible date")
+	}
+}
+
+func mustParse(t *testing.T, s string) Rule {
+	t.Helper()
+	r, err := Parse(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
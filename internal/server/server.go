@@ -19,6 +19,9 @@ import (
 // Config описывает параметры запуска gRPC-сервера.
 type Config struct {
 	Addr string
+	// UnaryInterceptors и StreamInterceptors выполняются по порядку перед каждым вызовом.
+	UnaryInterceptors  []grpc.UnaryServerInterceptor
+	StreamInterceptors []grpc.StreamServerInterceptor
 }
 
 // RegisterFunc регистрирует gRPC-сервисы на сервере.
@@ -30,7 +33,10 @@ func Run(ctx context.Context, cfg Config, register RegisterFunc) error {
 		return fmt.Errorf("register function is required")
 	}
 
-	srv := grpc.NewServer()
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(cfg.UnaryInterceptors...),
+		grpc.ChainStreamInterceptor(cfg.StreamInterceptors...),
+	)
 	register(srv)
 	healthSrv := health.NewServer()
 	healthSrv.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
@@ -0,0 +1,144 @@
+// Package apikey содержит бизнес-логику управления ключами API.
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	apikeyrepo "todo/internal/apikey"
+	"todo/internal/auth"
+)
+
+var (
+	// ErrValidation сигнализирует о нарушениях входных данных.
+	ErrValidation = errors.New("validation error")
+	// ErrPermissionDenied возвращается, если ключами управляют с помощью ключа API.
+	ErrPermissionDenied = errors.New("permission denied")
+)
+
+const (
+	// secretPrefix отличает ключи API от других токенов, например в логах
+	// сканеров утечек.
+	secretPrefix = "tdk_"
+	// secretBytes — энтропия секрета.
+	secretBytes = 32
+	// displayPrefixLength — сколько первых символов секрета хранится открыто.
+	displayPrefixLength = len(secretPrefix) + 8
+	// maxNameLength ограничивает длину названия ключа в символах.
+	maxNameLength = 100
+)
+
+// Service инкапсулирует операции над ключами API на уровне бизнес-логики.
+type Service struct {
+	repo *apikeyrepo.Repository
+}
+
+// NewService создает сервис ключей API.
+func NewService(repo *apikeyrepo.Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// Create создаёт ключ вызывающего и возвращает его вместе с секретом.
+// Секрет не сохраняется, поэтому получить его повторно нельзя.
+func (s *Service) Create(ctx context.Context, name string, scope apikeyrepo.Scope, expiresAt *time.Time) (apikeyrepo.Record, string, error) {
+	if err := forbidAPIKeys(ctx); err != nil {
+		return apikeyrepo.Record{}, "", err
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return apikeyrepo.Record{}, "", fmt.Errorf("%w: name is required", ErrValidation)
+	}
+	if utf8.RuneCountInString(name) > maxNameLength {
+		return apikeyrepo.Record{}, "", fmt.Errorf("%w: name is longer than %d characters", ErrValidation, maxNameLength)
+	}
+	if !scope.Valid() {
+		return apikeyrepo.Record{}, "", fmt.Errorf("%w: scope is required", ErrValidation)
+	}
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return apikeyrepo.Record{}, "", fmt.Errorf("%w: expires_at must be in the future", ErrValidation)
+	}
+
+	raw := make([]byte, secretBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return apikeyrepo.Record{}, "", fmt.Errorf("generate secret: %w", err)
+	}
+	secret := secretPrefix + base64.RawURLEncoding.EncodeToString(raw)
+	rec, err := s.repo.Create(ctx, apikeyrepo.CreateParams{
+		Name:       name,
+		Prefix:     secret[:displayPrefixLength],
+		SecretHash: hashSecret(secret),
+		Scope:      scope,
+		ExpiresAt:  expiresAt,
+	})
+	if err != nil {
+		return apikeyrepo.Record{}, "", err
+	}
+	return rec, secret, nil
+}
+
+// List возвращает ключи вызывающего.
+func (s *Service) List(ctx context.Context) ([]apikeyrepo.Record, error) {
+	if err := forbidAPIKeys(ctx); err != nil {
+		return nil, err
+	}
+	return s.repo.List(ctx)
+}
+
+// Revoke отзывает ключ вызывающего.
+func (s *Service) Revoke(ctx context.Context, id string) (apikeyrepo.Record, error) {
+	if err := forbidAPIKeys(ctx); err != nil {
+		return apikeyrepo.Record{}, err
+	}
+	if id == "" {
+		return apikeyrepo.Record{}, fmt.Errorf("%w: id is required", ErrValidation)
+	}
+	return s.repo.Revoke(ctx, id)
+}
+
+// VerifyAPIKey находит владельца действующего ключа; реализует auth.APIKeyVerifier.
+func (s *Service) VerifyAPIKey(ctx context.Context, secret string) (auth.Principal, error) {
+	if !strings.HasPrefix(secret, secretPrefix) {
+		return auth.Principal{}, fmt.Errorf("%w: malformed api key", auth.ErrUnauthenticated)
+	}
+	rec, err := s.repo.Use(ctx, hashSecret(secret))
+	if err != nil {
+		if errors.Is(err, apikeyrepo.ErrNotFound) {
+			return auth.Principal{}, fmt.Errorf("%w: unknown, revoked or expired api key", auth.ErrUnauthenticated)
+		}
+		return auth.Principal{}, err
+	}
+	return auth.Principal{
+		Subject:  rec.OwnerID,
+		TenantID: rec.TenantID,
+		ReadOnly: rec.Scope == apikeyrepo.ScopeReadOnly,
+		APIKeyID: rec.ID,
+	}, nil
+}
+
+// forbidAPIKeys не даёт ключу API создавать и отзывать ключи: утечка
+// одного ключа не должна позволять выпустить новые.
+func forbidAPIKeys(ctx context.Context) error {
+	principal, err := auth.Require(ctx)
+	if err != nil {
+		return err
+	}
+	if principal.APIKeyID != "" {
+		return fmt.Errorf("%w: api keys cannot manage api keys", ErrPermissionDenied)
+	}
+	return nil
+}
+
+// hashSecret возвращает SHA-256 секрета в hex. Секрет случаен и длинен,
+// поэтому медленное хеширование паролей здесь не нужно.
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
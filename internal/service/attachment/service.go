@@ -0,0 +1,213 @@
+// Package attachment содержит бизнес-логику работы с вложениями задач.
+package attachment
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"todo/internal/access"
+	attachmentrepo "todo/internal/attachment"
+	"todo/internal/blob"
+)
+
+// ErrValidation сигнализирует о нарушениях входных данных.
+var ErrValidation = errors.New("validation error")
+
+const (
+	// maxFilenameLength ограничивает длину имени файла в символах.
+	maxFilenameLength = 255
+	// sniffLength — сколько байт нужно для определения типа содержимого.
+	sniffLength = 512
+	// orphanGrace защищает от удаления содержимое, загрузка которого ещё
+	// не успела сослаться на него из метаданных.
+	orphanGrace = time.Hour
+)
+
+// Config задаёт ограничения на вложения.
+type Config struct {
+	// MaxSize — максимальный размер вложения в байтах.
+	MaxSize int64
+	// AllowedTypes перечисляет допустимые MIME-типы; "image/*" разрешает все подтипы.
+	AllowedTypes []string
+}
+
+// Service инкапсулирует операции над вложениями на уровне бизнес-логики.
+type Service struct {
+	repo   *attachmentrepo.Repository
+	access *access.Repository
+	store  blob.Store
+	cfg    Config
+}
+
+// NewService создает сервис вложений.
+func NewService(repo *attachmentrepo.Repository, access *access.Repository, store blob.Store, cfg Config) *Service {
+	return &Service{repo: repo, access: access, store: store, cfg: cfg}
+}
+
+// Upload сохраняет содержимое r как вложение задачи. Тип содержимого
+// определяется по первым байтам, а не по имени файла.
+func (s *Service) Upload(ctx context.Context, todoID, filename string, r io.Reader) (attachmentrepo.Record, error) {
+	if todoID == "" {
+		return attachmentrepo.Record{}, fmt.Errorf("%w: todo_id is required", ErrValidation)
+	}
+	filename, err := cleanFilename(filename)
+	if err != nil {
+		return attachmentrepo.Record{}, err
+	}
+	// Права проверяются до приёма содержимого, чтобы не сохранять его зря.
+	if err := s.access.RequireTodo(ctx, todoID, access.RoleEditor); err != nil {
+		return attachmentrepo.Record{}, err
+	}
+
+	br := bufio.NewReaderSize(r, sniffLength)
+	head, err := br.Peek(sniffLength)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return attachmentrepo.Record{}, err
+	}
+	if len(head) == 0 {
+		return attachmentrepo.Record{}, fmt.Errorf("%w: attachment is empty", ErrValidation)
+	}
+	contentType := http.DetectContentType(head)
+	if !s.allowed(contentType) {
+		return attachmentrepo.Record{}, fmt.Errorf("%w: content type %s is not allowed", ErrValidation, contentType)
+	}
+
+	// Blob учитывается раньше, чем содержимое окажется на месте, чтобы
+	// очистка сирот не удалила его между сохранением и созданием вложения.
+	info, err := s.store.Put(ctx, &limitedReader{r: br, left: s.cfg.MaxSize}, func(reserved blob.Info) error {
+		return s.repo.UseBlob(ctx, reserved.Hash, reserved.Size)
+	})
+	if err != nil {
+		if errors.Is(err, errTooLarge) {
+			return attachmentrepo.Record{}, fmt.Errorf("%w: attachment exceeds %d bytes", ErrValidation, s.cfg.MaxSize)
+		}
+		return attachmentrepo.Record{}, err
+	}
+	return s.repo.Create(ctx, attachmentrepo.CreateParams{
+		TodoID:      todoID,
+		Filename:    filename,
+		ContentType: contentType,
+		Size:        info.Size,
+		SHA256:      info.Hash,
+	})
+}
+
+// Open возвращает метаданные вложения и его содержимое. Вызывающий
+// обязан закрыть содержимое.
+func (s *Service) Open(ctx context.Context, id string) (attachmentrepo.Record, io.ReadCloser, error) {
+	if id == "" {
+		return attachmentrepo.Record{}, nil, fmt.Errorf("%w: id is required", ErrValidation)
+	}
+	rec, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return attachmentrepo.Record{}, nil, err
+	}
+	rc, err := s.store.Open(ctx, rec.SHA256)
+	if err != nil {
+		return attachmentrepo.Record{}, nil, fmt.Errorf("open blob %s: %w", rec.SHA256, err)
+	}
+	return rec, rc, nil
+}
+
+// List возвращает вложения задачи.
+func (s *Service) List(ctx context.Context, todoID string) ([]attachmentrepo.Record, error) {
+	if todoID == "" {
+		return nil, fmt.Errorf("%w: todo_id is required", ErrValidation)
+	}
+	return s.repo.List(ctx, todoID)
+}
+
+// Delete удаляет вложение.
+func (s *Service) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return fmt.Errorf("%w: id is required", ErrValidation)
+	}
+	rec, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.access.RequireTodo(ctx, rec.TodoID, access.RoleEditor); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// RemoveOrphans удаляет из хранилища содержимое, на которое больше не
+// ссылается ни одно вложение, например после окончательного удаления задач.
+func (s *Service) RemoveOrphans(ctx context.Context) error {
+	n, err := s.repo.DeleteOrphanBlobs(ctx, time.Now().Add(-orphanGrace), func(hash string) {
+		if err := s.store.Delete(ctx, hash); err != nil {
+			// Учёт удаляется, поэтому повторной попытки не будет: только сообщаем.
+			log.Printf("delete orphan blob %s: %v", hash, err)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		log.Printf("removed %d orphan blobs", n)
+	}
+	return nil
+}
+
+func (s *Service) allowed(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, allowed := range s.cfg.AllowedTypes {
+		if allowed == mediaType {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(allowed, "/*"); ok && strings.HasPrefix(mediaType, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// cleanFilename оставляет от имени файла только последний элемент пути.
+func cleanFilename(name string) (string, error) {
+	name = strings.TrimSpace(path.Base(strings.ReplaceAll(name, `\`, "/")))
+	if name == "" || name == "." || name == "/" || name == ".." {
+		return "", fmt.Errorf("%w: filename is required", ErrValidation)
+	}
+	if utf8.RuneCountInString(name) > maxFilenameLength {
+		return "", fmt.Errorf("%w: filename is longer than %d characters", ErrValidation, maxFilenameLength)
+	}
+	return name, nil
+}
+
+var errTooLarge = errors.New("attachment too large")
+
+// limitedReader, в отличие от io.LimitReader, сообщает о превышении лимита
+// ошибкой, чтобы хранилище не сохранило обрезанное содержимое.
+type limitedReader struct {
+	r    io.Reader
+	left int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.left < 0 {
+		return 0, errTooLarge
+	}
+	if int64(len(p)) > l.left+1 {
+		p = p[:l.left+1]
+	}
+	n, err := l.r.Read(p)
+	l.left -= int64(n)
+	if l.left < 0 {
+		return n, errTooLarge
+	}
+	return n, err
+}
@@ -0,0 +1,161 @@
+// Package comment содержит бизнес-логику работы с комментариями к задачам.
+package comment
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"todo/internal/access"
+	commentrepo "todo/internal/comment"
+)
+
+// ErrValidation сигнализирует о нарушениях входных данных.
+var ErrValidation = errors.New("validation error")
+
+const (
+	// DefaultPageSize используется, если клиент не указал размер страницы.
+	DefaultPageSize = 50
+	// MaxPageSize ограничивает размер страницы сверху.
+	MaxPageSize = 500
+	// maxBodyLength ограничивает длину комментария в символах.
+	maxBodyLength = 10000
+)
+
+// Service инкапсулирует операции над комментариями на уровне бизнес-логики.
+type Service struct {
+	repo   *commentrepo.Repository
+	access *access.Repository
+}
+
+// NewService создает сервис комментариев.
+func NewService(repo *commentrepo.Repository, access *access.Repository) *Service {
+	return &Service{repo: repo, access: access}
+}
+
+// Add добавляет комментарий к задаче; для этого достаточно её видеть.
+func (s *Service) Add(ctx context.Context, todoID, author, body string) (commentrepo.Record, error) {
+	if todoID == "" {
+		return commentrepo.Record{}, fmt.Errorf("%w: todo_id is required", ErrValidation)
+	}
+	author = strings.TrimSpace(author)
+	if author == "" {
+		return commentrepo.Record{}, fmt.Errorf("%w: author is required", ErrValidation)
+	}
+	if err := validateBody(body); err != nil {
+		return commentrepo.Record{}, err
+	}
+	return s.repo.Create(ctx, todoID, author, body)
+}
+
+// ListResult содержит страницу комментариев и курсор следующей страницы.
+type ListResult struct {
+	Items         []commentrepo.Record
+	NextPageToken string
+}
+
+// List возвращает страницу комментариев задачи от старых к новым.
+func (s *Service) List(ctx context.Context, todoID string, pageSize int, pageToken string) (ListResult, error) {
+	if todoID == "" {
+		return ListResult{}, fmt.Errorf("%w: todo_id is required", ErrValidation)
+	}
+	switch {
+	case pageSize < 0:
+		return ListResult{}, fmt.Errorf("%w: page_size must not be negative", ErrValidation)
+	case pageSize == 0:
+		pageSize = DefaultPageSize
+	case pageSize > MaxPageSize:
+		pageSize = MaxPageSize
+	}
+	after, err := decodePageToken(pageToken)
+	if err != nil {
+		return ListResult{}, err
+	}
+
+	recs, err := s.repo.List(ctx, todoID, pageSize+1, after)
+	if err != nil {
+		return ListResult{}, err
+	}
+	res := ListResult{Items: recs}
+	if len(recs) > pageSize {
+		res.Items = recs[:pageSize]
+		last := res.Items[pageSize-1]
+		res.NextPageToken, err = encodePageToken(commentrepo.Cursor{CreatedAt: last.CreatedAt, ID: last.ID})
+		if err != nil {
+			return ListResult{}, err
+		}
+	}
+	return res, nil
+}
+
+// Edit заменяет текст комментария.
+func (s *Service) Edit(ctx context.Context, id, body string) (commentrepo.Record, error) {
+	if id == "" {
+		return commentrepo.Record{}, fmt.Errorf("%w: id is required", ErrValidation)
+	}
+	if err := validateBody(body); err != nil {
+		return commentrepo.Record{}, err
+	}
+	if err := s.requireEditor(ctx, id); err != nil {
+		return commentrepo.Record{}, err
+	}
+	return s.repo.Update(ctx, id, body)
+}
+
+// Delete удаляет комментарий.
+func (s *Service) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return fmt.Errorf("%w: id is required", ErrValidation)
+	}
+	if err := s.requireEditor(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// requireEditor проверяет, что вызывающий может изменять задачу комментария.
+func (s *Service) requireEditor(ctx context.Context, id string) error {
+	rec, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.access.RequireTodo(ctx, rec.TodoID, access.RoleEditor)
+}
+
+func validateBody(body string) error {
+	if strings.TrimSpace(body) == "" {
+		return fmt.Errorf("%w: body is required", ErrValidation)
+	}
+	if utf8.RuneCountInString(body) > maxBodyLength {
+		return fmt.Errorf("%w: body is longer than %d characters", ErrValidation, maxBodyLength)
+	}
+	return nil
+}
+
+func encodePageToken(cursor commentrepo.Cursor) (string, error) {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", fmt.Errorf("encode page token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodePageToken разбирает курсор; пустая строка означает первую страницу.
+func decodePageToken(token string) (*commentrepo.Cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed page_token", ErrValidation)
+	}
+	var c commentrepo.Cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID == "" {
+		return nil, fmt.Errorf("%w: malformed page_token", ErrValidation)
+	}
+	return &c, nil
+}
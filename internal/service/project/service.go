@@ -0,0 +1,95 @@
+// Package project содержит бизнес-логику работы с проектами.
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"todo/internal/access"
+	projectrepo "todo/internal/project"
+)
+
+// ErrValidation сигнализирует о нарушениях входных данных.
+var ErrValidation = errors.New("validation error")
+
+// Поля проекта, которые можно указать в маске обновления.
+const (
+	FieldName        = "name"
+	FieldDescription = "description"
+)
+
+// Service инкапсулирует операции над проектами на уровне бизнес-логики.
+type Service struct {
+	repo   *projectrepo.Repository
+	access *access.Repository
+}
+
+// NewService создает сервис проектов.
+func NewService(repo *projectrepo.Repository, access *access.Repository) *Service {
+	return &Service{repo: repo, access: access}
+}
+
+// Create создаёт новый проект.
+func (s *Service) Create(ctx context.Context, name, description string) (projectrepo.Record, error) {
+	if name == "" {
+		return projectrepo.Record{}, fmt.Errorf("%w: name is required", ErrValidation)
+	}
+	return s.repo.Create(ctx, name, description)
+}
+
+// Get возвращает проект по идентификатору.
+func (s *Service) Get(ctx context.Context, id string) (projectrepo.Record, error) {
+	if id == "" {
+		return projectrepo.Record{}, fmt.Errorf("%w: id is required", ErrValidation)
+	}
+	return s.repo.Get(ctx, id)
+}
+
+// List возвращает проекты, видимые вызывающему.
+func (s *Service) List(ctx context.Context) ([]projectrepo.Record, error) {
+	return s.repo.List(ctx)
+}
+
+// Update изменяет поля проекта, перечисленные в paths; пустой список означает все поля.
+func (s *Service) Update(ctx context.Context, id, name, description string, paths []string) (projectrepo.Record, error) {
+	if id == "" {
+		return projectrepo.Record{}, fmt.Errorf("%w: id is required", ErrValidation)
+	}
+	if len(paths) == 0 {
+		paths = []string{FieldName, FieldDescription}
+	}
+	var patch projectrepo.Patch
+	for _, path := range paths {
+		switch path {
+		case FieldName:
+			if name == "" {
+				return projectrepo.Record{}, fmt.Errorf("%w: name is required", ErrValidation)
+			}
+			patch.Name = &name
+		case FieldDescription:
+			patch.Description = &description
+		default:
+			return projectrepo.Record{}, fmt.Errorf("%w: unknown update_mask path %q", ErrValidation, path)
+		}
+	}
+	if err := s.access.RequireProject(ctx, id, access.RoleEditor); err != nil {
+		return projectrepo.Record{}, err
+	}
+	return s.repo.Update(ctx, id, patch)
+}
+
+// Delete удаляет проект, распоряжаясь его задачами согласно mode.
+// Удалить проект может только владелец.
+func (s *Service) Delete(ctx context.Context, id string, mode projectrepo.DeleteMode) error {
+	if id == "" {
+		return fmt.Errorf("%w: id is required", ErrValidation)
+	}
+	if mode != projectrepo.DeleteMoveToInbox && mode != projectrepo.DeleteCascade {
+		return fmt.Errorf("%w: unknown delete mode %d", ErrValidation, mode)
+	}
+	if err := s.access.RequireProject(ctx, id, access.RoleOwner); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id, mode)
+}
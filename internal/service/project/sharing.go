@@ -0,0 +1,72 @@
+package project
+
+import (
+	"context"
+	"fmt"
+
+	"todo/internal/access"
+	"todo/internal/auth"
+)
+
+// Share выдаёт пользователю userID роль на проект и тем самым на все его
+// задачи. Делиться проектом может только его владелец.
+func (s *Service) Share(ctx context.Context, id, userID string, role access.Role) (access.Grant, error) {
+	if err := validateGrant(id, userID); err != nil {
+		return access.Grant{}, err
+	}
+	if !role.Valid() {
+		return access.Grant{}, fmt.Errorf("%w: unknown role %d", ErrValidation, role)
+	}
+	if err := s.access.RequireProject(ctx, id, access.RoleOwner); err != nil {
+		return access.Grant{}, err
+	}
+	owner, err := s.access.ProjectOwner(ctx, id)
+	if err != nil {
+		return access.Grant{}, err
+	}
+	if owner == userID {
+		return access.Grant{}, fmt.Errorf("%w: user %s already owns the project", ErrValidation, userID)
+	}
+	return s.access.ShareProject(ctx, id, userID, role)
+}
+
+// Unshare отзывает роль пользователя userID на проект. Владелец может
+// отозвать любую роль, а пользователь — отказаться от своей.
+func (s *Service) Unshare(ctx context.Context, id, userID string) error {
+	if err := validateGrant(id, userID); err != nil {
+		return err
+	}
+	principal, err := auth.Require(ctx)
+	if err != nil {
+		return err
+	}
+	min := access.RoleOwner
+	if userID == principal.Subject {
+		min = access.RoleViewer
+	}
+	if err := s.access.RequireProject(ctx, id, min); err != nil {
+		return err
+	}
+	return s.access.UnshareProject(ctx, id, userID)
+}
+
+// Collaborators возвращает владельца проекта и пользователей с ролями на него.
+func (s *Service) Collaborators(ctx context.Context, id string) ([]access.Grant, error) {
+	if id == "" {
+		return nil, fmt.Errorf("%w: id is required", ErrValidation)
+	}
+	if err := s.access.RequireProject(ctx, id, access.RoleViewer); err != nil {
+		return nil, err
+	}
+	return s.access.ProjectCollaborators(ctx, id)
+}
+
+func validateGrant(id, userID string) error {
+	if id == "" {
+		return fmt.Errorf("%w: id is required", ErrValidation)
+	}
+	if userID == "" {
+		return fmt.Errorf("%w: user_id is required", ErrValidation)
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package todo
+
+import (
+	"context"
+	"fmt"
+
+	todorepo "todo/internal/todo"
+	"todo/internal/uuid"
+)
+
+// DefaultMaxBatchSize ограничивает пакет, если Config.MaxBatchSize не задан.
+const DefaultMaxBatchSize = 1000
+
+// BatchResult — итог операции над одним элементом неатомарного пакета.
+type BatchResult struct {
+	// Record — задача после операции; пуст при ошибке и при удалении.
+	Record todorepo.Record
+	Err    error
+}
+
+// DeleteParams описывает удаление задачи в пакете.
+type DeleteParams struct {
+	ID string
+	// ExpectedVersion — ожидаемая версия задачи; 0 отключает проверку.
+	ExpectedVersion int64
+}
+
+// BatchCreate создаёт задачи. При allOrNothing пакет создаётся в одной
+// транзакции целиком или не создаётся вовсе, а ошибка имеет тип
+// *todorepo.ItemError; иначе каждая задача создаётся отдельно и её итог
+// возвращается в результате с тем же номером.
+func (s *Service) BatchCreate(ctx context.Context, items []CreateParams, allOrNothing bool) ([]BatchResult, error) {
+	if err := s.checkBatchSize(len(items)); err != nil {
+		return nil, err
+	}
+	if !allOrNothing {
+		results := make([]BatchResult, len(items))
+		for i, params := range items {
+			results[i].Record, results[i].Err = s.Create(ctx, params)
+		}
+		return results, nil
+	}
+
+	prepared := make([]todorepo.CreateParams, len(items))
+	for i, params := range items {
+		p, err := s.prepareCreate(ctx, params)
+		if err != nil {
+			return nil, &todorepo.ItemError{Index: i, Err: err}
+		}
+		prepared[i] = p
+	}
+	recs, err := s.repo.BatchCreate(ctx, prepared)
+	if err != nil {
+		return nil, err
+	}
+	return recordResults(recs), nil
+}
+
+// BatchUpdate изменяет задачи по правилам Update; allOrNothing работает
+// как в BatchCreate. Задача может встретиться в пакете только один раз.
+func (s *Service) BatchUpdate(ctx context.Context, items []UpdateParams, allOrNothing bool) ([]BatchResult, error) {
+	if err := s.checkBatchSize(len(items)); err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(items))
+	for i, params := range items {
+		ids[i] = params.ID
+	}
+	if err := checkUniqueIDs(ids); err != nil {
+		return nil, err
+	}
+	if !allOrNothing {
+		results := make([]BatchResult, len(items))
+		for i, params := range items {
+			results[i].Record, results[i].Err = s.Update(ctx, params)
+		}
+		return results, nil
+	}
+
+	prepared := make([]todorepo.UpdateItem, len(items))
+	for i, params := range items {
+		item, err := s.prepareUpdate(ctx, params)
+		if err != nil {
+			return nil, &todorepo.ItemError{Index: i, Err: err}
+		}
+		prepared[i] = item
+	}
+	recs, err := s.repo.BatchUpdate(ctx, prepared)
+	if err != nil {
+		return nil, err
+	}
+	return recordResults(recs), nil
+}
+
+// BatchDelete перемещает задачи в корзину по правилам Delete; allOrNothing
+// работает как в BatchCreate. Задача может встретиться в пакете только один раз.
+func (s *Service) BatchDelete(ctx context.Context, items []DeleteParams, allOrNothing bool) ([]BatchResult, error) {
+	if err := s.checkBatchSize(len(items)); err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(items))
+	for i, params := range items {
+		ids[i] = params.ID
+	}
+	if err := checkUniqueIDs(ids); err != nil {
+		return nil, err
+	}
+	results := make([]BatchResult, len(items))
+	if !allOrNothing {
+		for i, params := range items {
+			results[i].Err = s.Delete(ctx, params.ID, params.ExpectedVersion)
+		}
+		return results, nil
+	}
+
+	prepared := make([]todorepo.DeleteItem, len(items))
+	for i, params := range items {
+		id, err := s.prepareDelete(ctx, params.ID, params.ExpectedVersion)
+		if err != nil {
+			return nil, &todorepo.ItemError{Index: i, Err: err}
+		}
+		prepared[i] = todorepo.DeleteItem{ID: id, Version: params.ExpectedVersion}
+	}
+	if err := s.repo.BatchDelete(ctx, prepared); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (s *Service) checkBatchSize(n int) error {
+	limit := s.cfg.MaxBatchSize
+	if limit <= 0 {
+		limit = DefaultMaxBatchSize
+	}
+	switch {
+	case n == 0:
+		return fmt.Errorf("%w: batch must not be empty", ErrValidation)
+	case n > limit:
+		return fmt.Errorf("%w: batch of %d items exceeds the limit of %d", ErrValidation, n, limit)
+	}
+	return nil
+}
+
+// checkUniqueIDs запрещает повторять задачу в пакете: её версия, прочитанная
+// при подготовке, устарела бы после первого изменения.
+func checkUniqueIDs(ids []string) error {
+	seen := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		// Неверный идентификатор отклонит проверка самого элемента.
+		u, err := uuid.Parse(id)
+		if err != nil {
+			continue
+		}
+		id = u.String()
+		if _, ok := seen[id]; ok {
+			return fmt.Errorf("%w: todo %s appears in the batch more than once", ErrValidation, id)
+		}
+		seen[id] = struct{}{}
+	}
+	return nil
+}
+
+func recordResults(recs []todorepo.Record) []BatchResult {
+	results := make([]BatchResult, len(recs))
+	for i, rec := range recs {
+		results[i].Record = rec
+	}
+	return results
+}
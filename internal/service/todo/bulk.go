@@ -0,0 +1,137 @@
+package todo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"todo/internal/access"
+	todorepo "todo/internal/todo"
+)
+
+const (
+	// DefaultSampleSize — сколько задач показывает пробный запуск по умолчанию.
+	DefaultSampleSize = 10
+	// MaxSampleSize ограничивает выборку пробного запуска сверху.
+	MaxSampleSize = 100
+)
+
+// BulkParams описывает отбор задач для массового действия; правила отбора
+// те же, что у List, но затрагиваются только задачи, которые вызывающий
+// может изменять.
+type BulkParams struct {
+	Filter todorepo.ListFilter
+	Due    DueFilter
+	// DryRun только считает затрагиваемые задачи и ничего не меняет.
+	DryRun bool
+	// SampleSize — сколько затрагиваемых задач вернуть при пробном запуске.
+	SampleSize int
+}
+
+// BulkUpdateParams описывает массовое изменение; nil означает «оставить как есть».
+type BulkUpdateParams struct {
+	BulkParams
+	Completed *bool
+	Priority  *todorepo.Priority
+	// ProjectID переносит задачи в проект; пустая строка означает «Входящие».
+	ProjectID *string
+}
+
+// BulkResult — итог массового действия.
+type BulkResult struct {
+	// Count — число затронутых задач; при пробном запуске — число тех,
+	// что были бы затронуты.
+	Count int64
+	// Sample заполняется только при пробном запуске.
+	Sample []todorepo.Record
+}
+
+// BulkUpdate изменяет все задачи по фильтру. При завершении повторяющиеся
+// и заблокированные задачи пропускаются, а открытые подзадачи обрабатываются
+// по политике завершения: завершаются вместе с задачей или её исключают.
+// Задача исключается и тогда, когда среди её открытых подзадач есть
+// повторяющиеся, заблокированные или недоступные вызывающему для изменения.
+func (s *Service) BulkUpdate(ctx context.Context, params BulkUpdateParams) (BulkResult, error) {
+	if params.Completed == nil && params.Priority == nil && params.ProjectID == nil {
+		return BulkResult{}, fmt.Errorf("%w: at least one field to update is required", ErrValidation)
+	}
+	filter, sample, err := params.prepare()
+	if err != nil {
+		return BulkResult{}, err
+	}
+	patch := todorepo.BulkPatch{
+		Completed:        params.Completed,
+		Priority:         params.Priority,
+		CompleteSubtasks: s.cfg.CompletionPolicy == CompleteSubtasks,
+	}
+	if params.Priority != nil && !params.Priority.Valid() {
+		return BulkResult{}, fmt.Errorf("%w: unknown priority %d", ErrValidation, *params.Priority)
+	}
+	if params.ProjectID != nil {
+		projectID := *params.ProjectID
+		if err := optionalID("project_id", &projectID); err != nil {
+			return BulkResult{}, err
+		}
+		if projectID != "" {
+			if err := s.access.RequireProject(ctx, projectID, access.RoleEditor); err != nil {
+				return BulkResult{}, err
+			}
+		}
+		patch.ProjectID = &sql.NullString{String: projectID, Valid: projectID != ""}
+	}
+
+	if params.DryRun {
+		count, recs, err := s.repo.PreviewBulkUpdate(ctx, filter, patch, sample)
+		if err != nil {
+			return BulkResult{}, err
+		}
+		return BulkResult{Count: count, Sample: recs}, nil
+	}
+	count, err := s.repo.BulkUpdate(ctx, filter, patch)
+	if err != nil {
+		return BulkResult{}, err
+	}
+	return BulkResult{Count: count}, nil
+}
+
+// BulkDelete перемещает в корзину все задачи по фильтру.
+func (s *Service) BulkDelete(ctx context.Context, params BulkParams) (BulkResult, error) {
+	filter, sample, err := params.prepare()
+	if err != nil {
+		return BulkResult{}, err
+	}
+	if params.DryRun {
+		count, recs, err := s.repo.PreviewBulkDelete(ctx, filter, sample)
+		if err != nil {
+			return BulkResult{}, err
+		}
+		return BulkResult{Count: count, Sample: recs}, nil
+	}
+	count, err := s.repo.BulkDelete(ctx, filter)
+	if err != nil {
+		return BulkResult{}, err
+	}
+	return BulkResult{Count: count}, nil
+}
+
+// prepare проверяет отбор и возвращает фильтр и размер выборки пробного запуска.
+func (p BulkParams) prepare() (todorepo.ListFilter, int, error) {
+	if p.Filter.Trashed {
+		return todorepo.ListFilter{}, 0, fmt.Errorf("%w: bulk actions do not apply to the trash", ErrValidation)
+	}
+	filter, err := prepareFilter(p.Filter, p.Due, time.Now())
+	if err != nil {
+		return todorepo.ListFilter{}, 0, err
+	}
+	sample := p.SampleSize
+	switch {
+	case sample < 0:
+		return todorepo.ListFilter{}, 0, fmt.Errorf("%w: sample_size must not be negative", ErrValidation)
+	case sample == 0:
+		sample = DefaultSampleSize
+	case sample > MaxSampleSize:
+		sample = MaxSampleSize
+	}
+	return filter, sample, nil
+}
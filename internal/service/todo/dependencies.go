@@ -0,0 +1,61 @@
+package todo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"todo/internal/access"
+	todorepo "todo/internal/todo"
+)
+
+// AddDependency отмечает, что задача id не может быть завершена раньше blockerID.
+func (s *Service) AddDependency(ctx context.Context, id, blockerID string) (todorepo.Record, error) {
+	id, blockerID, err := validateDependency(id, blockerID)
+	if err != nil {
+		return todorepo.Record{}, err
+	}
+	if err := s.access.RequireTodo(ctx, id, access.RoleEditor); err != nil {
+		return todorepo.Record{}, err
+	}
+	// Блокирующую задачу достаточно видеть.
+	if _, err := s.repo.Get(ctx, blockerID); err != nil {
+		if errors.Is(err, todorepo.ErrNotFound) {
+			return todorepo.Record{}, fmt.Errorf("%w: blocker todo %s not found", ErrValidation, blockerID)
+		}
+		return todorepo.Record{}, err
+	}
+	rec, err := s.repo.AddDependency(ctx, id, blockerID)
+	if errors.Is(err, todorepo.ErrDependencyCycle) {
+		return todorepo.Record{}, fmt.Errorf("%w: todo %s already depends on %s", ErrValidation, blockerID, id)
+	}
+	return rec, err
+}
+
+// RemoveDependency снимает блокировку задачи id задачей blockerID.
+func (s *Service) RemoveDependency(ctx context.Context, id, blockerID string) (todorepo.Record, error) {
+	id, blockerID, err := validateDependency(id, blockerID)
+	if err != nil {
+		return todorepo.Record{}, err
+	}
+	if err := s.access.RequireTodo(ctx, id, access.RoleEditor); err != nil {
+		return todorepo.Record{}, err
+	}
+	return s.repo.RemoveDependency(ctx, id, blockerID)
+}
+
+// validateDependency проверяет пару задач и возвращает их идентификаторы в
+// каноническом виде.
+func validateDependency(id, blockerID string) (string, string, error) {
+	id, err := canonicalID("id", id)
+	if err != nil {
+		return "", "", err
+	}
+	if blockerID, err = canonicalID("blocker_id", blockerID); err != nil {
+		return "", "", err
+	}
+	if id == blockerID {
+		return "", "", fmt.Errorf("%w: todo cannot block itself", ErrValidation)
+	}
+	return id, blockerID, nil
+}
@@ -0,0 +1,70 @@
+package todo
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	todorepo "todo/internal/todo"
+)
+
+// HistoryResult содержит страницу журнала изменений и курсор следующей страницы.
+type HistoryResult struct {
+	Items         []todorepo.Event
+	NextPageToken string
+}
+
+// historyToken — содержимое непрозрачного курсора страницы журнала.
+type historyToken struct {
+	Seq int64 `json:"s"`
+}
+
+// History возвращает страницу журнала изменений задачи от новых событий к старым.
+// Журнал доступен всем, кто видит задачу, а после её окончательного
+// удаления — удалившему, бывшему владельцу и пользователям с ролями на неё.
+func (s *Service) History(ctx context.Context, id string, size int, pageToken string) (HistoryResult, error) {
+	id, err := canonicalID("id", id)
+	if err != nil {
+		return HistoryResult{}, err
+	}
+	size, err = pageSize(size)
+	if err != nil {
+		return HistoryResult{}, err
+	}
+	before, err := decodeHistoryToken(pageToken)
+	if err != nil {
+		return HistoryResult{}, err
+	}
+
+	events, err := s.repo.History(ctx, id, size+1, before)
+	if err != nil {
+		return HistoryResult{}, err
+	}
+	res := HistoryResult{Items: events}
+	if len(events) > size {
+		res.Items = events[:size]
+		data, err := json.Marshal(historyToken{Seq: res.Items[size-1].Seq})
+		if err != nil {
+			return HistoryResult{}, fmt.Errorf("encode page token: %w", err)
+		}
+		res.NextPageToken = base64.RawURLEncoding.EncodeToString(data)
+	}
+	return res, nil
+}
+
+// decodeHistoryToken разбирает курсор журнала; пустая строка означает первую страницу.
+func decodeHistoryToken(token string) (int64, error) {
+	if token == "" {
+		return 0, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, fmt.Errorf("%w: malformed page_token", ErrValidation)
+	}
+	var t historyToken
+	if err := json.Unmarshal(data, &t); err != nil || t.Seq <= 0 {
+		return 0, fmt.Errorf("%w: malformed page_token", ErrValidation)
+	}
+	return t.Seq, nil
+}
@@ -0,0 +1,180 @@
+package todo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"todo/internal/ical"
+	todorepo "todo/internal/todo"
+)
+
+// icalFields — поля, которые загрузка календаря переносит в существующую задачу.
+var icalFields = []string{
+	FieldTitle, FieldDescription, FieldCompleted, FieldDueAt, FieldStartAt, FieldAllDay, FieldTimeZone,
+	FieldPriority, FieldRecurrence, FieldTags,
+}
+
+// icalPriorities сопоставляет приоритеты шкале PRIORITY: 1 — высший, 9 — низший.
+var icalPriorities = map[todorepo.Priority]int{
+	todorepo.PriorityNone:   0,
+	todorepo.PriorityUrgent: 1,
+	todorepo.PriorityHigh:   3,
+	todorepo.PriorityMedium: 5,
+	todorepo.PriorityLow:    7,
+}
+
+// priorityFromICal переводит PRIORITY в приоритет задачи.
+func priorityFromICal(p int) todorepo.Priority {
+	switch {
+	case p == 0:
+		return todorepo.PriorityNone
+	case p <= 2:
+		return todorepo.PriorityUrgent
+	case p <= 4:
+		return todorepo.PriorityHigh
+	case p == 5:
+		return todorepo.PriorityMedium
+	default:
+		return todorepo.PriorityLow
+	}
+}
+
+// icalTodo переводит задачу в VTODO. Время завершения не хранится,
+// поэтому COMPLETED завершённой задачи — время её последнего изменения.
+func icalTodo(rec todorepo.Record) ical.Todo {
+	t := ical.Todo{
+		UID:          rec.ICalUID,
+		Summary:      rec.Title,
+		Description:  rec.Description,
+		Status:       ical.StatusNeedsAction,
+		Created:      &rec.CreatedAt,
+		LastModified: &rec.UpdatedAt,
+		Due:          rec.DueAt,
+		Start:        rec.StartAt,
+		AllDay:       rec.AllDay,
+		TimeZone:     rec.TimeZone,
+		Priority:     icalPriorities[rec.Priority],
+		Categories:   rec.Tags,
+		RRule:        rec.Recurrence,
+	}
+	if rec.Completed {
+		t.Status = ical.StatusCompleted
+		t.Completed = &rec.UpdatedAt
+	}
+	return t
+}
+
+// icalSchedule собирает сроки из VTODO. Если в календаре нет пояса, сроки
+// остаются в поясе tz; даты задач на весь день переносятся в него же.
+func icalSchedule(t ical.Todo, tz string) Schedule {
+	if t.TimeZone != "" {
+		tz = t.TimeZone
+	}
+	sc := Schedule{DueAt: t.Due, StartAt: t.Start, AllDay: t.AllDay, TimeZone: tz}
+	if t.AllDay {
+		sc.DueAt = sameDate(t.Due, defaultTimeZone, tz)
+		sc.StartAt = sameDate(t.Start, defaultTimeZone, tz)
+	}
+	return sc
+}
+
+// ExportICalendar пишет в w календарь с задачами по фильтру, от старых к
+// новым. Правила отбора те же, что у List.
+func (s *Service) ExportICalendar(ctx context.Context, filter todorepo.ListFilter, due DueFilter, w io.Writer) error {
+	filter, err := prepareFilter(filter, due, time.Now())
+	if err != nil {
+		return err
+	}
+	cw := ical.NewWriter(w, time.Now())
+	err = s.eachRecord(ctx, filter, func(rec todorepo.Record) error {
+		return cw.Write(icalTodo(rec))
+	})
+	if err != nil {
+		return err
+	}
+	return cw.Close()
+}
+
+// ImportICalendar загружает задачи из календаря. Задача с UID, который уже
+// есть у видимой вызывающему задачи, изменяет её по правилам Update, иначе
+// создаётся новая задача с этим UID, поэтому повторная загрузка того же
+// календаря не создаёт копий. Ошибка одной задачи не прерывает загрузку;
+// номер строки в ошибке — строка её BEGIN:VTODO.
+func (s *Service) ImportICalendar(ctx context.Context, r io.Reader) (ImportResult, error) {
+	cr := ical.NewReader(r)
+	var res ImportResult
+	for {
+		t, line, err := cr.Next()
+		switch {
+		case errors.Is(err, io.EOF):
+			return res, nil
+		case err != nil && line == 0:
+			if errors.Is(err, ical.ErrInvalid) {
+				return ImportResult{}, fmt.Errorf("%w: %v", ErrValidation, err)
+			}
+			return ImportResult{}, err
+		case err != nil:
+			res.fail(RowError{Row: int64(line), Err: fmt.Errorf("%w: %v", ErrValidation, err)})
+			continue
+		}
+
+		created, err := s.importICalTodo(ctx, t)
+		switch {
+		case err != nil && ctx.Err() != nil:
+			return ImportResult{}, ctx.Err()
+		case err != nil:
+			res.fail(RowError{Row: int64(line), Err: err})
+		case created:
+			res.Created++
+		default:
+			res.Updated++
+		}
+	}
+}
+
+// importICalTodo записывает задачу из календаря и сообщает, была ли она создана.
+func (s *Service) importICalTodo(ctx context.Context, t ical.Todo) (bool, error) {
+	completed := t.Status == ical.StatusCompleted || t.Completed != nil
+	priority := priorityFromICal(t.Priority)
+	if t.UID != "" {
+		cur, err := s.repo.GetByICalUID(ctx, t.UID)
+		switch {
+		case err == nil:
+			_, err = s.Update(ctx, UpdateParams{
+				ID:          cur.ID,
+				Title:       t.Summary,
+				Description: t.Description,
+				Completed:   completed,
+				Schedule:    icalSchedule(t, cur.TimeZone),
+				Priority:    priority,
+				Recurrence:  t.RRule,
+				Tags:        t.Categories,
+				Paths:       icalFields,
+			})
+			return false, err
+		case !errors.Is(err, todorepo.ErrNotFound):
+			return false, err
+		}
+	}
+
+	prepared, err := s.prepareCreate(ctx, CreateParams{
+		Title:       t.Summary,
+		Description: t.Description,
+		Schedule:    icalSchedule(t, ""),
+		Priority:    priority,
+		Tags:        t.Categories,
+		Recurrence:  t.RRule,
+	})
+	if err != nil {
+		return false, err
+	}
+	if t.UID != "" {
+		prepared.ICalUID = &t.UID
+	}
+	prepared.Completed = completed
+	_, err = s.repo.Create(ctx, prepared)
+	return true, err
+}
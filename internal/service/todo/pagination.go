@@ -0,0 +1,98 @@
+package todo
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	todorepo "todo/internal/todo"
+)
+
+const (
+	// DefaultPageSize используется, если клиент не указал размер страницы.
+	DefaultPageSize = 50
+	// MaxPageSize ограничивает размер страницы сверху.
+	MaxPageSize = 1000
+)
+
+// ListParams описывает запрос страницы задач.
+type ListParams struct {
+	PageSize  int
+	PageToken string
+	Filter    todorepo.ListFilter
+	Due       DueFilter
+	Order     todorepo.Order
+}
+
+// ListResult содержит страницу задач и курсор следующей страницы.
+type ListResult struct {
+	Items         []todorepo.Record
+	NextPageToken string
+}
+
+// pageToken — содержимое непрозрачного курсора страницы.
+type pageToken struct {
+	Order todorepo.Order `json:"o"`
+	// Filter — отпечаток фильтра, для которого выдан курсор.
+	Filter string          `json:"f"`
+	Cursor todorepo.Cursor `json:"c"`
+}
+
+// filterHash возвращает отпечаток фильтра списка. Берётся фильтр из
+// запроса, а не вычисленный от текущего времени, поэтому отпечаток не
+// меняется между страницами.
+func filterHash(filter todorepo.ListFilter, due DueFilter) (string, error) {
+	data, err := json.Marshal(struct {
+		Filter todorepo.ListFilter
+		Due    DueFilter
+	}{filter, due})
+	if err != nil {
+		return "", fmt.Errorf("hash filter: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:12]), nil
+}
+
+func pageSize(size int) (int, error) {
+	switch {
+	case size < 0:
+		return 0, fmt.Errorf("%w: page_size must not be negative", ErrValidation)
+	case size == 0:
+		return DefaultPageSize, nil
+	case size > MaxPageSize:
+		return MaxPageSize, nil
+	default:
+		return size, nil
+	}
+}
+
+func encodePageToken(order todorepo.Order, filter string, cursor todorepo.Cursor) (string, error) {
+	data, err := json.Marshal(pageToken{Order: order, Filter: filter, Cursor: cursor})
+	if err != nil {
+		return "", fmt.Errorf("encode page token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodePageToken разбирает курсор; пустая строка означает первую страницу.
+func decodePageToken(token string, order todorepo.Order, filter string) (*todorepo.Cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed page_token", ErrValidation)
+	}
+	var t pageToken
+	if err := json.Unmarshal(data, &t); err != nil || t.Cursor.ID == "" {
+		return nil, fmt.Errorf("%w: malformed page_token", ErrValidation)
+	}
+	if t.Order != order {
+		return nil, fmt.Errorf("%w: page_token was issued for a different order", ErrValidation)
+	}
+	if t.Filter != filter {
+		return nil, fmt.Errorf("%w: page_token was issued for a different filter", ErrValidation)
+	}
+	return &t.Cursor, nil
+}
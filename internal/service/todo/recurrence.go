@@ -0,0 +1,163 @@
+package todo
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"todo/internal/rrule"
+	todorepo "todo/internal/todo"
+)
+
+// parseRecurrence проверяет правило и возвращает его канонический вид.
+// Пустое правило означает разовую задачу.
+func parseRecurrence(rule string) (string, error) {
+	if rule == "" {
+		return "", nil
+	}
+	r, err := rrule.Parse(rule)
+	if err != nil {
+		if errors.Is(err, rrule.ErrInvalid) {
+			return "", fmt.Errorf("%w: %v", ErrValidation, err)
+		}
+		return "", err
+	}
+	return r.String(), nil
+}
+
+func validateRecurrenceMode(mode todorepo.RecurrenceMode) error {
+	if !mode.Valid() {
+		return fmt.Errorf("%w: unknown recurrence_mode %d", ErrValidation, mode)
+	}
+	return nil
+}
+
+// prepareRecurrence согласует правило повторения с итоговыми сроками задачи и,
+// если задача завершается, готовит следующее вхождение: в режиме advance
+// задача остаётся открытой с новыми сроками, в режиме spawn следующее
+// вхождение создаётся отдельной задачей. Когда серия исчерпана, задача
+// завершается как обычная.
+func prepareRecurrence(cur todorepo.Record, sc Schedule, patch *todorepo.Patch) error {
+	rule, start := cur.Recurrence, cur.SeriesStart
+	// Новое правило или ручной перенос срока начинают серию от текущего срока.
+	if patch.Recurrence != nil || (patch.DueAt != nil && !sameTime(cur.DueAt, sc.DueAt)) {
+		if patch.Recurrence != nil {
+			rule = *patch.Recurrence
+		}
+		start = sc.DueAt
+		if rule == "" {
+			start = nil
+		}
+		patch.SeriesStart = nullTime(start)
+	}
+	if rule == "" {
+		return nil
+	}
+	if sc.DueAt == nil {
+		return fmt.Errorf("%w: recurring todo requires due_at", ErrValidation)
+	}
+	if patch.Completed == nil || !*patch.Completed || cur.Completed {
+		return nil
+	}
+
+	next, ok, err := nextOccurrence(rule, start, sc)
+	if err != nil || !ok {
+		return err
+	}
+	mode := cur.RecurrenceMode
+	if patch.RecurrenceMode != nil {
+		mode = *patch.RecurrenceMode
+	}
+	if mode == todorepo.RecurrenceAdvance {
+		patch.Completed = nil
+		next.apply(patch)
+		return nil
+	}
+	patch.Spawn = &todorepo.CreateParams{
+		Title:          stringOr(patch.Title, cur.Title),
+		Description:    stringOr(patch.Description, cur.Description),
+		DueAt:          next.DueAt,
+		StartAt:        next.StartAt,
+		AllDay:         next.AllDay,
+		TimeZone:       next.TimeZone,
+		Priority:       cur.Priority,
+		Tags:           cur.Tags,
+		ProjectID:      nullStringOr(patch.ProjectID, cur.ProjectID),
+		ParentID:       nullStringOr(patch.ParentID, cur.ParentID),
+		Recurrence:     rule,
+		RecurrenceMode: mode,
+		SeriesStart:    start,
+	}
+	if patch.Priority != nil {
+		patch.Spawn.Priority = *patch.Priority
+	}
+	if patch.Tags != nil {
+		patch.Spawn.Tags = *patch.Tags
+	}
+	return nil
+}
+
+// nextOccurrence переносит сроки на следующее вхождение серии. Правило
+// разворачивается в часовом поясе задачи, время начала сохраняет своё
+// время суток и отступ в днях от срока.
+func nextOccurrence(rule string, start *time.Time, sc Schedule) (Schedule, bool, error) {
+	r, err := rrule.Parse(rule)
+	if err != nil {
+		return Schedule{}, false, err
+	}
+	loc, err := time.LoadLocation(sc.TimeZone)
+	if err != nil {
+		return Schedule{}, false, err
+	}
+	if start == nil {
+		start = sc.DueAt
+	}
+	due := sc.DueAt.In(loc)
+	next, ok := r.After(start.In(loc), due)
+	if !ok {
+		return Schedule{}, false, nil
+	}
+
+	out := sc
+	nextDue := next.UTC()
+	out.DueAt = &nextDue
+	if sc.StartAt != nil {
+		days := daysBetween(due, next)
+		st := sc.StartAt.In(loc)
+		nextStart := time.Date(st.Year(), st.Month(), st.Day()+days, st.Hour(), st.Minute(), st.Second(), st.Nanosecond(), loc).UTC()
+		out.StartAt = &nextStart
+	}
+	return out, true, nil
+}
+
+// daysBetween возвращает число календарных дней между датами from и to.
+func daysBetween(from, to time.Time) int {
+	a := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	b := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(b.Sub(a).Hours() / 24)
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Equal(*b)
+}
+
+func stringOr(v *string, fallback string) string {
+	if v != nil {
+		return *v
+	}
+	return fallback
+}
+
+func nullStringOr(v *sql.NullString, fallback *string) *string {
+	if v == nil {
+		return fallback
+	}
+	if !v.Valid {
+		return nil
+	}
+	return &v.String
+}
@@ -0,0 +1,139 @@
+package todo
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	todorepo "todo/internal/todo"
+)
+
+// defaultTimeZone используется, если часовой пояс не указан.
+const defaultTimeZone = "UTC"
+
+// Schedule описывает сроки задачи.
+type Schedule struct {
+	DueAt   *time.Time
+	StartAt *time.Time
+	// AllDay означает, что важна только дата: время в DueAt и StartAt отбрасывается.
+	AllDay   bool
+	TimeZone string
+}
+
+func scheduleOf(rec todorepo.Record) Schedule {
+	return Schedule{DueAt: rec.DueAt, StartAt: rec.StartAt, AllDay: rec.AllDay, TimeZone: rec.TimeZone}
+}
+
+// normalize проверяет сроки и приводит их к каноническому виду: пустой часовой
+// пояс заменяется на UTC, а даты задачи на весь день — на начало дня в её поясе.
+func (sc Schedule) normalize() (Schedule, error) {
+	if sc.TimeZone == "" {
+		sc.TimeZone = defaultTimeZone
+	}
+	loc, err := time.LoadLocation(sc.TimeZone)
+	if err != nil {
+		return Schedule{}, fmt.Errorf("%w: unknown time_zone %q", ErrValidation, sc.TimeZone)
+	}
+	if sc.AllDay {
+		if sc.DueAt == nil && sc.StartAt == nil {
+			return Schedule{}, fmt.Errorf("%w: all_day requires due_at or start_at", ErrValidation)
+		}
+		sc.DueAt = startOfDay(sc.DueAt, loc)
+		sc.StartAt = startOfDay(sc.StartAt, loc)
+	}
+	if sc.DueAt != nil && sc.StartAt != nil {
+		// Задача на весь день может начинаться и заканчиваться в один день.
+		if sc.StartAt.After(*sc.DueAt) || (!sc.AllDay && sc.StartAt.Equal(*sc.DueAt)) {
+			return Schedule{}, fmt.Errorf("%w: start_at must be before due_at", ErrValidation)
+		}
+	}
+	return sc, nil
+}
+
+// apply переносит сроки в патч целиком, чтобы поля оставались согласованными.
+func (sc Schedule) apply(patch *todorepo.Patch) {
+	patch.DueAt = nullTime(sc.DueAt)
+	patch.StartAt = nullTime(sc.StartAt)
+	patch.AllDay = &sc.AllDay
+	patch.TimeZone = &sc.TimeZone
+}
+
+func startOfDay(t *time.Time, loc *time.Location) *time.Time {
+	if t == nil {
+		return nil
+	}
+	y, m, d := t.In(loc).Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, loc).UTC()
+	return &day
+}
+
+// sameDate возвращает начало того же календарного дня в другом поясе.
+// Если один из поясов неизвестен, дата возвращается как есть: ошибку
+// сообщит последующая нормализация.
+func sameDate(t *time.Time, fromTZ, toTZ string) *time.Time {
+	if t == nil {
+		return nil
+	}
+	from, err := time.LoadLocation(fromTZ)
+	if err != nil {
+		return t
+	}
+	to, err := time.LoadLocation(toTZ)
+	if err != nil {
+		return t
+	}
+	y, m, d := t.In(from).Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, to).UTC()
+	return &day
+}
+
+func nullTime(t *time.Time) *sql.NullTime {
+	if t == nil {
+		return &sql.NullTime{}
+	}
+	return &sql.NullTime{Time: *t, Valid: true}
+}
+
+// DueFilter описывает отбор задач по сроку относительно текущего дня.
+type DueFilter struct {
+	// Overdue отбирает незавершённые задачи с истёкшим сроком.
+	Overdue bool
+	// Today отбирает задачи со сроком на сегодня.
+	Today bool
+	// WithinDays отбирает задачи со сроком в ближайшие N дней, считая сегодняшний.
+	WithinDays int
+	// TimeZone задаёт границы дня; по умолчанию UTC.
+	TimeZone string
+}
+
+// apply дополняет фильтр репозитория границами, вычисленными от now.
+func (f DueFilter) apply(filter *todorepo.ListFilter, now time.Time) error {
+	if f.WithinDays < 0 {
+		return fmt.Errorf("%w: due_within_days must not be negative", ErrValidation)
+	}
+	if !f.Overdue && !f.Today && f.WithinDays == 0 {
+		return nil
+	}
+	tz := f.TimeZone
+	if tz == "" {
+		tz = defaultTimeZone
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return fmt.Errorf("%w: unknown time_zone %q", ErrValidation, tz)
+	}
+	dayStart := *startOfDay(&now, loc)
+
+	days := f.WithinDays
+	if f.Today && (days == 0 || days > 1) {
+		days = 1
+	}
+	if days > 0 {
+		filter.DueFrom = dayStart
+		filter.DueTo = dayStart.In(loc).AddDate(0, 0, days).UTC()
+	}
+	if f.Overdue {
+		filter.Overdue = &todorepo.Overdue{Now: now.UTC(), DayStart: dayStart}
+	}
+	return nil
+}
@@ -3,58 +3,475 @@ package todo
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
+	"todo/internal/access"
 	todorepo "todo/internal/todo"
+	"todo/internal/uuid"
 )
 
-// ErrValidation сигнализирует о нарушениях входных данных.
-var ErrValidation = errors.New("validation error")
+var (
+	// ErrValidation сигнализирует о нарушениях входных данных.
+	ErrValidation = errors.New("validation error")
+	// ErrFailedPrecondition сигнализирует, что операция недопустима в текущем состоянии задачи.
+	ErrFailedPrecondition = errors.New("failed precondition")
+)
+
+// Config задаёт правила бизнес-логики задач.
+type Config struct {
+	// MaxDepth ограничивает вложенность подзадач; задача верхнего уровня имеет глубину 1.
+	MaxDepth int
+	// CompletionPolicy определяет, как завершается задача с открытыми подзадачами.
+	CompletionPolicy CompletionPolicy
+	// AfterPurge, если задан, вызывается после окончательного удаления задач,
+	// чтобы освободить связанные с ними ресурсы вне базы, например вложения.
+	AfterPurge func(ctx context.Context) error
+	// Notifier, если задан, сообщает о новых событиях журнала; без него
+	// подписки на изменения опрашивают журнал с интервалом WatchPollInterval.
+	Notifier Notifier
+	// WatchPollInterval — как часто подписка перечитывает журнал без уведомлений.
+	WatchPollInterval time.Duration
+	// MaxBatchSize ограничивает число элементов в пакетных операциях.
+	MaxBatchSize int
+}
 
 // Service инкапсулирует операции над задачами на уровне бизнес-логики.
 type Service struct {
-	repo *todorepo.Repository
+	repo   *todorepo.Repository
+	access *access.Repository
+	cfg    Config
 }
 
 // NewService создает сервис задач.
-func NewService(repo *todorepo.Repository) *Service {
-	return &Service{repo: repo}
+func NewService(repo *todorepo.Repository, access *access.Repository, cfg Config) *Service {
+	return &Service{repo: repo, access: access, cfg: cfg}
+}
+
+// CreateParams описывает новую задачу.
+type CreateParams struct {
+	Title       string
+	Description string
+	Schedule    Schedule
+	Priority    todorepo.Priority
+	Tags        []string
+	// ProjectID задаёт проект; пустая строка означает «Входящие».
+	ProjectID string
+	// ParentID делает задачу подзадачей указанной задачи.
+	ParentID string
+	// Recurrence — правило RRULE; требует срока выполнения.
+	Recurrence     string
+	RecurrenceMode todorepo.RecurrenceMode
 }
 
 // Create создаёт новую задачу.
-func (s *Service) Create(ctx context.Context, title, description string) (todorepo.Record, error) {
-	if title == "" {
-		return todorepo.Record{}, fmt.Errorf("%w: title is required", ErrValidation)
+func (s *Service) Create(ctx context.Context, params CreateParams) (todorepo.Record, error) {
+	prepared, err := s.prepareCreate(ctx, params)
+	if err != nil {
+		return todorepo.Record{}, err
 	}
-	return s.repo.Create(ctx, title, description)
+	return s.repo.Create(ctx, prepared)
+}
+
+// prepareCreate проверяет новую задачу и права на её создание.
+func (s *Service) prepareCreate(ctx context.Context, params CreateParams) (todorepo.CreateParams, error) {
+	if params.Title == "" {
+		return todorepo.CreateParams{}, fmt.Errorf("%w: title is required", ErrValidation)
+	}
+	if !params.Priority.Valid() {
+		return todorepo.CreateParams{}, fmt.Errorf("%w: unknown priority %d", ErrValidation, params.Priority)
+	}
+	sc, err := params.Schedule.normalize()
+	if err != nil {
+		return todorepo.CreateParams{}, err
+	}
+	tags, err := normalizeTags(params.Tags)
+	if err != nil {
+		return todorepo.CreateParams{}, err
+	}
+	rule, err := parseRecurrence(params.Recurrence)
+	if err != nil {
+		return todorepo.CreateParams{}, err
+	}
+	if err := validateRecurrenceMode(params.RecurrenceMode); err != nil {
+		return todorepo.CreateParams{}, err
+	}
+	var seriesStart *time.Time
+	if rule != "" {
+		if sc.DueAt == nil {
+			return todorepo.CreateParams{}, fmt.Errorf("%w: recurring todo requires due_at", ErrValidation)
+		}
+		seriesStart = sc.DueAt
+	}
+	if params.ProjectID != "" {
+		if params.ProjectID, err = canonicalID("project_id", params.ProjectID); err != nil {
+			return todorepo.CreateParams{}, err
+		}
+		if err := s.access.RequireProject(ctx, params.ProjectID, access.RoleEditor); err != nil {
+			return todorepo.CreateParams{}, err
+		}
+	}
+	if params.ParentID != "" {
+		if params.ParentID, err = canonicalID("parent_id", params.ParentID); err != nil {
+			return todorepo.CreateParams{}, err
+		}
+		if err := s.checkParent(ctx, "", params.ParentID); err != nil {
+			return todorepo.CreateParams{}, err
+		}
+	}
+	return todorepo.CreateParams{
+		Title:          params.Title,
+		Description:    params.Description,
+		DueAt:          sc.DueAt,
+		StartAt:        sc.StartAt,
+		AllDay:         sc.AllDay,
+		TimeZone:       sc.TimeZone,
+		Priority:       params.Priority,
+		Tags:           tags,
+		ProjectID:      optionalString(params.ProjectID),
+		ParentID:       optionalString(params.ParentID),
+		Recurrence:     rule,
+		RecurrenceMode: params.RecurrenceMode,
+		SeriesStart:    seriesStart,
+	}, nil
 }
 
 // Get возвращает задачу по идентификатору.
 func (s *Service) Get(ctx context.Context, id string) (todorepo.Record, error) {
-	if id == "" {
-		return todorepo.Record{}, fmt.Errorf("%w: id is required", ErrValidation)
+	id, err := canonicalID("id", id)
+	if err != nil {
+		return todorepo.Record{}, err
 	}
 	return s.repo.Get(ctx, id)
 }
 
-// List возвращает все задачи.
-func (s *Service) List(ctx context.Context) ([]todorepo.Record, error) {
-	return s.repo.List(ctx)
+// canonicalID проверяет идентификатор из запроса и приводит его к виду, в
+// котором его возвращает база, чтобы идентификаторы можно было сравнивать.
+func canonicalID(field, id string) (string, error) {
+	if id == "" {
+		return "", fmt.Errorf("%w: %s is required", ErrValidation, field)
+	}
+	u, err := uuid.Parse(id)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s must be a UUID", ErrValidation, field)
+	}
+	return u.String(), nil
+}
+
+// optionalID приводит к каноническому виду необязательный идентификатор;
+// пустая строка остаётся пустой.
+func optionalID(field string, id *string) error {
+	if id == nil || *id == "" {
+		return nil
+	}
+	canonical, err := canonicalID(field, *id)
+	if err != nil {
+		return err
+	}
+	*id = canonical
+	return nil
 }
 
-// Update обновляет существующую задачу.
-func (s *Service) Update(ctx context.Context, id, title, description string, completed bool) (todorepo.Record, error) {
-	if id == "" {
-		return todorepo.Record{}, fmt.Errorf("%w: id is required", ErrValidation)
+// List возвращает страницу задач по фильтру и курсору.
+func (s *Service) List(ctx context.Context, params ListParams) (ListResult, error) {
+	if !params.Order.Valid() {
+		return ListResult{}, fmt.Errorf("%w: unknown order", ErrValidation)
+	}
+	size, err := pageSize(params.PageSize)
+	if err != nil {
+		return ListResult{}, err
+	}
+	hash, err := filterHash(params.Filter, params.Due)
+	if err != nil {
+		return ListResult{}, err
+	}
+	after, err := decodePageToken(params.PageToken, params.Order, hash)
+	if err != nil {
+		return ListResult{}, err
+	}
+	filter, err := prepareFilter(params.Filter, params.Due, time.Now())
+	if err != nil {
+		return ListResult{}, err
 	}
-	return s.repo.Update(ctx, id, title, description, completed)
+
+	recs, err := s.repo.List(ctx, todorepo.ListParams{
+		Filter: filter,
+		Order:  params.Order,
+		Limit:  size + 1,
+		After:  after,
+	})
+	if err != nil {
+		return ListResult{}, err
+	}
+
+	res := ListResult{Items: recs}
+	if len(recs) > size {
+		res.Items = recs[:size]
+		res.NextPageToken, err = encodePageToken(params.Order, hash, todorepo.CursorFor(res.Items[size-1]))
+		if err != nil {
+			return ListResult{}, err
+		}
+	}
+	return res, nil
 }
 
-// Delete удаляет задачу по идентификатору.
-func (s *Service) Delete(ctx context.Context, id string) error {
-	if id == "" {
-		return fmt.Errorf("%w: id is required", ErrValidation)
+// prepareFilter проверяет фильтр списка и дополняет его условиями на сроки.
+func prepareFilter(filter todorepo.ListFilter, due DueFilter, now time.Time) (todorepo.ListFilter, error) {
+	if !filter.Ownership.Valid() {
+		return todorepo.ListFilter{}, fmt.Errorf("%w: unknown ownership", ErrValidation)
+	}
+	if err := due.apply(&filter, now); err != nil {
+		return todorepo.ListFilter{}, err
+	}
+	var err error
+	if filter.Tags, err = normalizeTags(filter.Tags); err != nil {
+		return todorepo.ListFilter{}, err
+	}
+	if filter.ProjectID != nil {
+		projectID := *filter.ProjectID
+		if err := optionalID("project_id", &projectID); err != nil {
+			return todorepo.ListFilter{}, err
+		}
+		filter.ProjectID = &projectID
+	}
+	if filter.ParentID != nil {
+		parentID := *filter.ParentID
+		if err := optionalID("parent_id", &parentID); err != nil {
+			return todorepo.ListFilter{}, err
+		}
+		filter.ParentID = &parentID
+	}
+	return filter, nil
+}
+
+// Поля задачи, которые можно указать в маске обновления.
+const (
+	FieldTitle          = "title"
+	FieldDescription    = "description"
+	FieldCompleted      = "completed"
+	FieldDueAt          = "due_at"
+	FieldStartAt        = "start_at"
+	FieldAllDay         = "all_day"
+	FieldTimeZone       = "time_zone"
+	FieldPriority       = "priority"
+	FieldProjectID      = "project_id"
+	FieldParentID       = "parent_id"
+	FieldRecurrence     = "recurrence"
+	FieldRecurrenceMode = "recurrence_mode"
+	FieldTags           = "tags"
+)
+
+// defaultUpdateFields используется, если маска обновления пуста.
+var defaultUpdateFields = []string{FieldTitle, FieldDescription, FieldCompleted}
+
+// UpdateParams описывает изменение задачи.
+type UpdateParams struct {
+	ID          string
+	Title       string
+	Description string
+	Completed   bool
+	Schedule    Schedule
+	Priority    todorepo.Priority
+	// ProjectID переносит задачу в проект; пустая строка означает «Входящие».
+	ProjectID string
+	// ParentID делает задачу подзадачей; пустая строка — задачей верхнего уровня.
+	ParentID string
+	// Recurrence задаёт правило повторения; пустая строка делает задачу разовой.
+	Recurrence     string
+	RecurrenceMode todorepo.RecurrenceMode
+	// Tags заменяет метки задачи.
+	Tags []string
+	// Paths перечисляет изменяемые поля; пустой список означает title, description и completed.
+	Paths []string
+	// ExpectedVersion — ожидаемая версия задачи; 0 отключает проверку.
+	ExpectedVersion int64
+}
+
+// Update изменяет поля задачи, перечисленные в маске.
+func (s *Service) Update(ctx context.Context, params UpdateParams) (todorepo.Record, error) {
+	item, err := s.prepareUpdate(ctx, params)
+	if err != nil {
+		return todorepo.Record{}, err
+	}
+	return s.repo.Update(ctx, item.ID, item.Version, item.Patch)
+}
+
+// prepareUpdate проверяет изменение и права на него и собирает патч.
+func (s *Service) prepareUpdate(ctx context.Context, params UpdateParams) (todorepo.UpdateItem, error) {
+	var err error
+	if params.ID, err = canonicalID("id", params.ID); err != nil {
+		return todorepo.UpdateItem{}, err
+	}
+	if err := optionalID("project_id", &params.ProjectID); err != nil {
+		return todorepo.UpdateItem{}, err
+	}
+	if err := optionalID("parent_id", &params.ParentID); err != nil {
+		return todorepo.UpdateItem{}, err
+	}
+	if params.ExpectedVersion < 0 {
+		return todorepo.UpdateItem{}, fmt.Errorf("%w: expected_version must not be negative", ErrValidation)
+	}
+	patch, scheduleChanged, err := buildPatch(params)
+	if err != nil {
+		return todorepo.UpdateItem{}, err
+	}
+	if err := s.access.RequireTodo(ctx, params.ID, access.RoleEditor); err != nil {
+		return todorepo.UpdateItem{}, err
+	}
+	if patch.ProjectID != nil && patch.ProjectID.Valid {
+		if err := s.access.RequireProject(ctx, patch.ProjectID.String, access.RoleEditor); err != nil {
+			return todorepo.UpdateItem{}, err
+		}
+	}
+	completing := patch.Completed != nil && *patch.Completed
+	patch.CheckBlockers = completing
+	version := params.ExpectedVersion
+	if scheduleChanged || patch.Recurrence != nil || completing {
+		// Сроки и повторение проверяются вместе с текущими значениями, поэтому
+		// запись применяется только к той версии задачи, что была прочитана.
+		cur, err := s.repo.Get(ctx, params.ID)
+		if err != nil {
+			return todorepo.UpdateItem{}, err
+		}
+		if version == 0 {
+			version = cur.Version
+		}
+		sc := scheduleOf(cur)
+		if scheduleChanged {
+			if sc, err = mergeSchedule(sc, params).normalize(); err != nil {
+				return todorepo.UpdateItem{}, err
+			}
+			sc.apply(&patch)
+		}
+		if err := prepareRecurrence(cur, sc, &patch); err != nil {
+			return todorepo.UpdateItem{}, err
+		}
+	}
+	if patch.ParentID != nil && patch.ParentID.Valid {
+		if err := s.checkParent(ctx, params.ID, patch.ParentID.String); err != nil {
+			return todorepo.UpdateItem{}, err
+		}
+	}
+	// В режиме advance повторяющаяся задача не завершается, а переносится.
+	if patch.Completed != nil && *patch.Completed {
+		s.applyCompletionPolicy(&patch)
+	}
+	return todorepo.UpdateItem{ID: params.ID, Version: version, Patch: patch}, nil
+}
+
+// buildPatch переносит поля из маски в патч и сообщает, затронуты ли сроки.
+func buildPatch(params UpdateParams) (todorepo.Patch, bool, error) {
+	paths := params.Paths
+	if len(paths) == 0 {
+		paths = defaultUpdateFields
+	}
+	var (
+		patch           todorepo.Patch
+		scheduleChanged bool
+	)
+	for _, path := range paths {
+		switch path {
+		case FieldTitle:
+			if params.Title == "" {
+				return todorepo.Patch{}, false, fmt.Errorf("%w: title is required", ErrValidation)
+			}
+			patch.Title = &params.Title
+		case FieldDescription:
+			patch.Description = &params.Description
+		case FieldCompleted:
+			patch.Completed = &params.Completed
+		case FieldPriority:
+			if !params.Priority.Valid() {
+				return todorepo.Patch{}, false, fmt.Errorf("%w: unknown priority %d", ErrValidation, params.Priority)
+			}
+			patch.Priority = &params.Priority
+		case FieldProjectID:
+			patch.ProjectID = &sql.NullString{String: params.ProjectID, Valid: params.ProjectID != ""}
+		case FieldParentID:
+			patch.ParentID = &sql.NullString{String: params.ParentID, Valid: params.ParentID != ""}
+		case FieldRecurrence:
+			rule, err := parseRecurrence(params.Recurrence)
+			if err != nil {
+				return todorepo.Patch{}, false, err
+			}
+			patch.Recurrence = &rule
+		case FieldRecurrenceMode:
+			if err := validateRecurrenceMode(params.RecurrenceMode); err != nil {
+				return todorepo.Patch{}, false, err
+			}
+			patch.RecurrenceMode = &params.RecurrenceMode
+		case FieldTags:
+			tags, err := normalizeTags(params.Tags)
+			if err != nil {
+				return todorepo.Patch{}, false, err
+			}
+			patch.Tags = &tags
+		case FieldDueAt, FieldStartAt, FieldAllDay, FieldTimeZone:
+			scheduleChanged = true
+		default:
+			return todorepo.Patch{}, false, fmt.Errorf("%w: unknown update_mask path %q", ErrValidation, path)
+		}
+	}
+	return patch, scheduleChanged, nil
+}
+
+// mergeSchedule накладывает поля сроков из маски на текущие сроки задачи.
+func mergeSchedule(cur Schedule, params UpdateParams) Schedule {
+	prev := cur
+	var dueSet, startSet bool
+	for _, path := range params.Paths {
+		switch path {
+		case FieldDueAt:
+			cur.DueAt, dueSet = params.Schedule.DueAt, true
+		case FieldStartAt:
+			cur.StartAt, startSet = params.Schedule.StartAt, true
+		case FieldAllDay:
+			cur.AllDay = params.Schedule.AllDay
+		case FieldTimeZone:
+			cur.TimeZone = params.Schedule.TimeZone
+		}
+	}
+	// Задача на весь день хранит начало дня в своём поясе; при смене пояса
+	// нетронутые даты переносятся так, чтобы сохранился календарный день.
+	if prev.AllDay && cur.AllDay && prev.TimeZone != cur.TimeZone {
+		if !dueSet {
+			cur.DueAt = sameDate(prev.DueAt, prev.TimeZone, cur.TimeZone)
+		}
+		if !startSet {
+			cur.StartAt = sameDate(prev.StartAt, prev.TimeZone, cur.TimeZone)
+		}
+	}
+	return cur
+}
+
+func optionalString(v string) *string {
+	if v == "" {
+		return nil
+	}
+	return &v
+}
+
+// Delete удаляет задачу по идентификатору. Если expectedVersion больше нуля,
+// задача удаляется только при совпадении версии.
+func (s *Service) Delete(ctx context.Context, id string, expectedVersion int64) error {
+	id, err := s.prepareDelete(ctx, id, expectedVersion)
+	if err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id, expectedVersion)
+}
+
+// prepareDelete проверяет запрос на удаление и права на него и возвращает
+// идентификатор задачи в каноническом виде.
+func (s *Service) prepareDelete(ctx context.Context, id string, expectedVersion int64) (string, error) {
+	id, err := canonicalID("id", id)
+	if err != nil {
+		return "", err
+	}
+	if expectedVersion < 0 {
+		return "", fmt.Errorf("%w: expected_version must not be negative", ErrValidation)
 	}
-	return s.repo.Delete(ctx, id)
+	return id, s.access.RequireTodo(ctx, id, access.RoleEditor)
 }
@@ -0,0 +1,78 @@
+package todo
+
+import (
+	"context"
+	"fmt"
+
+	"todo/internal/access"
+	"todo/internal/auth"
+)
+
+// Share выдаёт пользователю userID роль на задачу или меняет выданную.
+// Делиться задачей может только её владелец.
+func (s *Service) Share(ctx context.Context, id, userID string, role access.Role) (access.Grant, error) {
+	id, err := validateGrant(id, userID)
+	if err != nil {
+		return access.Grant{}, err
+	}
+	if !role.Valid() {
+		return access.Grant{}, fmt.Errorf("%w: unknown role %d", ErrValidation, role)
+	}
+	if err := s.access.RequireTodo(ctx, id, access.RoleOwner); err != nil {
+		return access.Grant{}, err
+	}
+	owner, err := s.access.TodoOwner(ctx, id)
+	if err != nil {
+		return access.Grant{}, err
+	}
+	if owner == userID {
+		return access.Grant{}, fmt.Errorf("%w: user %s already owns the todo", ErrValidation, userID)
+	}
+	return s.access.ShareTodo(ctx, id, userID, role)
+}
+
+// Unshare отзывает роль пользователя userID на задачу. Владелец может
+// отозвать любую роль, а пользователь — отказаться от своей.
+func (s *Service) Unshare(ctx context.Context, id, userID string) error {
+	id, err := validateGrant(id, userID)
+	if err != nil {
+		return err
+	}
+	principal, err := auth.Require(ctx)
+	if err != nil {
+		return err
+	}
+	min := access.RoleOwner
+	if userID == principal.Subject {
+		min = access.RoleViewer
+	}
+	if err := s.access.RequireTodo(ctx, id, min); err != nil {
+		return err
+	}
+	return s.access.UnshareTodo(ctx, id, userID)
+}
+
+// Collaborators возвращает владельца задачи и пользователей с ролями на неё.
+func (s *Service) Collaborators(ctx context.Context, id string) ([]access.Grant, error) {
+	id, err := canonicalID("id", id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.access.RequireTodo(ctx, id, access.RoleViewer); err != nil {
+		return nil, err
+	}
+	return s.access.TodoCollaborators(ctx, id)
+}
+
+// validateGrant проверяет запрос на роль и возвращает идентификатор задачи
+// в каноническом виде.
+func validateGrant(id, userID string) (string, error) {
+	id, err := canonicalID("id", id)
+	if err != nil {
+		return "", err
+	}
+	if userID == "" {
+		return "", fmt.Errorf("%w: user_id is required", ErrValidation)
+	}
+	return id, nil
+}
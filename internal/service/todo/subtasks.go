@@ -0,0 +1,124 @@
+package todo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"todo/internal/access"
+	todorepo "todo/internal/todo"
+)
+
+// CompletionPolicy определяет, как завершается задача с открытыми подзадачами.
+type CompletionPolicy int
+
+// Поддерживаемые политики завершения.
+const (
+	// CompleteSubtasks завершает открытые подзадачи вместе с родительской
+	// задачей. Если среди них есть заблокированные, повторяющиеся или
+	// недоступные вызывающему для изменения, задача не завершается.
+	CompleteSubtasks CompletionPolicy = iota
+	// RefuseOpenSubtasks запрещает завершать задачу, пока открыты её подзадачи.
+	RefuseOpenSubtasks
+)
+
+// TreeNode — задача с вложенными подзадачами и сводным прогрессом.
+type TreeNode struct {
+	Record   todorepo.Record
+	Children []*TreeNode
+	// Completed и Total считают всех потомков задачи, а не только прямых.
+	Completed int
+	Total     int
+}
+
+// Tree возвращает задачу со всеми подзадачами вне корзины.
+func (s *Service) Tree(ctx context.Context, id string) (*TreeNode, error) {
+	id, err := canonicalID("id", id)
+	if err != nil {
+		return nil, err
+	}
+	recs, err := s.repo.Tree(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make(map[string]*TreeNode, len(recs))
+	for _, rec := range recs {
+		nodes[rec.ID] = &TreeNode{Record: rec}
+	}
+	for _, rec := range recs {
+		if rec.ID == id || rec.ParentID == nil {
+			continue
+		}
+		if parent, ok := nodes[*rec.ParentID]; ok {
+			parent.Children = append(parent.Children, nodes[rec.ID])
+		}
+	}
+	root, ok := nodes[id]
+	if !ok {
+		return nil, todorepo.ErrNotFound
+	}
+	rollUp(root)
+	return root, nil
+}
+
+// rollUp подсчитывает прогресс узла по всем его потомкам.
+func rollUp(node *TreeNode) {
+	for _, child := range node.Children {
+		rollUp(child)
+		node.Total += child.Total + 1
+		node.Completed += child.Completed
+		if child.Record.Completed {
+			node.Completed++
+		}
+	}
+}
+
+// checkParent проверяет, что задачу todoID можно сделать подзадачей parentID:
+// родитель существует, не образуется цикл и не превышается максимальная глубина.
+// Для новой задачи todoID пуст.
+func (s *Service) checkParent(ctx context.Context, todoID, parentID string) error {
+	if todoID == parentID {
+		return fmt.Errorf("%w: todo cannot be its own parent", ErrValidation)
+	}
+	// Подзадачи добавляет тот, кто может изменять родителя.
+	if err := s.access.RequireTodo(ctx, parentID, access.RoleEditor); err != nil {
+		if errors.Is(err, access.ErrTodoNotFound) {
+			return fmt.Errorf("%w: parent todo %s not found", ErrValidation, parentID)
+		}
+		return err
+	}
+	ancestors, err := s.repo.Ancestors(ctx, parentID)
+	if err != nil {
+		if errors.Is(err, todorepo.ErrNotFound) {
+			return fmt.Errorf("%w: parent todo %s not found", ErrValidation, parentID)
+		}
+		return err
+	}
+	height := 1
+	if todoID != "" {
+		for _, ancestor := range ancestors {
+			if ancestor == todoID {
+				return fmt.Errorf("%w: parent %s is a subtask of the todo", ErrValidation, parentID)
+			}
+		}
+		if height, err = s.repo.SubtreeHeight(ctx, todoID); err != nil {
+			return err
+		}
+	}
+	if depth := len(ancestors) + height; depth > s.cfg.MaxDepth {
+		return fmt.Errorf("%w: subtask depth %d exceeds the limit of %d", ErrValidation, depth, s.cfg.MaxDepth)
+	}
+	return nil
+}
+
+// applyCompletionPolicy решает, что делать с открытыми подзадачами
+// при завершении задачи. Сами подзадачи проверяются в транзакции изменения.
+func (s *Service) applyCompletionPolicy(patch *todorepo.Patch) {
+	switch s.cfg.CompletionPolicy {
+	case RefuseOpenSubtasks:
+		patch.RefuseOpenSubtasks = true
+	default:
+		patch.CompleteSubtasks = true
+	}
+}
@@ -0,0 +1,79 @@
+package todo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"todo/internal/access"
+	todorepo "todo/internal/todo"
+)
+
+// maxTagLength ограничивает длину имени метки в символах.
+const maxTagLength = 64
+
+// AddTags добавляет задаче метки.
+func (s *Service) AddTags(ctx context.Context, id string, tags []string) (todorepo.Record, error) {
+	id, names, err := tagsForChange(id, tags)
+	if err != nil {
+		return todorepo.Record{}, err
+	}
+	if err := s.access.RequireTodo(ctx, id, access.RoleEditor); err != nil {
+		return todorepo.Record{}, err
+	}
+	return s.repo.AddTags(ctx, id, names)
+}
+
+// RemoveTags снимает с задачи метки.
+func (s *Service) RemoveTags(ctx context.Context, id string, tags []string) (todorepo.Record, error) {
+	id, names, err := tagsForChange(id, tags)
+	if err != nil {
+		return todorepo.Record{}, err
+	}
+	if err := s.access.RequireTodo(ctx, id, access.RoleEditor); err != nil {
+		return todorepo.Record{}, err
+	}
+	return s.repo.RemoveTags(ctx, id, names)
+}
+
+// ListTags возвращает используемые метки с числом задач.
+func (s *Service) ListTags(ctx context.Context) ([]todorepo.TagUsage, error) {
+	return s.repo.ListTags(ctx)
+}
+
+func tagsForChange(id string, tags []string) (string, []string, error) {
+	id, err := canonicalID("id", id)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(tags) == 0 {
+		return "", nil, fmt.Errorf("%w: at least one tag is required", ErrValidation)
+	}
+	names, err := normalizeTags(tags)
+	return id, names, err
+}
+
+// normalizeTags приводит имена меток к нижнему регистру, схлопывает пробелы,
+// убирает повторы и сортирует, чтобы метки сравнивались без учёта регистра.
+func normalizeTags(tags []string) ([]string, error) {
+	seen := make(map[string]struct{}, len(tags))
+	out := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		name := strings.ToLower(strings.Join(strings.Fields(tag), " "))
+		if name == "" {
+			return nil, fmt.Errorf("%w: tag must not be empty", ErrValidation)
+		}
+		if utf8.RuneCountInString(name) > maxTagLength {
+			return nil, fmt.Errorf("%w: tag %q is longer than %d characters", ErrValidation, name, maxTagLength)
+		}
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out, nil
+}
@@ -0,0 +1,255 @@
+package todo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	todorepo "todo/internal/todo"
+	"todo/internal/uuid"
+)
+
+// ImportMode определяет, как загрузка поступает с идентификаторами задач.
+type ImportMode int
+
+// Поддерживаемые режимы загрузки.
+const (
+	// ImportCreate создаёт из каждой строки новую задачу; id из файла не используется.
+	ImportCreate ImportMode = iota + 1
+	// ImportUpsert изменяет задачу с id из строки, а если её нет — создаёт
+	// задачу с этим id; строка без id создаёт новую задачу.
+	ImportUpsert
+)
+
+// Valid сообщает, поддерживается ли режим.
+func (m ImportMode) Valid() bool {
+	return m == ImportCreate || m == ImportUpsert
+}
+
+const (
+	// exportPageSize — сколько задач выгрузка читает из базы за раз.
+	exportPageSize = 500
+	// MaxImportErrors — сколько ошибок строк возвращает загрузка; остальные только считаются.
+	MaxImportErrors = 100
+)
+
+// importFields — поля, которые загрузка в режиме upsert переносит в существующую задачу.
+var importFields = []string{
+	FieldTitle, FieldDescription, FieldCompleted, FieldDueAt, FieldStartAt, FieldAllDay, FieldTimeZone,
+	FieldPriority, FieldProjectID, FieldParentID, FieldRecurrence, FieldRecurrenceMode, FieldTags,
+}
+
+// ExportParams описывает выгрузку; правила отбора те же, что у List.
+type ExportParams struct {
+	Filter todorepo.ListFilter
+	Due    DueFilter
+	Format Format
+}
+
+// Export пишет в w все задачи по фильтру, от старых к новым, чтобы
+// родительские задачи шли раньше подзадач. Задачи читаются из базы
+// страницами, поэтому память не растёт с размером выгрузки.
+func (s *Service) Export(ctx context.Context, params ExportParams, w io.Writer) error {
+	if !params.Format.Valid() {
+		return fmt.Errorf("%w: unknown format", ErrValidation)
+	}
+	filter, err := prepareFilter(params.Filter, params.Due, time.Now())
+	if err != nil {
+		return err
+	}
+	enc, err := newRowEncoder(params.Format, w)
+	if err != nil {
+		return err
+	}
+	err = s.eachRecord(ctx, filter, func(rec todorepo.Record) error {
+		return enc.encode(rowOf(rec))
+	})
+	if err != nil {
+		return err
+	}
+	return enc.flush()
+}
+
+// eachRecord вызывает fn для задач по фильтру от старых к новым, читая их
+// из базы страницами.
+func (s *Service) eachRecord(ctx context.Context, filter todorepo.ListFilter, fn func(todorepo.Record) error) error {
+	var after *todorepo.Cursor
+	for {
+		recs, err := s.repo.List(ctx, todorepo.ListParams{
+			Filter: filter,
+			Order:  todorepo.OrderCreatedAsc,
+			Limit:  exportPageSize,
+			After:  after,
+		})
+		if err != nil {
+			return err
+		}
+		for _, rec := range recs {
+			if err := fn(rec); err != nil {
+				return err
+			}
+		}
+		if len(recs) < exportPageSize {
+			return nil
+		}
+		cursor := todorepo.CursorFor(recs[len(recs)-1])
+		after = &cursor
+	}
+}
+
+// RowError — ошибка одной строки файла загрузки.
+type RowError struct {
+	// Row — номер строки файла, начиная с 1.
+	Row int64
+	Err error
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// ImportParams описывает загрузку.
+type ImportParams struct {
+	Format Format
+	Mode   ImportMode
+}
+
+// ImportResult — итог загрузки.
+type ImportResult struct {
+	Created int64
+	Updated int64
+	// Failed — число строк, которые не удалось загрузить.
+	Failed int64
+	// Errors содержит ошибки первых MaxImportErrors строк.
+	Errors []RowError
+}
+
+func (r *ImportResult) fail(err RowError) {
+	r.Failed++
+	if len(r.Errors) < MaxImportErrors {
+		r.Errors = append(r.Errors, err)
+	}
+}
+
+// Import загружает задачи из r построчно: каждая строка проверяется и
+// записывается по правилам Create и Update отдельно, а ошибка строки не
+// прерывает загрузку. Файл читается потоком, поэтому память не растёт с его
+// размером. Ошибка чтения прерывает загрузку, но уже записанные строки остаются.
+func (s *Service) Import(ctx context.Context, params ImportParams, r io.Reader) (ImportResult, error) {
+	if !params.Format.Valid() {
+		return ImportResult{}, fmt.Errorf("%w: unknown format", ErrValidation)
+	}
+	if !params.Mode.Valid() {
+		return ImportResult{}, fmt.Errorf("%w: unknown import mode", ErrValidation)
+	}
+	dec, err := newRowDecoder(params.Format, r)
+	if err != nil {
+		return ImportResult{}, err
+	}
+	var res ImportResult
+	for {
+		row, line, err := dec.next()
+		if errors.Is(err, io.EOF) {
+			return res, nil
+		}
+		var rowErr *RowError
+		if errors.As(err, &rowErr) {
+			res.fail(*rowErr)
+			continue
+		}
+		if err != nil {
+			return ImportResult{}, err
+		}
+
+		created, err := s.importRow(ctx, params.Mode, row)
+		switch {
+		case err != nil && ctx.Err() != nil:
+			return ImportResult{}, ctx.Err()
+		case err != nil:
+			res.fail(RowError{Row: line, Err: err})
+		case created:
+			res.Created++
+		default:
+			res.Updated++
+		}
+	}
+}
+
+// importRow записывает строку и сообщает, была ли создана новая задача.
+func (s *Service) importRow(ctx context.Context, mode ImportMode, row transferRow) (bool, error) {
+	priority, err := row.priority()
+	if err != nil {
+		return false, err
+	}
+	recurrenceMode, err := row.recurrenceMode()
+	if err != nil {
+		return false, err
+	}
+	schedule := Schedule{DueAt: row.DueAt, StartAt: row.StartAt, AllDay: row.AllDay, TimeZone: row.TimeZone}
+	if mode == ImportCreate || row.ID == "" {
+		return true, s.importCreate(ctx, nil, row, schedule, priority, recurrenceMode)
+	}
+	u, err := uuid.Parse(row.ID)
+	if err != nil {
+		return false, fmt.Errorf("%w: id %q is not a UUID", ErrValidation, row.ID)
+	}
+	row.ID = u.String()
+
+	_, err = s.repo.Get(ctx, row.ID)
+	if errors.Is(err, todorepo.ErrNotFound) {
+		err := s.importCreate(ctx, &row.ID, row, schedule, priority, recurrenceMode)
+		if errors.Is(err, todorepo.ErrAlreadyExists) {
+			// Идентификатор занят задачей, которую вызывающий не видит или
+			// которая лежит в корзине; сообщать об этом нельзя.
+			return false, todorepo.ErrNotFound
+		}
+		return true, err
+	}
+	if err != nil {
+		return false, err
+	}
+	_, err = s.Update(ctx, UpdateParams{
+		ID:             row.ID,
+		Title:          row.Title,
+		Description:    row.Description,
+		Completed:      row.Completed,
+		Schedule:       schedule,
+		Priority:       priority,
+		ProjectID:      row.ProjectID,
+		ParentID:       row.ParentID,
+		Recurrence:     row.Recurrence,
+		RecurrenceMode: recurrenceMode,
+		Tags:           row.Tags,
+		Paths:          importFields,
+	})
+	return false, err
+}
+
+// importCreate создаёт задачу из строки; id, если задан, становится её идентификатором.
+func (s *Service) importCreate(ctx context.Context, id *string, row transferRow, schedule Schedule,
+	priority todorepo.Priority, recurrenceMode todorepo.RecurrenceMode) error {
+	prepared, err := s.prepareCreate(ctx, CreateParams{
+		Title:          row.Title,
+		Description:    row.Description,
+		Schedule:       schedule,
+		Priority:       priority,
+		Tags:           row.Tags,
+		ProjectID:      row.ProjectID,
+		ParentID:       row.ParentID,
+		Recurrence:     row.Recurrence,
+		RecurrenceMode: recurrenceMode,
+	})
+	if err != nil {
+		return err
+	}
+	prepared.ID = id
+	prepared.Completed = row.Completed
+	_, err = s.repo.Create(ctx, prepared)
+	return err
+}
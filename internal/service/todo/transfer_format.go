@@ -0,0 +1,400 @@
+package todo
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	todorepo "todo/internal/todo"
+)
+
+// Format — формат файла выгрузки и загрузки задач.
+type Format int
+
+// Поддерживаемые форматы.
+const (
+	// FormatNDJSON — один JSON-объект задачи на строку.
+	FormatNDJSON Format = iota + 1
+	// FormatCSV — CSV со строкой заголовка; метки записываются JSON-массивом.
+	FormatCSV
+)
+
+// Valid сообщает, поддерживается ли формат.
+func (f Format) Valid() bool {
+	return f == FormatNDJSON || f == FormatCSV
+}
+
+// maxImportLine ограничивает длину строки NDJSON в байтах.
+const maxImportLine = 1 << 20
+
+// transferRow — задача в файле. Поля owner_id, version, created_at и
+// updated_at только выгружаются: при загрузке они не используются.
+type transferRow struct {
+	ID             string     `json:"id"`
+	Title          string     `json:"title"`
+	Description    string     `json:"description"`
+	Completed      bool       `json:"completed"`
+	Priority       string     `json:"priority"`
+	DueAt          *time.Time `json:"due_at"`
+	StartAt        *time.Time `json:"start_at"`
+	AllDay         bool       `json:"all_day"`
+	TimeZone       string     `json:"time_zone"`
+	Tags           []string   `json:"tags"`
+	ProjectID      string     `json:"project_id"`
+	ParentID       string     `json:"parent_id"`
+	Recurrence     string     `json:"recurrence"`
+	RecurrenceMode string     `json:"recurrence_mode"`
+	OwnerID        string     `json:"owner_id"`
+	Version        int64      `json:"version"`
+	CreatedAt      *time.Time `json:"created_at"`
+	UpdatedAt      *time.Time `json:"updated_at"`
+}
+
+// transferColumns — колонки CSV в порядке выгрузки.
+var transferColumns = []string{
+	"id", "title", "description", "completed", "priority", "due_at", "start_at", "all_day", "time_zone", "tags",
+	"project_id", "parent_id", "recurrence", "recurrence_mode", "owner_id", "version", "created_at", "updated_at",
+}
+
+var priorityNames = map[todorepo.Priority]string{
+	todorepo.PriorityNone:   "none",
+	todorepo.PriorityLow:    "low",
+	todorepo.PriorityMedium: "medium",
+	todorepo.PriorityHigh:   "high",
+	todorepo.PriorityUrgent: "urgent",
+}
+
+var recurrenceModeNames = map[todorepo.RecurrenceMode]string{
+	todorepo.RecurrenceSpawn:   "spawn",
+	todorepo.RecurrenceAdvance: "advance",
+}
+
+func rowOf(rec todorepo.Record) transferRow {
+	row := transferRow{
+		ID:             rec.ID,
+		Title:          rec.Title,
+		Description:    rec.Description,
+		Completed:      rec.Completed,
+		Priority:       priorityNames[rec.Priority],
+		DueAt:          rec.DueAt,
+		StartAt:        rec.StartAt,
+		AllDay:         rec.AllDay,
+		TimeZone:       rec.TimeZone,
+		Tags:           rec.Tags,
+		Recurrence:     rec.Recurrence,
+		RecurrenceMode: recurrenceModeNames[rec.RecurrenceMode],
+		OwnerID:        rec.OwnerID,
+		Version:        rec.Version,
+		CreatedAt:      &rec.CreatedAt,
+		UpdatedAt:      &rec.UpdatedAt,
+	}
+	if row.Tags == nil {
+		row.Tags = []string{}
+	}
+	if rec.ProjectID != nil {
+		row.ProjectID = *rec.ProjectID
+	}
+	if rec.ParentID != nil {
+		row.ParentID = *rec.ParentID
+	}
+	return row
+}
+
+// priority разбирает имя приоритета; пустое имя означает «без приоритета».
+func (r transferRow) priority() (todorepo.Priority, error) {
+	if r.Priority == "" {
+		return todorepo.PriorityNone, nil
+	}
+	for p, name := range priorityNames {
+		if name == r.Priority {
+			return p, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: unknown priority %q", ErrValidation, r.Priority)
+}
+
+// recurrenceMode разбирает режим повторения; пустое имя означает spawn.
+func (r transferRow) recurrenceMode() (todorepo.RecurrenceMode, error) {
+	if r.RecurrenceMode == "" {
+		return todorepo.RecurrenceSpawn, nil
+	}
+	for m, name := range recurrenceModeNames {
+		if name == r.RecurrenceMode {
+			return m, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: unknown recurrence_mode %q", ErrValidation, r.RecurrenceMode)
+}
+
+// rowEncoder записывает задачи в файл выгрузки.
+type rowEncoder interface {
+	encode(row transferRow) error
+	// flush дописывает буферизованные данные; вызывается в конце выгрузки.
+	flush() error
+}
+
+func newRowEncoder(format Format, w io.Writer) (rowEncoder, error) {
+	if format == FormatCSV {
+		cw := csv.NewWriter(w)
+		if err := cw.Write(transferColumns); err != nil {
+			return nil, err
+		}
+		return &csvEncoder{w: cw, record: make([]string, len(transferColumns))}, nil
+	}
+	return &ndjsonEncoder{enc: json.NewEncoder(w)}, nil
+}
+
+type ndjsonEncoder struct {
+	enc *json.Encoder
+}
+
+func (e *ndjsonEncoder) encode(row transferRow) error {
+	return e.enc.Encode(row)
+}
+
+func (e *ndjsonEncoder) flush() error {
+	return nil
+}
+
+type csvEncoder struct {
+	w      *csv.Writer
+	record []string
+}
+
+func (e *csvEncoder) encode(row transferRow) error {
+	tags, err := json.Marshal(row.Tags)
+	if err != nil {
+		return err
+	}
+	for i, column := range transferColumns {
+		var v string
+		switch column {
+		case "id":
+			v = row.ID
+		case "title":
+			v = row.Title
+		case "description":
+			v = row.Description
+		case "completed":
+			v = strconv.FormatBool(row.Completed)
+		case "priority":
+			v = row.Priority
+		case "due_at":
+			v = formatTime(row.DueAt)
+		case "start_at":
+			v = formatTime(row.StartAt)
+		case "all_day":
+			v = strconv.FormatBool(row.AllDay)
+		case "time_zone":
+			v = row.TimeZone
+		case "tags":
+			v = string(tags)
+		case "project_id":
+			v = row.ProjectID
+		case "parent_id":
+			v = row.ParentID
+		case "recurrence":
+			v = row.Recurrence
+		case "recurrence_mode":
+			v = row.RecurrenceMode
+		case "owner_id":
+			v = row.OwnerID
+		case "version":
+			v = strconv.FormatInt(row.Version, 10)
+		case "created_at":
+			v = formatTime(row.CreatedAt)
+		case "updated_at":
+			v = formatTime(row.UpdatedAt)
+		}
+		e.record[i] = v
+	}
+	return e.w.Write(e.record)
+}
+
+func (e *csvEncoder) flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// rowDecoder читает задачи из файла загрузки по одной, не загружая файл целиком.
+type rowDecoder interface {
+	// next возвращает очередную задачу и номер её строки в файле, начиная с 1,
+	// или io.EOF в конце файла. Ошибка разбора одной строки имеет тип
+	// *RowError, и после неё чтение можно продолжить.
+	next() (transferRow, int64, error)
+}
+
+func newRowDecoder(format Format, r io.Reader) (rowDecoder, error) {
+	if format == FormatCSV {
+		return newCSVDecoder(r)
+	}
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64<<10), maxImportLine)
+	return &ndjsonDecoder{sc: sc}, nil
+}
+
+type ndjsonDecoder struct {
+	sc   *bufio.Scanner
+	line int64
+}
+
+func (d *ndjsonDecoder) next() (transferRow, int64, error) {
+	for d.sc.Scan() {
+		d.line++
+		data := bytes.TrimSpace(d.sc.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		var row transferRow
+		if err := dec.Decode(&row); err != nil {
+			return transferRow{}, d.line, &RowError{Row: d.line, Err: fmt.Errorf("%w: %v", ErrValidation, err)}
+		}
+		return row, d.line, nil
+	}
+	if err := d.sc.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return transferRow{}, 0, fmt.Errorf("%w: line %d is longer than %d bytes", ErrValidation, d.line+1, maxImportLine)
+		}
+		return transferRow{}, 0, err
+	}
+	return transferRow{}, 0, io.EOF
+}
+
+type csvDecoder struct {
+	r *csv.Reader
+	// columns сопоставляет номер колонки файла с её именем.
+	columns []string
+}
+
+func newCSVDecoder(r io.Reader) (*csvDecoder, error) {
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: csv header is required", ErrValidation)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: csv header: %v", ErrValidation, err)
+	}
+	known := make(map[string]bool, len(transferColumns))
+	for _, column := range transferColumns {
+		known[column] = true
+	}
+	columns := make([]string, len(header))
+	seen := make(map[string]bool, len(header))
+	for i, column := range header {
+		if !known[column] {
+			return nil, fmt.Errorf("%w: unknown csv column %q", ErrValidation, column)
+		}
+		if seen[column] {
+			return nil, fmt.Errorf("%w: csv column %q appears more than once", ErrValidation, column)
+		}
+		seen[column] = true
+		columns[i] = column
+	}
+	if !seen["title"] {
+		return nil, fmt.Errorf("%w: csv column \"title\" is required", ErrValidation)
+	}
+	return &csvDecoder{r: cr, columns: columns}, nil
+}
+
+func (d *csvDecoder) next() (transferRow, int64, error) {
+	record, err := d.r.Read()
+	if errors.Is(err, io.EOF) {
+		return transferRow{}, 0, io.EOF
+	}
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		line := int64(parseErr.StartLine)
+		return transferRow{}, line, &RowError{Row: line, Err: fmt.Errorf("%w: %v", ErrValidation, parseErr.Err)}
+	}
+	if err != nil {
+		return transferRow{}, 0, err
+	}
+	lineNo, _ := d.r.FieldPos(0)
+	line := int64(lineNo)
+	row, err := d.decode(record)
+	if err != nil {
+		return transferRow{}, line, &RowError{Row: line, Err: err}
+	}
+	return row, line, nil
+}
+
+func (d *csvDecoder) decode(record []string) (transferRow, error) {
+	var (
+		row transferRow
+		err error
+	)
+	for i, v := range record {
+		column := d.columns[i]
+		switch column {
+		case "id":
+			row.ID = v
+		case "title":
+			row.Title = v
+		case "description":
+			row.Description = v
+		case "completed":
+			row.Completed, err = parseBool(v)
+		case "priority":
+			row.Priority = v
+		case "due_at":
+			row.DueAt, err = parseTime(v)
+		case "start_at":
+			row.StartAt, err = parseTime(v)
+		case "all_day":
+			row.AllDay, err = parseBool(v)
+		case "time_zone":
+			row.TimeZone = v
+		case "tags":
+			if v != "" {
+				err = json.Unmarshal([]byte(v), &row.Tags)
+			}
+		case "project_id":
+			row.ProjectID = v
+		case "parent_id":
+			row.ParentID = v
+		case "recurrence":
+			row.Recurrence = v
+		case "recurrence_mode":
+			row.RecurrenceMode = v
+		}
+		if err != nil {
+			return transferRow{}, fmt.Errorf("%w: column %s: %v", ErrValidation, column, err)
+		}
+	}
+	return row, nil
+}
+
+func parseBool(v string) (bool, error) {
+	if v == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(v)
+}
+
+func parseTime(v string) (*time.Time, error) {
+	if v == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, v)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,82 @@
+package todo
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"todo/internal/access"
+	todorepo "todo/internal/todo"
+)
+
+// Restore возвращает задачу из корзины.
+func (s *Service) Restore(ctx context.Context, id string) (todorepo.Record, error) {
+	id, err := canonicalID("id", id)
+	if err != nil {
+		return todorepo.Record{}, err
+	}
+	if err := s.access.RequireTodo(ctx, id, access.RoleEditor); err != nil {
+		return todorepo.Record{}, err
+	}
+	return s.repo.Restore(ctx, id)
+}
+
+// ListTrash возвращает страницу задач из корзины, начиная с недавно удалённых.
+func (s *Service) ListTrash(ctx context.Context, pageSize int, pageToken string) (ListResult, error) {
+	return s.List(ctx, ListParams{
+		PageSize:  pageSize,
+		PageToken: pageToken,
+		Filter:    todorepo.ListFilter{Trashed: true},
+		Order:     todorepo.OrderDeletedDesc,
+	})
+}
+
+// Purge окончательно удаляет задачу из корзины. Это доступно только владельцу.
+func (s *Service) Purge(ctx context.Context, id string) error {
+	id, err := canonicalID("id", id)
+	if err != nil {
+		return err
+	}
+	if err := s.access.RequireTodo(ctx, id, access.RoleOwner); err != nil {
+		return err
+	}
+	if err := s.repo.Purge(ctx, id); err != nil {
+		return err
+	}
+	s.afterPurge(ctx)
+	return nil
+}
+
+// RunTrashPurger раз в interval окончательно удаляет задачи, пролежавшие
+// в корзине дольше retention. Блокируется до отмены ctx.
+func (s *Service) RunTrashPurger(ctx context.Context, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		n, err := s.repo.PurgeDeletedBefore(ctx, time.Now().Add(-retention))
+		switch {
+		case err != nil && ctx.Err() == nil:
+			log.Printf("purge trash: %v", err)
+		case n > 0:
+			log.Printf("purged %d todos from trash", n)
+		}
+		// Очистка запускается и без удалённых задач: ресурсы, освобождённые
+		// недавно, становятся доступны для удаления не сразу.
+		s.afterPurge(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// afterPurge вызывает AfterPurge; её ошибка не отменяет уже выполненное удаление.
+func (s *Service) afterPurge(ctx context.Context) {
+	if s.cfg.AfterPurge == nil {
+		return
+	}
+	if err := s.cfg.AfterPurge(ctx); err != nil && ctx.Err() == nil {
+		log.Printf("after purge: %v", err)
+	}
+}
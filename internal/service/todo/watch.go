@@ -0,0 +1,138 @@
+package todo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"todo/internal/auth"
+	todorepo "todo/internal/todo"
+)
+
+const (
+	// watchBatchSize ограничивает число событий, читаемых из журнала за раз.
+	watchBatchSize = 500
+	// defaultWatchPollInterval используется, если интервал опроса не задан.
+	defaultWatchPollInterval = 30 * time.Second
+)
+
+// Notifier сообщает о новых событиях в журнале задач арендатора.
+type Notifier interface {
+	// Subscribe возвращает канал сигналов и функцию отписки.
+	Subscribe(tenant string) (<-chan struct{}, func())
+}
+
+// WatchParams описывает подписку на изменения задач.
+type WatchParams struct {
+	Filter todorepo.ListFilter
+	// Due вычисляется один раз при подписке.
+	Due DueFilter
+	// AfterSeq продолжает ленту после события с этим номером;
+	// 0 означает «только новые изменения».
+	AfterSeq int64
+}
+
+// WatchEvent — событие журнала, переданное подписчику.
+type WatchEvent struct {
+	todorepo.Event
+	// Record — текущее состояние задачи, которое может быть новее события;
+	// nil, если задача удалена окончательно, больше не видна вызывающему
+	// или ушла из подписки.
+	Record *todorepo.Record
+	// Left сообщает, что задача перестала подходить под фильтр подписки,
+	// стала невидимой или удалена окончательно. У такого события заданы
+	// только номер и идентификатор задачи: доступа к ней может уже не быть.
+	Left bool
+}
+
+// Watch передаёт в send изменения видимых вызывающему задач, подходящих
+// под фильтр, в порядке журнала, пока не отменён ctx или send не вернёт
+// ошибку. Задача, которая перестала подходить под фильтр, передаётся один
+// раз с признаком Left. Соответствие фильтру проверяется по текущему
+// состоянию задачи; при подписке оно берётся для всех задач сразу, поэтому
+// после переподключения уход задачи, случившийся без подписчика, не
+// передаётся. Номер события позволяет продолжить ленту после переподключения.
+func (s *Service) Watch(ctx context.Context, params WatchParams, send func(WatchEvent) error) error {
+	if params.AfterSeq < 0 {
+		return fmt.Errorf("%w: after_seq must not be negative", ErrValidation)
+	}
+	filter, err := prepareFilter(params.Filter, params.Due, time.Now())
+	if err != nil {
+		return err
+	}
+	principal, err := auth.Require(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Подписка оформляется до чтения журнала, чтобы не пропустить сигнал.
+	var wake <-chan struct{}
+	if s.cfg.Notifier != nil {
+		ch, unsubscribe := s.cfg.Notifier.Subscribe(principal.TenantID)
+		defer unsubscribe()
+		wake = ch
+	}
+	after := params.AfterSeq
+	if after == 0 {
+		if after, err = s.repo.LastSeq(ctx); err != nil {
+			return err
+		}
+	}
+	interval := s.cfg.WatchPollInterval
+	if interval <= 0 {
+		interval = defaultWatchPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	ids, err := s.repo.MatchingIDs(ctx, filter)
+	if err != nil {
+		return err
+	}
+	members := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		members[id] = true
+	}
+
+	for {
+		// Все события до последнего зафиксированного уже видны, поэтому
+		// неподходящие под фильтр не приходится перечитывать в следующий раз.
+		upto, err := s.repo.LastSeq(ctx)
+		if err != nil {
+			return err
+		}
+		events, err := s.repo.Feed(ctx, after, upto, watchBatchSize, filter)
+		if err != nil {
+			return err
+		}
+		for _, ev := range events {
+			out := WatchEvent{Event: ev.Event, Record: ev.Record}
+			switch {
+			case ev.Record != nil && ev.Matches:
+				members[ev.TodoID] = true
+			case members[ev.TodoID]:
+				delete(members, ev.TodoID)
+				out = WatchEvent{Event: todorepo.Event{Seq: ev.Seq, TodoID: ev.TodoID}, Left: true}
+			default:
+				after = ev.Seq
+				continue
+			}
+			if err := send(out); err != nil {
+				return err
+			}
+			after = ev.Seq
+		}
+		if len(events) == watchBatchSize {
+			continue
+		}
+		if upto > after {
+			after = upto
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-wake:
+		case <-ticker.C:
+		}
+	}
+}
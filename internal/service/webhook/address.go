@@ -0,0 +1,73 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// dialTimeout ограничивает установку соединения с получателем.
+const dialTimeout = 10 * time.Second
+
+// AddressPolicy решает, на какие адреса можно доставлять события. Внутренние
+// адреса — loopback, link-local, частные и служебные — запрещены, чтобы
+// вебхук нельзя было направить на сервисы рядом с сервером.
+type AddressPolicy struct {
+	// AllowedNetworks перечисляет сети, доставка в которые разрешена, даже
+	// если они внутренние, например для получателей в той же сети.
+	AllowedNetworks []netip.Prefix
+}
+
+// allowed сообщает, можно ли доставлять события на адрес addr.
+func (p AddressPolicy) allowed(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, network := range p.AllowedNetworks {
+		if network.Contains(addr) {
+			return true
+		}
+	}
+	return addr.IsGlobalUnicast() && !addr.IsPrivate()
+}
+
+// checkHost проверяет все адреса, в которые разрешается хост адреса вебхука.
+func (p AddressPolicy) checkHost(ctx context.Context, u *url.URL) error {
+	host := u.Hostname()
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("%w: cannot resolve host %q", ErrValidation, host)
+	}
+	for _, addr := range addrs {
+		if !p.allowed(addr) {
+			return fmt.Errorf("%w: host %q resolves to internal address %s", ErrValidation, host, addr)
+		}
+	}
+	return nil
+}
+
+// control проверяет адрес перед каждым соединением, уже после разрешения
+// имени: проверка при регистрации не защищает от смены записи DNS.
+func (p AddressPolicy) control(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	if !p.allowed(addrPort.Addr()) {
+		return fmt.Errorf("webhook address %s is not allowed", addrPort.Addr())
+	}
+	return nil
+}
+
+// transport возвращает транспорт, который соединяется только с разрешёнными
+// адресами. Прокси из окружения не используется: иначе проверялся бы адрес
+// прокси, а не получателя.
+func (p AddressPolicy) transport() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.Proxy = nil
+	t.DialContext = (&net.Dialer{Timeout: dialTimeout, Control: p.control}).DialContext
+	return t
+}
@@ -0,0 +1,196 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	webhookrepo "todo/internal/webhook"
+)
+
+// Заголовки запроса доставки.
+const (
+	// SignatureHeader содержит "sha256=" и HMAC-SHA256 в hex от строки
+	// "<timestamp>.<тело запроса>", вычисленный на секрете вебхука.
+	SignatureHeader = "X-Webhook-Signature"
+	// TimestampHeader содержит время отправки в unix timestamp; получателю
+	// стоит отклонять старые запросы, чтобы их нельзя было воспроизвести.
+	TimestampHeader = "X-Webhook-Timestamp"
+	// IDHeader содержит номер события, одинаковый во всех попытках доставки;
+	// по нему получатель отбрасывает повторы.
+	IDHeader = "X-Webhook-Id"
+	// EventHeader содержит тип события, например todo.update.
+	EventHeader = "X-Webhook-Event"
+)
+
+const (
+	// maxResponseBody ограничивает, сколько ответа получателя читается.
+	maxResponseBody = 64 << 10
+	// batchSize — сколько событий и доставок обрабатывается за один запрос.
+	batchSize = 100
+)
+
+// DispatcherConfig описывает доставку событий на вебхуки.
+type DispatcherConfig struct {
+	// PollInterval — как часто диспетчер проверяет новые события и повторы.
+	PollInterval time.Duration
+	// Timeout ограничивает одну попытку доставки.
+	Timeout time.Duration
+	// MaxAttempts — после стольких неудачных попыток доставка считается мёртвой.
+	MaxAttempts int
+	// BackoffBase — пауза после первой неудачи; дальше она удваивается.
+	BackoffBase time.Duration
+	// BackoffMax ограничивает паузу между попытками сверху.
+	BackoffMax time.Duration
+	// Addresses ограничивает адреса, с которыми соединяется диспетчер.
+	Addresses AddressPolicy
+}
+
+// Dispatcher доставляет события outbox на вебхуки.
+type Dispatcher struct {
+	repo   *webhookrepo.Repository
+	cfg    DispatcherConfig
+	client *http.Client
+}
+
+// NewDispatcher создаёт диспетчер; доставка начинается после запуска Run.
+func NewDispatcher(repo *webhookrepo.Repository, cfg DispatcherConfig) *Dispatcher {
+	return &Dispatcher{
+		repo: repo,
+		cfg:  cfg,
+		client: &http.Client{
+			Timeout:   cfg.Timeout,
+			Transport: cfg.Addresses.transport(),
+			// Перенаправление считается неудачей: иначе подписанное событие
+			// ушло бы на адрес, который владелец вебхука не указывал.
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// Run раскладывает события по вебхукам и доставляет их, пока не отменён ctx.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		if err := d.dispatch(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("dispatch webhooks: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatch раскладывает все накопившиеся события и выполняет наступившие
+// попытки доставки.
+func (d *Dispatcher) dispatch(ctx context.Context) error {
+	for {
+		n, err := d.repo.FanOut(ctx, batchSize)
+		if err != nil {
+			return fmt.Errorf("fan out events: %w", err)
+		}
+		if n < batchSize {
+			break
+		}
+	}
+	for {
+		// Доставка не успевшего завершиться диспетчера повторится после lease.
+		tasks, err := d.repo.Claim(ctx, batchSize, 2*d.cfg.Timeout)
+		if err != nil {
+			return fmt.Errorf("claim deliveries: %w", err)
+		}
+		var wg sync.WaitGroup
+		for _, task := range tasks {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				outcome := d.attempt(ctx, task)
+				if err := d.repo.Finish(ctx, task.DeliveryID, outcome); err != nil && ctx.Err() == nil {
+					log.Printf("record webhook delivery %d: %v", task.DeliveryID, err)
+				}
+			}()
+		}
+		wg.Wait()
+		if len(tasks) < batchSize {
+			return nil
+		}
+	}
+}
+
+// attempt отправляет событие и решает, повторять ли доставку.
+func (d *Dispatcher) attempt(ctx context.Context, task webhookrepo.Task) webhookrepo.Outcome {
+	code, err := d.post(ctx, task)
+	now := time.Now().UTC()
+	if err == nil {
+		return webhookrepo.Outcome{Status: webhookrepo.StatusSucceeded, StatusCode: code, At: now}
+	}
+	outcome := webhookrepo.Outcome{StatusCode: code, Error: err.Error(), At: now}
+	attempts := task.Attempts + 1
+	if attempts >= d.cfg.MaxAttempts {
+		outcome.Status = webhookrepo.StatusDead
+		return outcome
+	}
+	outcome.Status = webhookrepo.StatusPending
+	outcome.NextAttemptAt = now.Add(d.backoff(attempts))
+	return outcome
+}
+
+// post отправляет подписанное событие и возвращает код ответа; ошибка
+// означает, что событие не принято.
+func (d *Dispatcher) post(ctx context.Context, task webhookrepo.Task) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, task.URL, bytes.NewReader(task.Payload))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Sign(task.Secret, timestamp, task.Payload))
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(IDHeader, strconv.FormatInt(task.EventID, 10))
+	req.Header.Set(EventHeader, task.EventType)
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBody))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// backoff возвращает паузу после attempts неудачных попыток.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.cfg.BackoffBase
+	for i := 1; i < attempts && delay < d.cfg.BackoffMax; i++ {
+		delay *= 2
+	}
+	return min(delay, d.cfg.BackoffMax)
+}
+
+// Sign вычисляет подпись доставки для заголовка SignatureHeader.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
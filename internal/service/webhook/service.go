@@ -0,0 +1,157 @@
+// Package webhook содержит бизнес-логику управления вебхуками и их доставки.
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	webhookrepo "todo/internal/webhook"
+)
+
+// ErrValidation сигнализирует о нарушениях входных данных.
+var ErrValidation = errors.New("validation error")
+
+const (
+	// DefaultPageSize используется, если клиент не указал размер страницы.
+	DefaultPageSize = 50
+	// MaxPageSize ограничивает размер страницы сверху.
+	MaxPageSize = 500
+	// secretPrefix отличает секреты вебхуков от других секретов.
+	secretPrefix = "whsec_"
+	// secretBytes — энтропия секрета.
+	secretBytes = 32
+	// maxURLLength ограничивает длину адреса вебхука.
+	maxURLLength = 2048
+)
+
+// Service инкапсулирует операции над вебхуками на уровне бизнес-логики.
+type Service struct {
+	repo      *webhookrepo.Repository
+	addresses AddressPolicy
+}
+
+// NewService создает сервис вебхуков; addresses ограничивает адреса вебхуков.
+func NewService(repo *webhookrepo.Repository, addresses AddressPolicy) *Service {
+	return &Service{repo: repo, addresses: addresses}
+}
+
+// Create регистрирует вебхук вызывающего и возвращает его вместе с секретом
+// подписи. Вебхук получает изменения задач, которые видит вызывающий.
+// Хост адреса должен разрешаться только в адреса, разрешённые политикой.
+func (s *Service) Create(ctx context.Context, rawURL string) (webhookrepo.Record, string, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	u, err := validateURL(rawURL)
+	if err != nil {
+		return webhookrepo.Record{}, "", err
+	}
+	if err := s.addresses.checkHost(ctx, u); err != nil {
+		return webhookrepo.Record{}, "", err
+	}
+	raw := make([]byte, secretBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return webhookrepo.Record{}, "", fmt.Errorf("generate secret: %w", err)
+	}
+	secret := secretPrefix + base64.RawURLEncoding.EncodeToString(raw)
+	rec, err := s.repo.Create(ctx, rawURL, secret)
+	if err != nil {
+		return webhookrepo.Record{}, "", err
+	}
+	return rec, secret, nil
+}
+
+// List возвращает вебхуки вызывающего.
+func (s *Service) List(ctx context.Context) ([]webhookrepo.Record, error) {
+	return s.repo.List(ctx)
+}
+
+// Delete удаляет вебхук вызывающего; недоставленные события на него теряются.
+func (s *Service) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return fmt.Errorf("%w: id is required", ErrValidation)
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// DeliveriesResult содержит страницу журнала доставок и курсор следующей страницы.
+type DeliveriesResult struct {
+	Items         []webhookrepo.Delivery
+	NextPageToken string
+}
+
+// deliveriesToken — содержимое непрозрачного курсора журнала доставок.
+type deliveriesToken struct {
+	ID int64 `json:"i"`
+}
+
+// Deliveries возвращает страницу журнала доставок на вебхук, начиная с новых.
+func (s *Service) Deliveries(ctx context.Context, webhookID string, pageSize int, pageToken string) (DeliveriesResult, error) {
+	if webhookID == "" {
+		return DeliveriesResult{}, fmt.Errorf("%w: webhook_id is required", ErrValidation)
+	}
+	switch {
+	case pageSize < 0:
+		return DeliveriesResult{}, fmt.Errorf("%w: page_size must not be negative", ErrValidation)
+	case pageSize == 0:
+		pageSize = DefaultPageSize
+	case pageSize > MaxPageSize:
+		pageSize = MaxPageSize
+	}
+	before, err := decodeDeliveriesToken(pageToken)
+	if err != nil {
+		return DeliveriesResult{}, err
+	}
+
+	items, err := s.repo.Deliveries(ctx, webhookID, pageSize+1, before)
+	if err != nil {
+		return DeliveriesResult{}, err
+	}
+	res := DeliveriesResult{Items: items}
+	if len(items) > pageSize {
+		res.Items = items[:pageSize]
+		data, err := json.Marshal(deliveriesToken{ID: res.Items[pageSize-1].ID})
+		if err != nil {
+			return DeliveriesResult{}, fmt.Errorf("encode page token: %w", err)
+		}
+		res.NextPageToken = base64.RawURLEncoding.EncodeToString(data)
+	}
+	return res, nil
+}
+
+// decodeDeliveriesToken разбирает курсор журнала; пустая строка означает первую страницу.
+func decodeDeliveriesToken(token string) (int64, error) {
+	if token == "" {
+		return 0, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, fmt.Errorf("%w: malformed page_token", ErrValidation)
+	}
+	var t deliveriesToken
+	if err := json.Unmarshal(data, &t); err != nil || t.ID <= 0 {
+		return 0, fmt.Errorf("%w: malformed page_token", ErrValidation)
+	}
+	return t.ID, nil
+}
+
+func validateURL(rawURL string) (*url.URL, error) {
+	if rawURL == "" {
+		return nil, fmt.Errorf("%w: url is required", ErrValidation)
+	}
+	if len(rawURL) > maxURLLength {
+		return nil, fmt.Errorf("%w: url is longer than %d bytes", ErrValidation, maxURLLength)
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("%w: url must be an absolute http or https url", ErrValidation)
+	}
+	if u.User != nil {
+		return nil, fmt.Errorf("%w: url must not contain credentials", ErrValidation)
+	}
+	return u, nil
+}
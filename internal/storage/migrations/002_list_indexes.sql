@@ -0,0 +1,6 @@
+-- Индексы под keyset-пагинацию и фильтры ListTodos.
+create index if not exists todos_created_at_id_idx on todos (created_at desc, id desc);
+create index if not exists todos_updated_at_id_idx on todos (updated_at desc, id desc);
+create index if not exists todos_title_id_idx on todos (title, id);
+create index if not exists todos_title_prefix_idx on todos (title text_pattern_ops);
+create index if not exists todos_completed_created_at_idx on todos (completed, created_at desc, id desc);
@@ -0,0 +1,2 @@
+-- Версия задачи для оптимистичной блокировки.
+alter table todos add column if not exists version bigint not null default 1;
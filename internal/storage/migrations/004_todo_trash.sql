@@ -0,0 +1,4 @@
+-- Мягкое удаление: задачи с deleted_at находятся в корзине.
+alter table todos add column if not exists deleted_at timestamptz;
+
+create index if not exists todos_trash_idx on todos (deleted_at desc, id desc) where deleted_at is not null;
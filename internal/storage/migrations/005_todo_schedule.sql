@@ -0,0 +1,10 @@
+-- Сроки задачи: начало, дедлайн, задачи на весь день и часовой пояс.
+alter table todos add column if not exists due_at timestamptz;
+alter table todos add column if not exists start_at timestamptz;
+alter table todos add column if not exists all_day boolean not null default false;
+alter table todos add column if not exists time_zone text not null default 'UTC';
+
+create index if not exists todos_due_at_idx on todos (due_at, id)
+    where due_at is not null and deleted_at is null;
+create index if not exists todos_open_due_at_idx on todos (due_at)
+    where due_at is not null and not completed and deleted_at is null;
@@ -0,0 +1,9 @@
+-- Приоритет задачи: 0 — не задан, 4 — срочно. Существующие задачи получают 0.
+alter table todos add column if not exists priority smallint;
+update todos set priority = 0 where priority is null;
+alter table todos alter column priority set default 0;
+alter table todos alter column priority set not null;
+
+create index if not exists todos_priority_idx
+    on todos (priority desc, (coalesce(due_at, 'infinity'::timestamptz)), created_at desc, id desc)
+    where deleted_at is null;
@@ -0,0 +1,14 @@
+-- Метки задач: имена хранятся в нормализованном виде (нижний регистр).
+create table if not exists tags (
+    id uuid primary key default gen_random_uuid(),
+    name text not null unique,
+    created_at timestamptz not null default now()
+);
+
+create table if not exists todo_tags (
+    todo_id uuid not null references todos (id) on delete cascade,
+    tag_id uuid not null references tags (id) on delete cascade,
+    primary key (todo_id, tag_id)
+);
+
+create index if not exists todo_tags_tag_id_idx on todo_tags (tag_id, todo_id);
@@ -0,0 +1,13 @@
+-- Проекты объединяют задачи; задача без проекта находится во «Входящих».
+create table if not exists projects (
+    id uuid primary key default gen_random_uuid(),
+    name text not null,
+    description text not null default '',
+    created_at timestamptz not null default now(),
+    updated_at timestamptz not null default now()
+);
+
+alter table todos add column if not exists project_id uuid references projects (id) on delete set null;
+
+create index if not exists todos_project_id_idx on todos (project_id, created_at desc, id desc)
+    where deleted_at is null;
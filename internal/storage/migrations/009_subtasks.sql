@@ -0,0 +1,4 @@
+-- Подзадачи: parent_id указывает на родительскую задачу.
+alter table todos add column if not exists parent_id uuid references todos (id) on delete set null;
+
+create index if not exists todos_parent_id_idx on todos (parent_id) where parent_id is not null;
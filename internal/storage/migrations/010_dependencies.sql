@@ -0,0 +1,10 @@
+-- Зависимости задач: todo_id заблокирована, пока не завершена blocker_id.
+create table if not exists todo_dependencies (
+    todo_id uuid not null references todos (id) on delete cascade,
+    blocker_id uuid not null references todos (id) on delete cascade,
+    created_at timestamptz not null default now(),
+    primary key (todo_id, blocker_id),
+    check (todo_id <> blocker_id)
+);
+
+create index if not exists todo_dependencies_blocker_id_idx on todo_dependencies (blocker_id, todo_id);
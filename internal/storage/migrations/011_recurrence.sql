@@ -0,0 +1,8 @@
+-- Повторяющиеся задачи: правило RRULE, режим повторения и серия, к которой
+-- относятся все вхождения. series_start — DTSTART серии для разворота правила.
+alter table todos add column if not exists recurrence text not null default '';
+alter table todos add column if not exists recurrence_mode smallint not null default 0;
+alter table todos add column if not exists series_id uuid;
+alter table todos add column if not exists series_start timestamptz;
+
+create index if not exists todos_series_id_idx on todos (series_id, created_at) where series_id is not null;
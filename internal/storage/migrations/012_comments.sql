@@ -0,0 +1,13 @@
+-- Комментарии к задачам. deleted_at совпадает с deleted_at задачи, пока она
+-- в корзине; при окончательном удалении задачи комментарии удаляются каскадно.
+create table if not exists comments (
+    id uuid primary key default gen_random_uuid(),
+    todo_id uuid not null references todos (id) on delete cascade,
+    author text not null,
+    body text not null,
+    created_at timestamptz not null default now(),
+    edited_at timestamptz,
+    deleted_at timestamptz
+);
+
+create index if not exists comments_todo_id_idx on comments (todo_id, created_at, id);
@@ -0,0 +1,21 @@
+-- Вложения задач. Содержимое хранится в blob-хранилище по SHA-256: одинаковые
+-- файлы хранятся один раз, а таблица blobs учитывает, на какие хеши есть ссылки.
+create table if not exists blobs (
+    sha256 text primary key,
+    size bigint not null,
+    created_at timestamptz not null default now(),
+    last_used_at timestamptz not null default now()
+);
+
+create table if not exists attachments (
+    id uuid primary key default gen_random_uuid(),
+    todo_id uuid not null references todos (id) on delete cascade,
+    filename text not null,
+    content_type text not null,
+    size bigint not null,
+    sha256 text not null references blobs (sha256),
+    created_at timestamptz not null default now()
+);
+
+create index if not exists attachments_todo_id_idx on attachments (todo_id, created_at, id);
+create index if not exists attachments_sha256_idx on attachments (sha256);
@@ -0,0 +1,14 @@
+-- Арендаторы и владельцы: существующие задачи и проекты переходят к арендатору по умолчанию.
+alter table todos add column if not exists tenant_id text not null default 'default';
+alter table todos add column if not exists owner_id text not null default 'default';
+alter table todos alter column tenant_id drop default;
+alter table todos alter column owner_id drop default;
+
+alter table projects add column if not exists tenant_id text not null default 'default';
+alter table projects add column if not exists owner_id text not null default 'default';
+alter table projects alter column tenant_id drop default;
+alter table projects alter column owner_id drop default;
+
+create index if not exists todos_tenant_created_idx on todos (tenant_id, created_at desc, id desc)
+    where deleted_at is null;
+create index if not exists projects_tenant_name_idx on projects (tenant_id, name, id);
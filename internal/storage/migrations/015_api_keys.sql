@@ -0,0 +1,16 @@
+-- Ключи API: хранится только SHA-256 секрета, сам секрет показывается один раз при создании.
+create table if not exists api_keys (
+    id uuid primary key default gen_random_uuid(),
+    tenant_id text not null,
+    owner_id text not null,
+    name text not null,
+    prefix text not null,
+    secret_hash text not null unique,
+    scope smallint not null,
+    created_at timestamptz not null default now(),
+    expires_at timestamptz,
+    last_used_at timestamptz,
+    revoked_at timestamptz
+);
+
+create index if not exists api_keys_owner_idx on api_keys (tenant_id, owner_id, created_at desc, id desc);
@@ -0,0 +1,20 @@
+-- Совместный доступ: роли 1 — просмотр, 2 — редактирование, 3 — владение.
+create table if not exists todo_grants (
+    todo_id uuid not null references todos (id) on delete cascade,
+    grantee_id text not null,
+    role smallint not null check (role between 1 and 3),
+    created_at timestamptz not null default now(),
+    primary key (todo_id, grantee_id)
+);
+
+create table if not exists project_grants (
+    project_id uuid not null references projects (id) on delete cascade,
+    grantee_id text not null,
+    role smallint not null check (role between 1 and 3),
+    created_at timestamptz not null default now(),
+    primary key (project_id, grantee_id)
+);
+
+create index if not exists todo_grants_grantee_idx on todo_grants (grantee_id, todo_id);
+create index if not exists project_grants_grantee_idx on project_grants (grantee_id, project_id);
+create index if not exists todos_owner_idx on todos (tenant_id, owner_id) where deleted_at is null;
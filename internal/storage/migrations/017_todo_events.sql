@@ -0,0 +1,25 @@
+-- Журнал изменений задач. Строки только добавляются и переживают окончательное
+-- удаление задачи, поэтому внешнего ключа на todos нет.
+create table if not exists todo_events (
+    seq bigserial primary key,
+    todo_id uuid not null,
+    tenant_id text not null,
+    actor text not null,
+    operation text not null check (operation in ('create', 'update', 'delete', 'restore')),
+    changes jsonb not null default '{}',
+    created_at timestamptz not null default now()
+);
+
+create index if not exists todo_events_todo_idx on todo_events (todo_id, seq);
+
+create or replace function todo_events_append_only() returns trigger
+language plpgsql as $$
+begin
+    raise exception 'todo_events is append-only';
+end;
+$$;
+
+drop trigger if exists todo_events_append_only on todo_events;
+create trigger todo_events_append_only
+    before update or delete on todo_events
+    for each row execute function todo_events_append_only();
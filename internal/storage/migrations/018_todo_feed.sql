@@ -0,0 +1,46 @@
+-- Лента изменений задач поверх журнала: события арендатора фиксируются
+-- в порядке seq, а реплики узнают о них через NOTIFY todo_events.
+
+-- Блокировка ленты арендатора; транзакция держит её до фиксации. Чтобы не
+-- было взаимных блокировок, пишущие журнал транзакции берут её первой,
+-- раньше блокировок строк.
+create or replace function todo_events_lock(tenant text) returns void
+language sql as $$
+    select pg_advisory_xact_lock(hashtext('todo_events'), hashtext(tenant))
+$$;
+
+create or replace function todo_events_sequence() returns trigger
+language plpgsql as $$
+begin
+    -- Номер выдаётся под блокировкой арендатора: читатель ленты не увидит
+    -- событие с большим номером раньше, чем зафиксируются все события
+    -- с меньшими.
+    perform todo_events_lock(new.tenant_id);
+    new.seq := nextval(pg_get_serial_sequence('todo_events', 'seq'));
+    return new;
+end;
+$$;
+
+-- Номер выдаёт только триггер, иначе каждое событие тратило бы два значения.
+alter table todo_events alter column seq drop default;
+
+drop trigger if exists todo_events_sequence on todo_events;
+create trigger todo_events_sequence
+    before insert on todo_events
+    for each row execute function todo_events_sequence();
+
+-- Payload — арендатор; одинаковые уведомления транзакции Postgres схлопывает.
+create or replace function todo_events_notify() returns trigger
+language plpgsql as $$
+begin
+    perform pg_notify('todo_events', new.tenant_id);
+    return null;
+end;
+$$;
+
+drop trigger if exists todo_events_notify on todo_events;
+create trigger todo_events_notify
+    after insert on todo_events
+    for each row execute function todo_events_notify();
+
+create index if not exists todo_events_tenant_idx on todo_events (tenant_id, seq);
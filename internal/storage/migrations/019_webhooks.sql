@@ -0,0 +1,66 @@
+-- Исходящие вебхуки. Каждое событие журнала задач в той же транзакции
+-- попадает в outbox, откуда диспетчер раскладывает его по вебхукам.
+create table if not exists outbox (
+    id bigserial primary key,
+    tenant_id text not null,
+    todo_id uuid not null,
+    event_type text not null,
+    payload jsonb not null,
+    created_at timestamptz not null default now(),
+    dispatched_at timestamptz
+);
+
+create index if not exists outbox_pending_idx on outbox (id) where dispatched_at is null;
+
+-- Триггер покрывает все пути записи в журнал, включая массовые изменения
+-- одним запросом, поэтому событие не может попасть в журнал без outbox.
+create or replace function todo_events_outbox() returns trigger
+language plpgsql as $$
+begin
+    insert into outbox (tenant_id, todo_id, event_type, payload, created_at)
+    values (new.tenant_id, new.todo_id, 'todo.' || new.operation, jsonb_build_object(
+        'type', 'todo.' || new.operation,
+        'seq', new.seq,
+        'tenant_id', new.tenant_id,
+        'todo_id', new.todo_id,
+        'actor', new.actor,
+        'changes', new.changes,
+        'occurred_at', new.created_at
+    ), new.created_at);
+    return null;
+end;
+$$;
+
+drop trigger if exists todo_events_outbox on todo_events;
+create trigger todo_events_outbox
+    after insert on todo_events
+    for each row execute function todo_events_outbox();
+
+-- Секрет хранится открыто: им подписывается каждая доставка.
+create table if not exists webhooks (
+    id uuid primary key default gen_random_uuid(),
+    tenant_id text not null,
+    owner_id text not null,
+    url text not null,
+    secret text not null,
+    created_at timestamptz not null default now()
+);
+
+create index if not exists webhooks_owner_idx on webhooks (tenant_id, owner_id, created_at desc, id desc);
+
+create table if not exists webhook_deliveries (
+    id bigserial primary key,
+    webhook_id uuid not null references webhooks (id) on delete cascade,
+    outbox_id bigint not null references outbox (id),
+    status text not null check (status in ('pending', 'succeeded', 'dead')),
+    attempts integer not null default 0,
+    next_attempt_at timestamptz not null,
+    last_attempt_at timestamptz,
+    last_status_code integer not null default 0,
+    last_error text not null default '',
+    created_at timestamptz not null default now(),
+    unique (webhook_id, outbox_id)
+);
+
+create index if not exists webhook_deliveries_due_idx on webhook_deliveries (next_attempt_at) where status = 'pending';
+create index if not exists webhook_deliveries_webhook_idx on webhook_deliveries (webhook_id, id desc);
@@ -0,0 +1,18 @@
+-- Ключи идемпотентности: повтор изменяющего вызова с тем же ключом получает
+-- сохранённый ответ вместо повторного выполнения.
+create table if not exists idempotency_keys (
+    tenant_id text not null,
+    subject text not null,
+    key text not null,
+    method text not null,
+    -- fingerprint — SHA-256 метода и тела запроса; тот же ключ с другим
+    -- запросом отклоняется.
+    fingerprint text not null,
+    -- response пуст, пока первый вызов выполняется.
+    response bytea,
+    created_at timestamptz not null default now(),
+    expires_at timestamptz not null,
+    primary key (tenant_id, subject, key)
+);
+
+create index if not exists idempotency_keys_expires_idx on idempotency_keys (expires_at);
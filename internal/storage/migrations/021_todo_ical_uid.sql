@@ -0,0 +1,24 @@
+-- UID задачи в iCalendar. Задачи, созданные в сервисе, получают UID, равный
+-- своему id; загруженные из календаря сохраняют исходный UID, чтобы
+-- повторная загрузка изменяла задачу, а не создавала копию.
+alter table todos add column if not exists ical_uid text;
+
+create or replace function todos_default_ical_uid() returns trigger
+language plpgsql as $$
+begin
+    if new.ical_uid is null then
+        new.ical_uid := new.id::text;
+    end if;
+    return new;
+end;
+$$;
+
+drop trigger if exists todos_default_ical_uid on todos;
+create trigger todos_default_ical_uid
+    before insert on todos
+    for each row execute function todos_default_ical_uid();
+
+update todos set ical_uid = id::text where ical_uid is null;
+alter table todos alter column ical_uid set not null;
+
+create unique index if not exists todos_ical_uid_idx on todos (tenant_id, ical_uid);
@@ -0,0 +1,18 @@
+-- Окончательное удаление задачи тоже попадает в журнал: событие purge
+-- остаётся последним в истории задачи, которой больше нет. Ограничение
+-- пересоздаётся, только пока в нём нет purge: иначе каждый запуск заново
+-- проверял бы весь журнал под эксклюзивной блокировкой.
+do $$
+begin
+    if not exists (
+        select 1 from pg_constraint
+        where conrelid = 'todo_events'::regclass
+          and conname = 'todo_events_operation_check'
+          and pg_get_constraintdef(oid) like '%purge%'
+    ) then
+        alter table todo_events drop constraint if exists todo_events_operation_check;
+        alter table todo_events add constraint todo_events_operation_check
+            check (operation in ('create', 'update', 'delete', 'restore', 'purge'));
+    end if;
+end;
+$$;
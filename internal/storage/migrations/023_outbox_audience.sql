@@ -0,0 +1,43 @@
+-- Outbox хранит владельца, проект и роли задачи на момент события, чтобы
+-- разослать события задачи, которую окончательно удалили до рассылки.
+alter table outbox add column if not exists owner_id text;
+alter table outbox add column if not exists project_id uuid;
+alter table outbox add column if not exists grantees text[] not null default '{}';
+
+create index if not exists outbox_todo_idx on outbox (todo_id, id desc);
+
+-- Событие purge записывается, когда задачи уже нет, поэтому доступ к ней
+-- берётся из её предыдущего события.
+create or replace function todo_events_outbox() returns trigger
+language plpgsql as $$
+declare
+    v_owner text;
+    v_project uuid;
+    v_grantees text[];
+begin
+    select t.owner_id, t.project_id, array(select g.grantee_id from todo_grants g where g.todo_id = t.id)
+    into v_owner, v_project, v_grantees
+    from todos t
+    where t.id = new.todo_id;
+    if not found then
+        select o.owner_id, o.project_id, o.grantees
+        into v_owner, v_project, v_grantees
+        from outbox o
+        where o.todo_id = new.todo_id
+        order by o.id desc
+        limit 1;
+    end if;
+
+    insert into outbox (tenant_id, todo_id, event_type, payload, created_at, owner_id, project_id, grantees)
+    values (new.tenant_id, new.todo_id, 'todo.' || new.operation, jsonb_build_object(
+        'type', 'todo.' || new.operation,
+        'seq', new.seq,
+        'tenant_id', new.tenant_id,
+        'todo_id', new.todo_id,
+        'actor', new.actor,
+        'changes', new.changes,
+        'occurred_at', new.created_at
+    ), new.created_at, v_owner, v_project, coalesce(v_grantees, '{}'));
+    return null;
+end;
+$$;
@@ -0,0 +1,3 @@
+-- Метка резервирования: сохранить ответ или освободить ключ может только
+-- вызов, который его занял, даже если его lease истёк и ключ занят снова.
+alter table idempotency_keys add column if not exists token text not null default '';
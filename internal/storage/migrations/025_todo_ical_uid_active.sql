@@ -0,0 +1,14 @@
+-- UID в календаре занимают только задачи вне корзины: загрузка календаря
+-- не видит задачи в корзине и создаёт новую задачу с тем же UID.
+do $$
+begin
+    if exists (
+        select 1 from pg_indexes
+        where tablename = 'todos' and indexname = 'todos_ical_uid_idx' and indexdef not like '%WHERE%'
+    ) then
+        drop index todos_ical_uid_idx;
+    end if;
+end;
+$$;
+
+create unique index if not exists todos_ical_uid_idx on todos (tenant_id, ical_uid) where deleted_at is null;
@@ -0,0 +1,3 @@
+-- Событие purge хранит бывшего владельца и пользователей с ролями на задачу:
+-- только им, кроме удалившего, доступен журнал задачи, которой больше нет.
+alter table todo_events add column if not exists readers text[] not null default '{}';
@@ -0,0 +1,90 @@
+package todo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// ItemError сообщает, на каком элементе пакета прервалась операция.
+type ItemError struct {
+	// Index — номер элемента в пакете, начиная с нуля.
+	Index int
+	Err   error
+}
+
+func (e *ItemError) Error() string {
+	return fmt.Sprintf("item %d: %v", e.Index, e.Err)
+}
+
+func (e *ItemError) Unwrap() error {
+	return e.Err
+}
+
+// UpdateItem описывает изменение одной задачи пакета.
+type UpdateItem struct {
+	ID string
+	// Version, если больше нуля, — ожидаемая версия задачи.
+	Version int64
+	Patch   Patch
+}
+
+// DeleteItem описывает удаление одной задачи пакета.
+type DeleteItem struct {
+	ID string
+	// Version, если больше нуля, — ожидаемая версия задачи.
+	Version int64
+}
+
+// BatchCreate добавляет задачи в одной транзакции: если не удалось добавить
+// хотя бы одну, не добавляется ни одна, а ошибка имеет тип *ItemError.
+func (r *Repository) BatchCreate(ctx context.Context, items []CreateParams) ([]Record, error) {
+	recs := make([]Record, 0, len(items))
+	err := r.withTx(ctx, func(tx *sql.Tx) error {
+		for i, params := range items {
+			rec, err := insertRecord(ctx, tx, params)
+			if err != nil {
+				return &ItemError{Index: i, Err: err}
+			}
+			recs = append(recs, rec)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return recs, nil
+}
+
+// BatchUpdate изменяет задачи в одной транзакции по правилам Update.
+// Ошибка любого элемента отменяет весь пакет и имеет тип *ItemError.
+func (r *Repository) BatchUpdate(ctx context.Context, items []UpdateItem) ([]Record, error) {
+	recs := make([]Record, 0, len(items))
+	err := r.withTx(ctx, func(tx *sql.Tx) error {
+		for i, item := range items {
+			rec, err := updateRecord(ctx, tx, item.ID, item.Version, item.Patch)
+			if err != nil {
+				return &ItemError{Index: i, Err: err}
+			}
+			recs = append(recs, rec)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return recs, nil
+}
+
+// BatchDelete перемещает задачи в корзину в одной транзакции по правилам
+// Delete. Ошибка любого элемента отменяет весь пакет и имеет тип *ItemError.
+func (r *Repository) BatchDelete(ctx context.Context, items []DeleteItem) error {
+	return r.withTx(ctx, func(tx *sql.Tx) error {
+		for i, item := range items {
+			if err := deleteRecord(ctx, tx, item.ID, item.Version); err != nil {
+				return &ItemError{Index: i, Err: err}
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,280 @@
+package todo
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"todo/internal/access"
+	"todo/internal/auth"
+)
+
+// BulkPatch перечисляет поля, которые массовое изменение задаёт всем
+// отобранным задачам; nil означает «оставить как есть».
+type BulkPatch struct {
+	Completed *bool
+	Priority  *Priority
+	// ProjectID переносит задачи в проект; невалидное значение — во «Входящие».
+	ProjectID *sql.NullString
+	// CompleteSubtasks при завершении завершает и открытые подзадачи
+	// отобранных задач; без него задачи с открытыми потомками пропускаются.
+	// Задачи, подзадачи которых нельзя завершить вместе с ними, пропускаются всегда.
+	CompleteSubtasks bool
+}
+
+// completing сообщает, завершает ли изменение задачи.
+func (p BulkPatch) completing() bool {
+	return p.Completed != nil && *p.Completed
+}
+
+// bulkField описывает поле, которое может задать массовое изменение.
+type bulkField struct {
+	name   string
+	column string
+}
+
+// fields возвращает заданные в изменении поля и плейсхолдеры их значений.
+func (p BulkPatch) fields(args *queryArgs) ([]bulkField, []string) {
+	var (
+		fields []bulkField
+		values []string
+	)
+	if p.Completed != nil {
+		fields = append(fields, bulkField{name: "completed", column: "completed"})
+		values = append(values, args.add(*p.Completed))
+	}
+	if p.Priority != nil {
+		fields = append(fields, bulkField{name: "priority", column: "priority"})
+		values = append(values, args.add(*p.Priority))
+	}
+	if p.ProjectID != nil {
+		fields = append(fields, bulkField{name: "project_id", column: "project_id"})
+		values = append(values, args.add(*p.ProjectID)+"::uuid")
+	}
+	return fields, values
+}
+
+// bulkScope возвращает условия отбора задач, которые вызывающий может изменять.
+func bulkScope(ctx context.Context, filter ListFilter, args *queryArgs) ([]string, auth.Principal, error) {
+	principal, err := auth.Require(ctx)
+	if err != nil {
+		return nil, auth.Principal{}, err
+	}
+	conds := append(filter.scope(principal, args), filter.conditions(args)...)
+	conds = append(conds, access.TodoEditable("todos", args.add(principal.TenantID), args.add(principal.Subject)))
+	return conds, principal, nil
+}
+
+// bulkUpdateConditions дополняет отбор условиями массового изменения: задача
+// затрагивается, только если меняется хотя бы одно поле. Повторяющиеся и
+// заблокированные задачи не завершаются: их следующее вхождение и блокировки
+// проверяются по одной задаче. По тем же причинам не завершаются задачи,
+// среди открытых потомков которых есть такие задачи или задачи, которые
+// вызывающий не может изменять.
+func bulkUpdateConditions(conds []string, patch BulkPatch, fields []bulkField, values []string,
+	principal auth.Principal, args *queryArgs) []string {
+	changed := make([]string, len(fields))
+	for i, f := range fields {
+		changed[i] = f.column + " is distinct from " + values[i]
+	}
+	conds = append(conds, "("+strings.Join(changed, " or ")+")")
+	if patch.completing() {
+		conds = append(conds, "recurrence = ''", `not exists (
+		select 1 from todo_dependencies d
+		where d.todo_id = todos.id and `+openBlockerCondition+`)`)
+		// Без CompleteSubtasks задача не завершается, пока открыт любой её потомок,
+		// а не только прямая подзадача, как и в checkOpenDescendants.
+		tenant := args.add(principal.TenantID)
+		stuck := "not s.completed"
+		if patch.CompleteSubtasks {
+			stuck += " and (" + uncompletableCondition("s", tenant, args.add(principal.Subject)) + ")"
+		}
+		conds = append(conds, `not exists (
+		with recursive tree as (
+		    select c.id, 1 as depth from todos c
+		    where c.parent_id = todos.id and c.tenant_id = `+tenant+` and c.deleted_at is null
+		    union all
+		    select t.id, tree.depth + 1
+		    from todos t
+		    join tree on t.parent_id = tree.id
+		    where t.tenant_id = `+tenant+` and t.deleted_at is null and tree.depth < `+args.add(recursionLimit)+`
+		)
+		select 1 from todos s
+		where s.id in (select id from tree) and `+stuck+`)`)
+	}
+	return conds
+}
+
+// PreviewBulkUpdate возвращает, сколько задач по фильтру изменит BulkUpdate,
+// и до sample из них, начиная с новых. Ничего не изменяет.
+func (r *Repository) PreviewBulkUpdate(ctx context.Context, filter ListFilter, patch BulkPatch, sample int) (int64, []Record, error) {
+	var args queryArgs
+	conds, principal, err := bulkScope(ctx, filter, &args)
+	if err != nil {
+		return 0, nil, err
+	}
+	fields, values := patch.fields(&args)
+	return r.preview(ctx, bulkUpdateConditions(conds, patch, fields, values, principal, &args), args, sample)
+}
+
+// BulkUpdate одним запросом применяет patch ко всем задачам по фильтру,
+// которые вызывающий может изменять, и записывает в журнал событие для
+// каждой изменённой задачи. Возвращает число изменённых задач без учёта
+// завершённых вместе с ними подзадач.
+func (r *Repository) BulkUpdate(ctx context.Context, filter ListFilter, patch BulkPatch) (int64, error) {
+	var args queryArgs
+	conds, principal, err := bulkScope(ctx, filter, &args)
+	if err != nil {
+		return 0, err
+	}
+	fields, values := patch.fields(&args)
+	conds = bulkUpdateConditions(conds, patch, fields, values, principal, &args)
+
+	now := args.add(time.Now().UTC())
+	sets := []string{"version = t.version + 1", "updated_at = " + now}
+	olds := make([]string, 0, len(fields))
+	changes := make([]string, 0, len(fields))
+	for i, f := range fields {
+		sets = append(sets, f.column+" = "+values[i])
+		olds = append(olds, "target."+f.column+" as old_"+f.column+", t."+f.column)
+		changes = append(changes, `case when old_`+f.column+` is distinct from `+f.column+`
+        then jsonb_build_object('`+f.name+`', jsonb_build_object('before', old_`+f.column+`, 'after', `+f.column+`))
+        else '{}'::jsonb end`)
+	}
+	query := `
+with target as (
+    select id, completed, priority, project_id
+    from todos
+    where ` + strings.Join(conds, "\n      and ") + `
+    for update
+), updated as (
+    update todos t
+    set ` + strings.Join(sets, ", ") + `
+    from target
+    where t.id = target.id
+    returning t.id, t.tenant_id, ` + strings.Join(olds, ", ") + `
+)
+insert into todo_events (todo_id, tenant_id, actor, operation, changes, created_at)
+select id, tenant_id, ` + args.add(principal.Subject) + `, 'update', ` + strings.Join(changes, "\n    || ") + `, ` + now + `
+from updated
+returning todo_id`
+
+	var ids []string
+	err = r.withTx(ctx, func(tx *sql.Tx) error {
+		if patch.ProjectID != nil && patch.ProjectID.Valid {
+			if err := checkProject(ctx, tx, principal.TenantID, patch.ProjectID.String); err != nil {
+				return err
+			}
+		}
+		rows, err := tx.QueryContext(ctx, query, args...)
+		if err != nil {
+			return projectError(err)
+		}
+		defer func() {
+			_ = rows.Close()
+		}()
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				return err
+			}
+			ids = append(ids, id)
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		if patch.completing() && patch.CompleteSubtasks && len(ids) > 0 {
+			return completeDescendants(ctx, tx, principal, ids...)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(ids)), nil
+}
+
+// PreviewBulkDelete возвращает, сколько задач по фильтру удалит BulkDelete,
+// и до sample из них, начиная с новых. Ничего не изменяет.
+func (r *Repository) PreviewBulkDelete(ctx context.Context, filter ListFilter, sample int) (int64, []Record, error) {
+	var args queryArgs
+	conds, _, err := bulkScope(ctx, filter, &args)
+	if err != nil {
+		return 0, nil, err
+	}
+	return r.preview(ctx, conds, args, sample)
+}
+
+// BulkDelete одним запросом перемещает в корзину все задачи по фильтру,
+// которые вызывающий может изменять, вместе с их комментариями и записывает
+// в журнал событие для каждой. Возвращает число удалённых задач.
+func (r *Repository) BulkDelete(ctx context.Context, filter ListFilter) (int64, error) {
+	var args queryArgs
+	conds, principal, err := bulkScope(ctx, filter, &args)
+	if err != nil {
+		return 0, err
+	}
+	at := time.Now().UTC()
+	now := args.add(at)
+	query := `
+with target as (
+    select id
+    from todos
+    where ` + strings.Join(conds, "\n      and ") + `
+    for update
+), deleted as (
+    update todos t
+    set deleted_at = ` + now + `, version = t.version + 1
+    from target
+    where t.id = target.id
+    returning t.id, t.tenant_id
+), trashed_comments as (
+    update comments c
+    set deleted_at = ` + now + `
+    from deleted
+    where c.todo_id = deleted.id and c.deleted_at is null
+)
+insert into todo_events (todo_id, tenant_id, actor, operation, changes, created_at)
+select id, tenant_id, ` + args.add(principal.Subject) + `, 'delete',
+    jsonb_build_object('deleted_at', jsonb_build_object('before', 'null'::jsonb, 'after', ` + args.add(timeValue(&at)) + `::text)),
+    ` + now + `
+from deleted`
+
+	var affected int64
+	err = r.withTx(ctx, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		affected, err = res.RowsAffected()
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return affected, nil
+}
+
+// preview считает задачи по условиям и возвращает до sample из них.
+func (r *Repository) preview(ctx context.Context, conds []string, args queryArgs, sample int) (int64, []Record, error) {
+	where := strings.Join(conds, "\n  and ")
+	var count int64
+	if err := r.db.QueryRowContext(ctx, "select count(*) from todos where "+where, args...).Scan(&count); err != nil {
+		return 0, nil, err
+	}
+	if count == 0 || sample <= 0 {
+		return count, nil, nil
+	}
+	query := `
+select ` + recordColumns + `
+from todos
+where ` + where + `
+order by created_at desc, id desc
+limit ` + args.add(sample)
+	recs, err := queryRecords(ctx, r.db, query, args...)
+	if err != nil {
+		return 0, nil, err
+	}
+	return count, recs, nil
+}
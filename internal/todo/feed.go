@@ -0,0 +1,150 @@
+package todo
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"todo/internal/auth"
+)
+
+// FeedEvent — событие журнала вместе с текущим состоянием задачи.
+type FeedEvent struct {
+	Event
+	// Record — текущее состояние задачи, которое может быть новее события;
+	// nil, если задача удалена окончательно или больше не видна вызывающему.
+	Record *Record
+	// Matches сообщает, подходит ли задача под фильтр в текущем состоянии.
+	Matches bool
+}
+
+// LastSeq возвращает номер последнего события в задачах арендатора вызывающего.
+func (r *Repository) LastSeq(ctx context.Context) (int64, error) {
+	tenant, err := auth.TenantID(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var seq int64
+	err = r.db.QueryRowContext(ctx,
+		`select coalesce(max(seq), 0) from todo_events where tenant_id = $1`, tenant).Scan(&seq)
+	return seq, err
+}
+
+// feedConditions возвращает условие видимости и условие фильтра. Условие на
+// корзину не применяется: иначе подписчик не узнает об удалении.
+func feedConditions(principal auth.Principal, filter ListFilter, args *queryArgs) (string, string) {
+	scope := filter.scope(principal, args)
+	conds := append(scope[1:], filter.conditions(args)[1:]...)
+	if len(conds) == 0 {
+		return scope[0], "true"
+	}
+	return scope[0], strings.Join(conds, "\n      and ")
+}
+
+// MatchingIDs возвращает идентификаторы видимых вызывающему задач, которые
+// подходят под фильтр, включая задачи в корзине.
+func (r *Repository) MatchingIDs(ctx context.Context, filter ListFilter) ([]string, error) {
+	principal, err := auth.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var args queryArgs
+	visibleCond, matchCond := feedConditions(principal, filter, &args)
+	rows, err := r.db.QueryContext(ctx,
+		`select id from todos where `+visibleCond+` and `+matchCond, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// Feed возвращает до limit событий арендатора вызывающего с номерами
+// в (afterSeq, uptoSeq] вместе с текущим состоянием видимых ему задач и
+// признаком того, подходит ли задача под фильтр. Решать, какие события
+// показывать подписчику, оставлено вызывающему.
+func (r *Repository) Feed(ctx context.Context, afterSeq, uptoSeq int64, limit int, filter ListFilter) ([]FeedEvent, error) {
+	principal, err := auth.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	args := queryArgs{afterSeq, uptoSeq, principal.TenantID}
+	visibleCond, matchCond := feedConditions(principal, filter, &args)
+	query := `
+select e.seq, e.todo_id, e.actor, e.operation, e.changes, e.created_at,
+       t.id is not null, coalesce(t.matches, false)
+from todo_events e
+left join lateral (
+    select todos.id, (` + matchCond + `) as matches
+    from todos
+    where todos.id = e.todo_id and ` + visibleCond + `
+) t on true
+where e.tenant_id = $3 and e.seq > $1 and e.seq <= $2
+order by e.seq
+limit ` + args.add(limit)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var (
+		items   []FeedEvent
+		visible []bool
+		ids     []string
+	)
+	for rows.Next() {
+		var (
+			ev      FeedEvent
+			changes []byte
+			seen    bool
+		)
+		if err := rows.Scan(&ev.Seq, &ev.TodoID, &ev.Actor, &ev.Operation, &changes, &ev.CreatedAt,
+			&seen, &ev.Matches); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(changes, &ev.Changes); err != nil {
+			return nil, err
+		}
+		if seen {
+			ids = append(ids, ev.TodoID)
+		}
+		items = append(items, ev)
+		visible = append(visible, seen)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return items, nil
+	}
+
+	recs, err := queryRecords(ctx, r.db, `select `+recordColumns+` from todos where id = any($1)`, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*Record, len(recs))
+	for i := range recs {
+		byID[recs[i].ID] = &recs[i]
+	}
+	for i := range items {
+		if visible[i] {
+			items[i].Record = byID[items[i].TodoID]
+		}
+	}
+	return items, nil
+}
@@ -0,0 +1,239 @@
+package todo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"time"
+
+	"todo/internal/auth"
+)
+
+// Operation — вид изменения задачи в журнале.
+type Operation string
+
+// Операции, которые попадают в журнал.
+const (
+	OpCreate  Operation = "create"
+	OpUpdate  Operation = "update"
+	OpDelete  Operation = "delete"
+	OpRestore Operation = "restore"
+	// OpPurge — окончательное удаление; событие не содержит изменений.
+	OpPurge Operation = "purge"
+)
+
+// retentionActor — автор событий фоновой очистки корзины.
+const retentionActor = "system:retention"
+
+// Change хранит значение поля до и после изменения; nil означает пустое значение.
+type Change struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// Event — неизменяемая запись журнала изменений задачи.
+type Event struct {
+	// Seq возрастает вместе с порядком записи событий.
+	Seq       int64
+	TodoID    string
+	Actor     string
+	Operation Operation
+	// Changes содержит только изменившиеся поля; при создании — заданные.
+	Changes   map[string]Change
+	CreatedAt time.Time
+}
+
+// snapshot возвращает отслеживаемые поля задачи в виде, пригодном для JSON.
+func snapshot(rec *Record) map[string]any {
+	tags := rec.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	return map[string]any{
+		"title":           rec.Title,
+		"description":     rec.Description,
+		"completed":       rec.Completed,
+		"deleted_at":      timeValue(rec.DeletedAt),
+		"due_at":          timeValue(rec.DueAt),
+		"start_at":        timeValue(rec.StartAt),
+		"all_day":         rec.AllDay,
+		"time_zone":       rec.TimeZone,
+		"priority":        int(rec.Priority),
+		"project_id":      stringValue(rec.ProjectID),
+		"parent_id":       stringValue(rec.ParentID),
+		"recurrence":      rec.Recurrence,
+		"recurrence_mode": int(rec.RecurrenceMode),
+		"tags":            tags,
+	}
+}
+
+// diff сравнивает снимки задачи. Без before возвращаются непустые поля after.
+func diff(before, after *Record) map[string]Change {
+	changes := make(map[string]Change)
+	next := snapshot(after)
+	if before == nil {
+		for field, value := range next {
+			if value != nil && !reflect.ValueOf(value).IsZero() && !isEmptyList(value) {
+				changes[field] = Change{After: value}
+			}
+		}
+		return changes
+	}
+	prev := snapshot(before)
+	for field, value := range next {
+		if !reflect.DeepEqual(prev[field], value) {
+			changes[field] = Change{Before: prev[field], After: value}
+		}
+	}
+	return changes
+}
+
+func isEmptyList(v any) bool {
+	list, ok := v.([]string)
+	return ok && len(list) == 0
+}
+
+func timeValue(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+func stringValue(s *string) any {
+	if s == nil {
+		return nil
+	}
+	return *s
+}
+
+// recordEvent записывает в журнал изменение задачи от имени вызывающего.
+// Обновление, не изменившее ни одного отслеживаемого поля, не записывается.
+func recordEvent(ctx context.Context, q querier, op Operation, before, after *Record) error {
+	principal, err := auth.Require(ctx)
+	if err != nil {
+		return err
+	}
+	changes := diff(before, after)
+	if op == OpUpdate && len(changes) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+	_, err = q.ExecContext(ctx, `
+insert into todo_events (todo_id, tenant_id, actor, operation, changes, created_at)
+values ($1, $2, $3, $4, $5, $6)`,
+		after.ID, principal.TenantID, principal.Subject, op, string(data), time.Now().UTC())
+	return err
+}
+
+// purgeEvents возвращает запрос, который записывает в журнал события purge
+// для задач, удалённых в CTE purged (id, tenant_id, owner_id). Читателями
+// журнала остаются бывший владелец и пользователи с ролями на задачу: роли
+// ещё видны запросу, хотя удаляются вместе с задачей.
+func purgeEvents(actorPH, createdPH string) string {
+	return `
+insert into todo_events (todo_id, tenant_id, actor, operation, created_at, readers)
+select p.id, p.tenant_id, ` + actorPH + `, '` + string(OpPurge) + `', ` + createdPH + `,
+    array_prepend(p.owner_id, array(select g.grantee_id from todo_grants g where g.todo_id = p.id))
+from purged p`
+}
+
+// lockRecord блокирует задачу до конца транзакции и возвращает её вместе
+// с метками, чтобы журнал увидел состояние до изменения. Видимость задачи
+// проверяет последующий запрос на изменение.
+func lockRecord(ctx context.Context, tx *sql.Tx, id string) (Record, error) {
+	rec, err := scanRecord(tx.QueryRowContext(ctx, `select `+recordColumns+` from todos where id = $1 for update`, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Record{}, ErrNotFound
+		}
+		return Record{}, err
+	}
+	if err := loadTags(ctx, tx, []*Record{&rec}); err != nil {
+		return Record{}, err
+	}
+	return rec, nil
+}
+
+// History возвращает журнал изменений задачи, в том числе находящейся
+// в корзине, от новых событий к старым. Журнал окончательно удалённой
+// задачи доступен тому, кто её удалил, её бывшему владельцу и пользователям,
+// у которых были роли на неё. Если beforeSeq больше нуля, возвращаются
+// события строго до него; limit 0 означает без ограничения.
+func (r *Repository) History(ctx context.Context, todoID string, limit int, beforeSeq int64) ([]Event, error) {
+	principal, err := auth.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tenant := principal.TenantID
+	args := queryArgs{todoID, tenant}
+	cond, err := visible(ctx, "todos", &args)
+	if err != nil {
+		return nil, err
+	}
+	user := args.add(principal.Subject)
+	var readable bool
+	err = r.db.QueryRowContext(ctx, `
+select exists(select 1 from todos where id = $1 and `+cond+`)
+    or (not exists(select 1 from todos where id = $1)
+        and exists(
+            select 1 from todo_events
+            where todo_id = $1 and tenant_id = $2 and operation = '`+string(OpPurge)+`'
+              and (actor = `+user+` or `+user+` = any(readers))))`,
+		args...).Scan(&readable)
+	if err != nil {
+		return nil, err
+	}
+	if !readable {
+		return nil, ErrNotFound
+	}
+
+	query := `
+select seq, todo_id, actor, operation, changes, created_at
+from todo_events
+where todo_id = $1 and tenant_id = $2`
+	params := []any{todoID, tenant}
+	if beforeSeq > 0 {
+		params = append(params, beforeSeq)
+		query += ` and seq < $3`
+	}
+	query += `
+order by seq desc`
+	if limit > 0 {
+		params = append(params, limit)
+		query += ` limit $` + strconv.Itoa(len(params))
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, params...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var items []Event
+	for rows.Next() {
+		var (
+			ev      Event
+			changes []byte
+		)
+		if err := rows.Scan(&ev.Seq, &ev.TodoID, &ev.Actor, &ev.Operation, &changes, &ev.CreatedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(changes, &ev.Changes); err != nil {
+			return nil, err
+		}
+		items = append(items, ev)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,368 @@
+package todo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"todo/internal/access"
+	"todo/internal/auth"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Order задаёт порядок сортировки списка задач.
+type Order int
+
+// Поддерживаемые порядки сортировки.
+const (
+	OrderCreatedDesc Order = iota
+	OrderCreatedAsc
+	OrderUpdatedDesc
+	OrderUpdatedAsc
+	OrderTitleAsc
+	OrderTitleDesc
+	// OrderPriority: сначала более приоритетные, затем с ближайшим сроком
+	// (задачи без срока в конце), затем новые.
+	OrderPriority
+	// OrderDeletedDesc упорядочивает корзину: сначала недавно удалённые.
+	OrderDeletedDesc
+)
+
+// Valid сообщает, поддерживается ли порядок сортировки.
+func (o Order) Valid() bool {
+	_, ok := orderKeys[o]
+	return ok
+}
+
+// ListFilter описывает условия отбора задач. Нулевые значения полей не ограничивают выборку.
+type ListFilter struct {
+	Completed     *bool
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	UpdatedAfter  time.Time
+	UpdatedBefore time.Time
+	TitlePrefix   string
+	// Trashed выбирает задачи из корзины вместо активных.
+	Trashed bool
+	// DueFrom и DueTo ограничивают срок выполнения полуинтервалом [DueFrom, DueTo).
+	DueFrom time.Time
+	DueTo   time.Time
+	// Overdue, если задан, отбирает только просроченные незавершённые задачи.
+	Overdue *Overdue
+	// Tags отбирает задачи с любой из меток либо, если TagsMatchAll, со всеми сразу.
+	// Имена меток должны быть нормализованы и не повторяться.
+	Tags         []string
+	TagsMatchAll bool
+	// ProjectID отбирает задачи проекта; пустая строка — задачи из «Входящих».
+	ProjectID *string
+	// ParentID отбирает подзадачи задачи; пустая строка — задачи верхнего уровня.
+	ParentID *string
+	// SeriesID отбирает вхождения одной повторяющейся задачи.
+	SeriesID string
+	// Actionable отбирает только задачи, которые не заблокированы другими.
+	Actionable bool
+	// Ownership отбирает собственные задачи вызывающего либо доступные ему
+	// благодаря выданным ролям.
+	Ownership Ownership
+}
+
+// Ownership задаёт отбор задач по владельцу.
+type Ownership int
+
+// Поддерживаемые отборы по владельцу.
+const (
+	AnyOwner Ownership = iota
+	OwnedByMe
+	SharedWithMe
+)
+
+// Valid сообщает, поддерживается ли отбор.
+func (o Ownership) Valid() bool {
+	return o >= AnyOwner && o <= SharedWithMe
+}
+
+// Overdue задаёт момент, относительно которого задача считается просроченной.
+type Overdue struct {
+	// Now — текущий момент; задачи со сроком до него просрочены.
+	Now time.Time
+	// DayStart — начало текущего дня. Задача на весь день просрочена,
+	// только если её день закончился, то есть срок раньше DayStart.
+	DayStart time.Time
+}
+
+// Cursor хранит ключ сортировки последней выданной записи для keyset-пагинации.
+type Cursor struct {
+	ID        string     `json:"id"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	Title     string     `json:"title"`
+	DeletedAt time.Time  `json:"deleted_at"`
+	Priority  Priority   `json:"priority"`
+	DueAt     *time.Time `json:"due_at"`
+}
+
+// CursorFor строит курсор, указывающий на переданную запись.
+func CursorFor(rec Record) Cursor {
+	c := Cursor{
+		ID:        rec.ID,
+		CreatedAt: rec.CreatedAt,
+		UpdatedAt: rec.UpdatedAt,
+		Title:     rec.Title,
+		Priority:  rec.Priority,
+		DueAt:     rec.DueAt,
+	}
+	if rec.DeletedAt != nil {
+		c.DeletedAt = *rec.DeletedAt
+	}
+	return c
+}
+
+// ListParams задаёт параметры выборки страницы задач.
+type ListParams struct {
+	Filter ListFilter
+	Order  Order
+	// Limit ограничивает число возвращаемых записей; 0 означает без ограничения.
+	Limit int
+	// After, если задан, возвращает записи строго после курсора.
+	After *Cursor
+}
+
+// sortKey описывает одно выражение ключа сортировки.
+type sortKey struct {
+	expr  string
+	desc  bool
+	value func(Cursor) any
+}
+
+// orderKeys сопоставляет порядок сортировки с ключом; последним всегда идёт id,
+// чтобы ключ был уникальным и курсор однозначно указывал на запись.
+var orderKeys = map[Order][]sortKey{
+	OrderCreatedDesc: {createdKey(true), idKey(true)},
+	OrderCreatedAsc:  {createdKey(false), idKey(false)},
+	OrderUpdatedDesc: {updatedKey(true), idKey(true)},
+	OrderUpdatedAsc:  {updatedKey(false), idKey(false)},
+	OrderTitleAsc:    {titleKey(false), idKey(false)},
+	OrderTitleDesc:   {titleKey(true), idKey(true)},
+	OrderPriority:    {priorityKey(true), dueKey(false), createdKey(true), idKey(true)},
+	OrderDeletedDesc: {deletedKey(true), idKey(true)},
+}
+
+func idKey(desc bool) sortKey {
+	return sortKey{expr: "id", desc: desc, value: func(c Cursor) any { return c.ID }}
+}
+
+func createdKey(desc bool) sortKey {
+	return sortKey{expr: "created_at", desc: desc, value: func(c Cursor) any { return c.CreatedAt }}
+}
+
+func updatedKey(desc bool) sortKey {
+	return sortKey{expr: "updated_at", desc: desc, value: func(c Cursor) any { return c.UpdatedAt }}
+}
+
+func titleKey(desc bool) sortKey {
+	return sortKey{expr: "title", desc: desc, value: func(c Cursor) any { return c.Title }}
+}
+
+func deletedKey(desc bool) sortKey {
+	return sortKey{expr: "deleted_at", desc: desc, value: func(c Cursor) any { return c.DeletedAt }}
+}
+
+func priorityKey(desc bool) sortKey {
+	return sortKey{expr: "priority", desc: desc, value: func(c Cursor) any { return c.Priority }}
+}
+
+// dueKey ставит задачи без срока после всех остальных при сортировке по возрастанию.
+func dueKey(desc bool) sortKey {
+	return sortKey{expr: "coalesce(due_at, 'infinity'::timestamptz)", desc: desc, value: func(c Cursor) any {
+		if c.DueAt == nil {
+			return pgtype.Timestamptz{InfinityModifier: pgtype.Infinity, Valid: true}
+		}
+		return *c.DueAt
+	}}
+}
+
+// queryArgs накапливает позиционные параметры динамически собираемого запроса.
+type queryArgs []any
+
+// add добавляет значение и возвращает его плейсхолдер.
+func (a *queryArgs) add(v any) string {
+	*a = append(*a, v)
+	return "$" + strconv.Itoa(len(*a))
+}
+
+// List возвращает страницу задач по фильтру в заданном порядке.
+func (r *Repository) List(ctx context.Context, params ListParams) ([]Record, error) {
+	principal, err := auth.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keys, ok := orderKeys[params.Order]
+	if !ok {
+		keys = orderKeys[OrderCreatedDesc]
+	}
+
+	var args queryArgs
+	conds := append(params.Filter.scope(principal, &args), params.Filter.conditions(&args)...)
+	if params.After != nil {
+		conds = append(conds, keysetCondition(keys, *params.After, &args))
+	}
+
+	var b strings.Builder
+	b.WriteString("\nselect " + recordColumns + "\nfrom todos\nwhere " + strings.Join(conds, "\n  and "))
+	b.WriteString("\norder by " + orderClause(keys))
+	if params.Limit > 0 {
+		b.WriteString("\nlimit " + args.add(params.Limit))
+	}
+
+	return queryRecords(ctx, r.db, b.String(), args...)
+}
+
+// scope возвращает условия видимости задач вызывающему с учётом отбора по владельцу.
+func (f ListFilter) scope(principal auth.Principal, args *queryArgs) []string {
+	owner := args.add(principal.Subject)
+	conds := []string{access.TodoVisible("todos", args.add(principal.TenantID), owner)}
+	switch f.Ownership {
+	case OwnedByMe:
+		conds = append(conds, "owner_id = "+owner)
+	case SharedWithMe:
+		conds = append(conds, "owner_id <> "+owner)
+	}
+	return conds
+}
+
+// conditions переводит фильтр в набор SQL-условий, объединяемых через and.
+// Первым всегда идёт условие на корзину.
+func (f ListFilter) conditions(args *queryArgs) []string {
+	conds := []string{"deleted_at is null"}
+	if f.Trashed {
+		conds[0] = "deleted_at is not null"
+	}
+	if f.Completed != nil {
+		conds = append(conds, "completed = "+args.add(*f.Completed))
+	}
+	if !f.CreatedAfter.IsZero() {
+		conds = append(conds, "created_at >= "+args.add(f.CreatedAfter))
+	}
+	if !f.CreatedBefore.IsZero() {
+		conds = append(conds, "created_at < "+args.add(f.CreatedBefore))
+	}
+	if !f.UpdatedAfter.IsZero() {
+		conds = append(conds, "updated_at >= "+args.add(f.UpdatedAfter))
+	}
+	if !f.UpdatedBefore.IsZero() {
+		conds = append(conds, "updated_at < "+args.add(f.UpdatedBefore))
+	}
+	if f.TitlePrefix != "" {
+		conds = append(conds, "title like "+args.add(escapeLike(f.TitlePrefix)+"%"))
+	}
+	if !f.DueFrom.IsZero() {
+		conds = append(conds, "due_at >= "+args.add(f.DueFrom))
+	}
+	if !f.DueTo.IsZero() {
+		conds = append(conds, "due_at < "+args.add(f.DueTo))
+	}
+	if f.Overdue != nil {
+		conds = append(conds,
+			"not completed",
+			"due_at < "+args.add(f.Overdue.Now),
+			"(not all_day or due_at < "+args.add(f.Overdue.DayStart)+")",
+		)
+	}
+	if f.ProjectID != nil {
+		if *f.ProjectID == "" {
+			conds = append(conds, "project_id is null")
+		} else {
+			conds = append(conds, "project_id = "+args.add(*f.ProjectID))
+		}
+	}
+	if f.ParentID != nil {
+		if *f.ParentID == "" {
+			conds = append(conds, "parent_id is null")
+		} else {
+			conds = append(conds, "parent_id = "+args.add(*f.ParentID))
+		}
+	}
+	if f.SeriesID != "" {
+		conds = append(conds, "series_id = "+args.add(f.SeriesID))
+	}
+	if f.Actionable {
+		conds = append(conds, `not exists (
+		select 1 from todo_dependencies d
+		where d.todo_id = todos.id and `+openBlockerCondition+`)`)
+	}
+	if len(f.Tags) > 0 {
+		tagged := `
+	select %s from todo_tags tt join tags t on t.id = tt.tag_id
+	where tt.todo_id = todos.id and t.name = any(` + args.add(f.Tags) + `)`
+		if f.TagsMatchAll {
+			conds = append(conds, "("+fmt.Sprintf(tagged, "count(*)")+") = "+args.add(len(f.Tags)))
+		} else {
+			conds = append(conds, "exists ("+fmt.Sprintf(tagged, "1")+")")
+		}
+	}
+	return conds
+}
+
+// keysetCondition строит условие «строго после курсора» для ключа сортировки.
+// Если все колонки отсортированы в одну сторону, используется сравнение строк,
+// которое Postgres умеет обслуживать одним проходом по составному индексу.
+func keysetCondition(keys []sortKey, c Cursor, args *queryArgs) string {
+	uniform := true
+	for _, k := range keys[1:] {
+		if k.desc != keys[0].desc {
+			uniform = false
+			break
+		}
+	}
+	if uniform {
+		cols := make([]string, 0, len(keys))
+		vals := make([]string, 0, len(keys))
+		for _, k := range keys {
+			cols = append(cols, k.expr)
+			vals = append(vals, args.add(k.value(c)))
+		}
+		return "(" + strings.Join(cols, ", ") + ") " + keyOp(keys[0]) + " (" + strings.Join(vals, ", ") + ")"
+	}
+
+	placeholders := make([]string, len(keys))
+	for i, k := range keys {
+		placeholders[i] = args.add(k.value(c))
+	}
+	alts := make([]string, 0, len(keys))
+	for i, k := range keys {
+		parts := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			parts = append(parts, keys[j].expr+" = "+placeholders[j])
+		}
+		parts = append(parts, k.expr+" "+keyOp(k)+" "+placeholders[i])
+		alts = append(alts, "("+strings.Join(parts, " and ")+")")
+	}
+	return "(" + strings.Join(alts, " or ") + ")"
+}
+
+func keyOp(k sortKey) string {
+	if k.desc {
+		return "<"
+	}
+	return ">"
+}
+
+func orderClause(keys []sortKey) string {
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		if k.desc {
+			parts = append(parts, k.expr+" desc")
+		} else {
+			parts = append(parts, k.expr+" asc")
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// escapeLike экранирует спецсимволы шаблона LIKE.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -5,17 +5,82 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
 	"time"
+
+	"todo/internal/access"
+	"todo/internal/auth"
+
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
-// ErrNotFound возвращается, если задача не найдена.
-var ErrNotFound = errors.New("todo not found")
+const (
+	// foreignKeyViolation — код ошибки Postgres при нарушении внешнего ключа.
+	foreignKeyViolation = "23503"
+	// uniqueViolation — код ошибки Postgres при нарушении уникальности.
+	uniqueViolation = "23505"
+)
+
+var (
+	// ErrNotFound возвращается, если задача не найдена.
+	ErrNotFound = errors.New("todo not found")
+	// ErrVersionConflict возвращается, если версия задачи не совпала с ожидаемой.
+	ErrVersionConflict = errors.New("todo version conflict")
+	// ErrProjectNotFound возвращается, если задачу переносят в несуществующий проект.
+	ErrProjectNotFound = errors.New("project not found")
+	// ErrAlreadyExists возвращается, если задачу создают с занятым идентификатором или UID.
+	ErrAlreadyExists = errors.New("todo already exists")
+	// ErrOpenSubtasks возвращается, если у задачи есть открытые подзадачи,
+	// которые мешают её завершить.
+	ErrOpenSubtasks = errors.New("todo has open subtasks")
+	// ErrBlocked возвращается при завершении задачи, которую блокируют открытые задачи.
+	ErrBlocked = errors.New("todo is blocked")
+	// ErrDependencyCycle возвращается, если новая зависимость замкнула бы цикл.
+	ErrDependencyCycle = errors.New("todo dependency cycle")
+	// ErrParentCycle возвращается, если задача стала бы подзадачей самой себя.
+	ErrParentCycle = errors.New("todo cannot be a subtask of itself")
+)
 
-// Repository инкапсулирует доступ к таблице задач.
+// Repository инкапсулирует доступ к таблице задач. Все методы, кроме
+// фоновой очистки корзины, работают только с задачами арендатора
+// вызывающего из контекста.
 type Repository struct {
 	db *sql.DB
 }
 
+// Priority — приоритет задачи.
+type Priority int
+
+// Поддерживаемые приоритеты, от меньшего к большему.
+const (
+	PriorityNone Priority = iota
+	PriorityLow
+	PriorityMedium
+	PriorityHigh
+	PriorityUrgent
+)
+
+// Valid сообщает, входит ли приоритет в допустимый диапазон.
+func (p Priority) Valid() bool {
+	return p >= PriorityNone && p <= PriorityUrgent
+}
+
+// RecurrenceMode определяет, что происходит при завершении повторяющейся задачи.
+type RecurrenceMode int
+
+// Поддерживаемые режимы повторения.
+const (
+	// RecurrenceSpawn завершает задачу и создаёт следующее вхождение серии.
+	RecurrenceSpawn RecurrenceMode = iota
+	// RecurrenceAdvance оставляет задачу открытой и переносит её сроки на следующее вхождение.
+	RecurrenceAdvance
+)
+
+// Valid сообщает, поддерживается ли режим повторения.
+func (m RecurrenceMode) Valid() bool {
+	return m == RecurrenceSpawn || m == RecurrenceAdvance
+}
+
 // Record представляет запись задачи.
 type Record struct {
 	ID          string
@@ -24,6 +89,64 @@ type Record struct {
 	Completed   bool
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+	Version     int64
+	// OwnerID — пользователь, создавший задачу.
+	OwnerID string
+	// DeletedAt задан, если задача находится в корзине.
+	DeletedAt *time.Time
+	DueAt     *time.Time
+	StartAt   *time.Time
+	// AllDay означает, что DueAt и StartAt указывают на начало дня в TimeZone.
+	AllDay   bool
+	TimeZone string
+	Priority Priority
+	// Tags содержит нормализованные имена меток в алфавитном порядке.
+	Tags []string
+	// ProjectID не задан, если задача находится во «Входящих».
+	ProjectID *string
+	// ParentID задан у подзадач.
+	ParentID *string
+	// Recurrence — правило RRULE в каноническом виде; пустое у разовых задач.
+	Recurrence     string
+	RecurrenceMode RecurrenceMode
+	// SeriesID объединяет вхождения одной повторяющейся задачи.
+	SeriesID *string
+	// SeriesStart — начало серии, от которого разворачивается правило.
+	SeriesStart *time.Time
+	// CommentCount — число комментариев к задаче.
+	CommentCount int64
+	// Blocked вычисляется: у задачи есть незавершённая блокирующая задача вне корзины.
+	Blocked bool
+	// ICalUID — UID задачи в iCalendar; у созданных в сервисе задач совпадает с ID.
+	ICalUID string
+}
+
+// recordColumns перечисляет колонки, из которых собирается Record.
+const recordColumns = `id, title, description, completed, created_at, updated_at, version, owner_id, deleted_at,
+	due_at, start_at, all_day, time_zone, priority, project_id, parent_id, recurrence, recurrence_mode, series_id,
+	series_start, ical_uid`
+
+// querier обобщает *sql.DB и *sql.Tx.
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// rowScanner обобщает *sql.Row и *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanRecord(row rowScanner) (Record, error) {
+	var rec Record
+	err := row.Scan(
+		&rec.ID, &rec.Title, &rec.Description, &rec.Completed, &rec.CreatedAt, &rec.UpdatedAt, &rec.Version, &rec.OwnerID,
+		&rec.DeletedAt, &rec.DueAt, &rec.StartAt, &rec.AllDay, &rec.TimeZone, &rec.Priority, &rec.ProjectID,
+		&rec.ParentID, &rec.Recurrence, &rec.RecurrenceMode, &rec.SeriesID, &rec.SeriesStart,
+		&rec.ICalUID,
+	)
+	return rec, err
 }
 
 // NewRepository создает новый репозиторий задач.
@@ -31,106 +154,712 @@ func NewRepository(db *sql.DB) *Repository {
 	return &Repository{db: db}
 }
 
+// queryRecords выполняет запрос и собирает задачи вместе с их метками и блокировками.
+func queryRecords(ctx context.Context, q querier, query string, args ...any) ([]Record, error) {
+	items, err := scanRecords(q.QueryContext(ctx, query, args...))
+	if err != nil {
+		return nil, err
+	}
+	ptrs := make([]*Record, len(items))
+	for i := range items {
+		ptrs[i] = &items[i]
+	}
+	if err := loadDetails(ctx, q, ptrs); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// loadDetails дополняет задачи вычисляемыми полями из связанных таблиц.
+func loadDetails(ctx context.Context, q querier, recs []*Record) error {
+	if err := loadTags(ctx, q, recs); err != nil {
+		return err
+	}
+	if err := loadCommentCounts(ctx, q, recs); err != nil {
+		return err
+	}
+	return loadBlocked(ctx, q, recs)
+}
+
+func scanRecords(rows *sql.Rows, err error) ([]Record, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var items []Record
+	for rows.Next() {
+		rec, err := scanRecord(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, rec)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// withTx выполняет fn в транзакции и фиксирует её, если fn не вернула ошибку.
+// Транзакция сразу берёт блокировку ленты арендатора: журнал пишется после
+// блокировок строк, и взятая позже она приводила бы к взаимным блокировкам.
+func (r *Repository) withTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tenant, err := auth.TenantID(ctx)
+	if err != nil {
+		return err
+	}
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `select todo_events_lock($1)`, tenant); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// CreateParams описывает новую задачу.
+type CreateParams struct {
+	// ID задаёт идентификатор задачи; если не задан, он генерируется.
+	ID          *string
+	Title       string
+	Description string
+	DueAt       *time.Time
+	StartAt     *time.Time
+	AllDay      bool
+	TimeZone    string
+	Priority    Priority
+	Tags        []string
+	ProjectID   *string
+	ParentID    *string
+	// Recurrence задаёт правило RRULE; для разовой задачи пусто.
+	Recurrence     string
+	RecurrenceMode RecurrenceMode
+	// SeriesID продолжает существующую серию; если не задан, а правило есть,
+	// начинается новая серия.
+	SeriesID    *string
+	SeriesStart *time.Time
+	// OwnerID задаёт владельца задачи; если пуст, владельцем становится вызывающий.
+	OwnerID string
+	// Completed создаёт задачу сразу завершённой, например при загрузке из файла.
+	Completed bool
+	// ICalUID задаёт UID задачи в iCalendar; если не задан, им становится ID.
+	ICalUID *string
+}
+
 // Create добавляет новую задачу.
-func (r *Repository) Create(ctx context.Context, title, description string) (Record, error) {
-	now := time.Now().UTC()
+func (r *Repository) Create(ctx context.Context, params CreateParams) (Record, error) {
+	var rec Record
+	err := r.withTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		rec, err = insertRecord(ctx, tx, params)
+		return err
+	})
+	if err != nil {
+		return Record{}, err
+	}
+	return rec, nil
+}
+
+// insertRecord добавляет задачу вместе с метками в рамках транзакции.
+// Задача принадлежит арендатору вызывающего, а он сам становится её
+// владельцем, если владелец не задан явно.
+func insertRecord(ctx context.Context, tx *sql.Tx, params CreateParams) (Record, error) {
+	principal, err := auth.Require(ctx)
+	if err != nil {
+		return Record{}, err
+	}
+	owner := params.OwnerID
+	if owner == "" {
+		owner = principal.Subject
+	}
+	if params.ProjectID != nil {
+		if err := checkProject(ctx, tx, principal.TenantID, *params.ProjectID); err != nil {
+			return Record{}, err
+		}
+	}
 	query := `
-insert into todos (title, description, completed, created_at, updated_at)
-values ($1, $2, false, $3, $3)
-returning id, title, description, completed, created_at, updated_at`
+insert into todos (
+    id, title, description, completed, created_at, updated_at, due_at, start_at, all_day, time_zone, priority,
+    project_id, parent_id, recurrence, recurrence_mode, series_id, series_start, tenant_id, owner_id, ical_uid
+)
+values (
+    coalesce($17::uuid, gen_random_uuid()), $1, $2, $18, $3, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12,
+    case when $11 = '' then $13::uuid else coalesce($13::uuid, gen_random_uuid()) end, $14, $15, $16, $19
+)
+returning ` + recordColumns
 
-	var rec Record
-	if err := r.db.QueryRowContext(ctx, query, title, description, now).Scan(
-		&rec.ID, &rec.Title, &rec.Description, &rec.Completed, &rec.CreatedAt, &rec.UpdatedAt,
-	); err != nil {
+	rec, err := scanRecord(tx.QueryRowContext(ctx, query,
+		params.Title, params.Description, time.Now().UTC(), params.DueAt, params.StartAt, params.AllDay, params.TimeZone,
+		params.Priority, params.ProjectID, params.ParentID, params.Recurrence, params.RecurrenceMode, params.SeriesID,
+		params.SeriesStart, principal.TenantID, owner, params.ID, params.Completed, params.ICalUID,
+	))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation &&
+			(pgErr.ConstraintName == "todos_pkey" || pgErr.ConstraintName == "todos_ical_uid_idx") {
+			return Record{}, ErrAlreadyExists
+		}
+		return Record{}, projectError(err)
+	}
+	if err := attachTags(ctx, tx, rec.ID, params.Tags); err != nil {
+		return Record{}, err
+	}
+	if err := loadDetails(ctx, tx, []*Record{&rec}); err != nil {
+		return Record{}, err
+	}
+	if err := recordEvent(ctx, tx, OpCreate, nil, &rec); err != nil {
 		return Record{}, err
 	}
 	return rec, nil
 }
 
-// Get возвращает задачу по идентификатору.
+// Get возвращает задачу по идентификатору. Задачи из корзины не возвращаются.
 func (r *Repository) Get(ctx context.Context, id string) (Record, error) {
+	args := queryArgs{id}
+	cond, err := visible(ctx, "todos", &args)
+	if err != nil {
+		return Record{}, err
+	}
 	query := `
-select id, title, description, completed, created_at, updated_at
+select ` + recordColumns + `
 from todos
-where id = $1`
+where id = $1 and deleted_at is null and ` + cond
 
-	var rec Record
-	if err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&rec.ID, &rec.Title, &rec.Description, &rec.Completed, &rec.CreatedAt, &rec.UpdatedAt,
-	); err != nil {
+	rec, err := scanRecord(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return Record{}, ErrNotFound
 		}
 		return Record{}, err
 	}
+	if err := loadDetails(ctx, r.db, []*Record{&rec}); err != nil {
+		return Record{}, err
+	}
 	return rec, nil
 }
 
-// List возвращает список задач, отсортированных по дате создания.
-func (r *Repository) List(ctx context.Context) ([]Record, error) {
+// GetByICalUID возвращает задачу по UID iCalendar. Задачи из корзины не возвращаются.
+func (r *Repository) GetByICalUID(ctx context.Context, uid string) (Record, error) {
+	args := queryArgs{uid}
+	cond, err := visible(ctx, "todos", &args)
+	if err != nil {
+		return Record{}, err
+	}
 	query := `
-select id, title, description, completed, created_at, updated_at
+select ` + recordColumns + `
 from todos
-order by created_at desc`
+where ical_uid = $1 and deleted_at is null and ` + cond
 
-	rows, err := r.db.QueryContext(ctx, query)
+	rec, err := scanRecord(r.db.QueryRowContext(ctx, query, args...))
 	if err != nil {
-		return nil, err
+		if errors.Is(err, sql.ErrNoRows) {
+			return Record{}, ErrNotFound
+		}
+		return Record{}, err
 	}
-	defer func() {
-		_ = rows.Close()
-	}()
+	if err := loadDetails(ctx, r.db, []*Record{&rec}); err != nil {
+		return Record{}, err
+	}
+	return rec, nil
+}
 
-	var items []Record
-	for rows.Next() {
-		var rec Record
-		if err := rows.Scan(
-			&rec.ID, &rec.Title, &rec.Description, &rec.Completed, &rec.CreatedAt, &rec.UpdatedAt,
-		); err != nil {
-			return nil, err
+// Patch перечисляет изменяемые поля задачи; nil означает «оставить как есть».
+// Для сроков невалидное значение sql.NullTime снимает срок.
+type Patch struct {
+	Title       *string
+	Description *string
+	Completed   *bool
+	DueAt       *sql.NullTime
+	StartAt     *sql.NullTime
+	AllDay      *bool
+	TimeZone    *string
+	Priority    *Priority
+	// ProjectID переносит задачу в проект; невалидное значение — во «Входящие».
+	ProjectID *sql.NullString
+	// ParentID делает задачу подзадачей; невалидное значение — задачей верхнего уровня.
+	ParentID *sql.NullString
+	// Recurrence меняет правило повторения; непустое правило включает задачу в серию.
+	Recurrence     *string
+	RecurrenceMode *RecurrenceMode
+	SeriesStart    *sql.NullTime
+	// Tags заменяет метки задачи; недостающие метки создаются.
+	Tags *[]string
+	// CheckBlockers отклоняет изменение, если задачу блокируют открытые задачи.
+	// Проверка выполняется в той же транзакции под блокировкой строки задачи,
+	// поэтому не разминётся с одновременным добавлением зависимости.
+	CheckBlockers bool
+	// RefuseOpenSubtasks отклоняет изменение, если у задачи есть открытые
+	// подзадачи; подзадачи, завершённые раньше в той же транзакции, не мешают.
+	RefuseOpenSubtasks bool
+	// CompleteSubtasks в той же транзакции завершает все открытые подзадачи;
+	// если какую-то из них завершить нельзя, изменение отклоняется.
+	CompleteSubtasks bool
+	// Spawn, если задан, в той же транзакции создаёт следующее вхождение серии.
+	Spawn *CreateParams
+}
+
+// Update изменяет поля задачи, заданные в patch. Если version больше нуля,
+// изменение применяется только к задаче с этой версией.
+func (r *Repository) Update(ctx context.Context, id string, version int64, patch Patch) (Record, error) {
+	var rec Record
+	err := r.withTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		rec, err = updateRecord(ctx, tx, id, version, patch)
+		return err
+	})
+	if err != nil {
+		return Record{}, err
+	}
+	return rec, nil
+}
+
+// updateRecord изменяет задачу в рамках транзакции.
+func updateRecord(ctx context.Context, tx *sql.Tx, id string, version int64, patch Patch) (Record, error) {
+	principal, err := auth.Require(ctx)
+	if err != nil {
+		return Record{}, err
+	}
+	args := queryArgs{id}
+	cond, err := editable(ctx, "todos", &args)
+	if err != nil {
+		return Record{}, err
+	}
+	conds := []string{"id = $1", "deleted_at is null", cond}
+	if version > 0 {
+		conds = append(conds, "version = "+args.add(version))
+	}
+	sets := []string{"version = version + 1", "updated_at = " + args.add(time.Now().UTC())}
+	if patch.Title != nil {
+		sets = append(sets, "title = "+args.add(*patch.Title))
+	}
+	if patch.Description != nil {
+		sets = append(sets, "description = "+args.add(*patch.Description))
+	}
+	if patch.Completed != nil {
+		sets = append(sets, "completed = "+args.add(*patch.Completed))
+	}
+	if patch.DueAt != nil {
+		sets = append(sets, "due_at = "+args.add(*patch.DueAt))
+	}
+	if patch.StartAt != nil {
+		sets = append(sets, "start_at = "+args.add(*patch.StartAt))
+	}
+	if patch.AllDay != nil {
+		sets = append(sets, "all_day = "+args.add(*patch.AllDay))
+	}
+	if patch.TimeZone != nil {
+		sets = append(sets, "time_zone = "+args.add(*patch.TimeZone))
+	}
+	if patch.Priority != nil {
+		sets = append(sets, "priority = "+args.add(*patch.Priority))
+	}
+	if patch.ProjectID != nil {
+		sets = append(sets, "project_id = "+args.add(*patch.ProjectID))
+	}
+	if patch.ParentID != nil {
+		sets = append(sets, "parent_id = "+args.add(*patch.ParentID))
+	}
+	if patch.Recurrence != nil {
+		sets = append(sets, "recurrence = "+args.add(*patch.Recurrence))
+		if *patch.Recurrence != "" {
+			sets = append(sets, "series_id = coalesce(series_id, gen_random_uuid())")
 		}
-		items = append(items, rec)
 	}
-	if err := rows.Err(); err != nil {
-		return nil, err
+	if patch.RecurrenceMode != nil {
+		sets = append(sets, "recurrence_mode = "+args.add(*patch.RecurrenceMode))
 	}
-	return items, nil
+	if patch.SeriesStart != nil {
+		sets = append(sets, "series_start = "+args.add(*patch.SeriesStart))
+	}
+	query := `
+update todos
+set ` + strings.Join(sets, ", ") + `
+where ` + strings.Join(conds, " and ") + `
+returning ` + recordColumns
+
+	if patch.ProjectID != nil && patch.ProjectID.Valid {
+		if err := checkProject(ctx, tx, principal.TenantID, patch.ProjectID.String); err != nil {
+			return Record{}, err
+		}
+	}
+	before, err := lockRecord(ctx, tx, id)
+	if err != nil {
+		return Record{}, err
+	}
+	if patch.ParentID != nil && patch.ParentID.Valid {
+		// Проверка идёт под блокировкой ленты арендатора, которую держит
+		// транзакция: параллельная смена родителя не замкнёт цикл.
+		cycle, err := hasAncestor(ctx, tx, principal.TenantID, patch.ParentID.String, id)
+		if err != nil {
+			return Record{}, err
+		}
+		if cycle {
+			return Record{}, ErrParentCycle
+		}
+	}
+	rec, err := scanRecord(tx.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Record{}, missingError(ctx, tx, id)
+		}
+		return Record{}, projectError(err)
+	}
+	if patch.Tags != nil {
+		if err := replaceTags(ctx, tx, id, *patch.Tags); err != nil {
+			return Record{}, err
+		}
+	}
+	if patch.CheckBlockers {
+		if err := checkBlockers(ctx, tx, principal.TenantID, id); err != nil {
+			return Record{}, err
+		}
+	}
+	if patch.RefuseOpenSubtasks {
+		if err := checkOpenDescendants(ctx, tx, principal.TenantID, id); err != nil {
+			return Record{}, err
+		}
+	}
+	if patch.CompleteSubtasks {
+		if err := completeDescendants(ctx, tx, principal, id); err != nil {
+			return Record{}, err
+		}
+	}
+	if patch.Spawn != nil {
+		// Новая серия у задачи могла появиться в этом же запросе.
+		// Следующее вхождение остаётся за владельцем серии и наследует
+		// выданные на задачу роли, кто бы ни завершил текущее.
+		spawn := *patch.Spawn
+		spawn.SeriesID = rec.SeriesID
+		spawn.OwnerID = rec.OwnerID
+		next, err := insertRecord(ctx, tx, spawn)
+		if err != nil {
+			return Record{}, err
+		}
+		if _, err := tx.ExecContext(ctx, `
+insert into todo_grants (todo_id, grantee_id, role, created_at)
+select $2, grantee_id, role, created_at from todo_grants where todo_id = $1`, id, next.ID); err != nil {
+			return Record{}, err
+		}
+	}
+	if err := loadDetails(ctx, tx, []*Record{&rec}); err != nil {
+		return Record{}, err
+	}
+	if err := recordEvent(ctx, tx, OpUpdate, &before, &rec); err != nil {
+		return Record{}, err
+	}
+	return rec, nil
+}
+
+// Delete перемещает задачу в корзину вместе с её комментариями. Если version
+// больше нуля, удаляется только задача с этой версией.
+func (r *Repository) Delete(ctx context.Context, id string, version int64) error {
+	return r.withTx(ctx, func(tx *sql.Tx) error {
+		return deleteRecord(ctx, tx, id, version)
+	})
 }
 
-// Update изменяет существующую задачу.
-func (r *Repository) Update(ctx context.Context, id, title, description string, completed bool) (Record, error) {
+// deleteRecord перемещает задачу в корзину в рамках транзакции.
+func deleteRecord(ctx context.Context, tx *sql.Tx, id string, version int64) error {
+	args := queryArgs{id}
+	cond, err := editable(ctx, "todos", &args)
+	if err != nil {
+		return err
+	}
 	now := time.Now().UTC()
+	conds := []string{"id = $1", "deleted_at is null", cond}
+	if version > 0 {
+		conds = append(conds, "version = "+args.add(version))
+	}
 	query := `
 update todos
-set title = $2, description = $3, completed = $4, updated_at = $5
-where id = $1
-returning id, title, description, completed, created_at, updated_at`
+set deleted_at = ` + args.add(now) + `, version = version + 1
+where ` + strings.Join(conds, " and ") + `
+returning ` + recordColumns
 
-	var rec Record
-	if err := r.db.QueryRowContext(ctx, query, id, title, description, completed, now).Scan(
-		&rec.ID, &rec.Title, &rec.Description, &rec.Completed, &rec.CreatedAt, &rec.UpdatedAt,
-	); err != nil {
+	before, err := lockRecord(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	rec, err := scanRecord(tx.QueryRowContext(ctx, query, args...))
+	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return Record{}, ErrNotFound
+			return missingError(ctx, tx, id)
 		}
+		return err
+	}
+	rec.Tags = before.Tags
+	if err := recordEvent(ctx, tx, OpDelete, &before, &rec); err != nil {
+		return err
+	}
+	// Комментарии уходят в корзину с той же отметкой, что и задача:
+	// по ней Restore вернёт именно их, а Purge удалит каскадно.
+	_, err = tx.ExecContext(ctx,
+		`update comments set deleted_at = $2 where todo_id = $1 and deleted_at is null`, id, now)
+	return err
+}
+
+// Restore возвращает задачу из корзины вместе с комментариями, удалёнными
+// вместе с ней. Если её UID в календаре уже занят другой задачей, возвращает
+// ErrAlreadyExists.
+func (r *Repository) Restore(ctx context.Context, id string) (Record, error) {
+	args := queryArgs{id}
+	cond, err := editable(ctx, "t", &args)
+	if err != nil {
+		return Record{}, err
+	}
+	// Комментарии восстанавливаются в том же запросе: подзапрос видит
+	// отметку удаления задачи до её сброса.
+	query := `
+with restored_comments as (
+    update comments c
+    set deleted_at = null
+    from todos t
+    where t.id = $1 and c.todo_id = t.id and c.deleted_at = t.deleted_at and ` + cond + `
+)
+update todos t
+set deleted_at = null, version = version + 1, updated_at = ` + args.add(time.Now().UTC()) + `
+where id = $1 and deleted_at is not null and ` + cond + `
+returning ` + recordColumns
+
+	var rec Record
+	err = r.withTx(ctx, func(tx *sql.Tx) error {
+		before, err := lockRecord(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		rec, err = scanRecord(tx.QueryRowContext(ctx, query, args...))
+		if err != nil {
+			var pgErr *pgconn.PgError
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				return ErrNotFound
+			case errors.As(err, &pgErr) && pgErr.Code == uniqueViolation && pgErr.ConstraintName == "todos_ical_uid_idx":
+				return ErrAlreadyExists
+			}
+			return err
+		}
+		if err := loadDetails(ctx, tx, []*Record{&rec}); err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, OpRestore, &before, &rec)
+	})
+	if err != nil {
 		return Record{}, err
 	}
 	return rec, nil
 }
 
-// Delete удаляет задачу по идентификатору.
-func (r *Repository) Delete(ctx context.Context, id string) error {
-	res, err := r.db.ExecContext(ctx, `delete from todos where id = $1`, id)
+// Purge окончательно удаляет задачу, находящуюся в корзине, и записывает
+// это в журнал.
+func (r *Repository) Purge(ctx context.Context, id string) error {
+	principal, err := auth.Require(ctx)
 	if err != nil {
 		return err
 	}
-	affected, err := res.RowsAffected()
+	args := queryArgs{id}
+	cond, err := visible(ctx, "todos", &args)
 	if err != nil {
 		return err
 	}
-	if affected == 0 {
-		return ErrNotFound
+	query := `
+with purged as (
+    delete from todos where id = $1 and deleted_at is not null and ` + cond + `
+    returning id, tenant_id, owner_id
+)` + purgeEvents(args.add(principal.Subject), args.add(time.Now().UTC()))
+	return r.withTx(ctx, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return ErrNotFound
+		}
+		return nil
+	})
+}
+
+// PurgeDeletedBefore окончательно удаляет задачи всех арендаторов, попавшие
+// в корзину раньше before, записывает это в журнал и возвращает их количество.
+func (r *Repository) PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	// Блокировки лент берутся до удаления строк и в одном порядке, как и
+	// в транзакциях арендаторов. Задачи, попавшие в корзину позже, сюда не
+	// подходят: их deleted_at не раньше before.
+	var tenants []string
+	rows, err := tx.QueryContext(ctx,
+		`select distinct tenant_id from todos where deleted_at < $1 order by tenant_id`, before)
+	if err != nil {
+		return 0, err
+	}
+	for rows.Next() {
+		var tenant string
+		if err := rows.Scan(&tenant); err != nil {
+			_ = rows.Close()
+			return 0, err
+		}
+		tenants = append(tenants, tenant)
+	}
+	if err := rows.Close(); err != nil {
+		return 0, err
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	if len(tenants) == 0 {
+		return 0, nil
+	}
+	for _, tenant := range tenants {
+		if _, err := tx.ExecContext(ctx, `select todo_events_lock($1)`, tenant); err != nil {
+			return 0, err
+		}
+	}
+
+	res, err := tx.ExecContext(ctx, `
+with purged as (
+    delete from todos where deleted_at < $1 and tenant_id = any($4::text[])
+    returning id, tenant_id, owner_id
+)`+purgeEvents("$2", "$3"),
+		before, retentionActor, time.Now().UTC(), tenants)
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return n, tx.Commit()
+}
+
+// loadCommentCounts одним запросом подсчитывает комментарии у всех переданных задач.
+// У задачи в корзине учитываются комментарии, удалённые вместе с ней.
+func loadCommentCounts(ctx context.Context, q querier, recs []*Record) error {
+	if len(recs) == 0 {
+		return nil
+	}
+	byID := make(map[string]*Record, len(recs))
+	ids := make([]string, 0, len(recs))
+	for _, rec := range recs {
+		rec.CommentCount = 0
+		byID[rec.ID] = rec
+		ids = append(ids, rec.ID)
+	}
+
+	rows, err := q.QueryContext(ctx, `
+select c.todo_id, count(*)
+from comments c
+join todos t on t.id = c.todo_id
+where c.todo_id = any($1::text[]::uuid[]) and c.deleted_at is not distinct from t.deleted_at
+group by c.todo_id`, ids)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+		var (
+			todoID string
+			count  int64
+		)
+		if err := rows.Scan(&todoID, &count); err != nil {
+			return err
+		}
+		if rec, ok := byID[todoID]; ok {
+			rec.CommentCount = count
+		}
+	}
+	return rows.Err()
+}
+
+// visible возвращает условие видимости задачи alias для вызывающего
+// и добавляет его параметры в args.
+func visible(ctx context.Context, alias string, args *queryArgs) (string, error) {
+	principal, err := auth.Require(ctx)
+	if err != nil {
+		return "", err
+	}
+	return access.TodoVisible(alias, args.add(principal.TenantID), args.add(principal.Subject)), nil
+}
+
+// editable возвращает условие «вызывающий может изменять задачу alias» и
+// добавляет его параметры в args. Запросы на изменение проверяют права
+// этим условием сами, не полагаясь на проверку перед транзакцией.
+func editable(ctx context.Context, alias string, args *queryArgs) (string, error) {
+	principal, err := auth.Require(ctx)
+	if err != nil {
+		return "", err
+	}
+	return access.TodoEditable(alias, args.add(principal.TenantID), args.add(principal.Subject)), nil
+}
+
+// checkProject проверяет, что проект существует и принадлежит арендатору:
+// внешний ключ не защищает от ссылки на чужой проект.
+func checkProject(ctx context.Context, q querier, tenant, projectID string) error {
+	var exists bool
+	err := q.QueryRowContext(ctx,
+		`select exists(select 1 from projects where id = $1 and tenant_id = $2)`, projectID, tenant).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrProjectNotFound
 	}
 	return nil
 }
+
+// projectError заменяет нарушение внешнего ключа на проект на ErrProjectNotFound.
+func projectError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation && pgErr.ConstraintName == "todos_project_id_fkey" {
+		return ErrProjectNotFound
+	}
+	return err
+}
+
+// missingError объясняет, почему условная запись не затронула строку:
+// активной задачи нет совсем, вызывающий не может её изменять либо её
+// версия уже изменилась.
+func missingError(ctx context.Context, q querier, id string) error {
+	args := queryArgs{id}
+	visibleCond, err := visible(ctx, "todos", &args)
+	if err != nil {
+		return err
+	}
+	editableCond, err := editable(ctx, "todos", &args)
+	if err != nil {
+		return err
+	}
+	query := `select ` + editableCond + ` from todos where id = $1 and deleted_at is null and ` + visibleCond
+	var canEdit bool
+	if err := q.QueryRowContext(ctx, query, args...).Scan(&canEdit); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		return err
+	}
+	if !canEdit {
+		return access.ErrPermissionDenied
+	}
+	return ErrVersionConflict
+}
@@ -0,0 +1,174 @@
+package todo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// TagUsage описывает метку и число активных задач с ней.
+type TagUsage struct {
+	Name  string
+	Count int64
+}
+
+// AddTags добавляет задаче метки, создавая недостающие.
+func (r *Repository) AddTags(ctx context.Context, id string, names []string) (Record, error) {
+	return r.changeLinks(ctx, id, func(tx *sql.Tx) error {
+		return attachTags(ctx, tx, id, names)
+	})
+}
+
+// RemoveTags снимает с задачи перечисленные метки.
+func (r *Repository) RemoveTags(ctx context.Context, id string, names []string) (Record, error) {
+	return r.changeLinks(ctx, id, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+delete from todo_tags
+where todo_id = $1 and tag_id in (select id from tags where name = any($2))`, id, names)
+		return err
+	})
+}
+
+// changeLinks увеличивает версию задачи и в той же транзакции меняет её связи:
+// метки или зависимости.
+func (r *Repository) changeLinks(ctx context.Context, id string, change func(tx *sql.Tx) error) (Record, error) {
+	args := queryArgs{id, time.Now().UTC()}
+	cond, err := editable(ctx, "todos", &args)
+	if err != nil {
+		return Record{}, err
+	}
+	query := `
+update todos
+set version = version + 1, updated_at = $2
+where id = $1 and deleted_at is null and ` + cond + `
+returning ` + recordColumns
+
+	var rec Record
+	err = r.withTx(ctx, func(tx *sql.Tx) error {
+		before, err := lockRecord(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		rec, err = scanRecord(tx.QueryRowContext(ctx, query, args...))
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrNotFound
+			}
+			return err
+		}
+		if err := change(tx); err != nil {
+			return err
+		}
+		if err := loadDetails(ctx, tx, []*Record{&rec}); err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, OpUpdate, &before, &rec)
+	})
+	if err != nil {
+		return Record{}, err
+	}
+	return rec, nil
+}
+
+// ListTags возвращает используемые метки с числом активных задач.
+func (r *Repository) ListTags(ctx context.Context) ([]TagUsage, error) {
+	var args queryArgs
+	cond, err := visible(ctx, "td", &args)
+	if err != nil {
+		return nil, err
+	}
+	// Сами метки общие для всех арендаторов, поэтому видимость
+	// определяется задачами, к которым они привязаны.
+	query := `
+select t.name, count(*)
+from tags t
+join todo_tags tt on tt.tag_id = t.id
+join todos td on td.id = tt.todo_id and td.deleted_at is null and ` + cond + `
+group by t.name
+order by t.name`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var items []TagUsage
+	for rows.Next() {
+		var tag TagUsage
+		if err := rows.Scan(&tag.Name, &tag.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, tag)
+	}
+	return items, rows.Err()
+}
+
+// attachTags создаёт недостающие метки и привязывает их к задаче.
+func attachTags(ctx context.Context, q querier, todoID string, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	if _, err := q.ExecContext(ctx, `
+insert into tags (name)
+select unnest($1::text[])
+on conflict (name) do nothing`, names); err != nil {
+		return err
+	}
+	_, err := q.ExecContext(ctx, `
+insert into todo_tags (todo_id, tag_id)
+select $1, id from tags where name = any($2)
+on conflict do nothing`, todoID, names)
+	return err
+}
+
+// replaceTags приводит метки задачи к names.
+func replaceTags(ctx context.Context, q querier, todoID string, names []string) error {
+	if _, err := q.ExecContext(ctx, `
+delete from todo_tags
+where todo_id = $1 and tag_id not in (select id from tags where name = any($2))`, todoID, names); err != nil {
+		return err
+	}
+	return attachTags(ctx, q, todoID, names)
+}
+
+// loadTags одним запросом заполняет метки у всех переданных задач.
+func loadTags(ctx context.Context, q querier, recs []*Record) error {
+	if len(recs) == 0 {
+		return nil
+	}
+	byID := make(map[string]*Record, len(recs))
+	ids := make([]string, 0, len(recs))
+	for _, rec := range recs {
+		rec.Tags = []string{}
+		byID[rec.ID] = rec
+		ids = append(ids, rec.ID)
+	}
+
+	rows, err := q.QueryContext(ctx, `
+select tt.todo_id, t.name
+from todo_tags tt
+join tags t on t.id = tt.tag_id
+where tt.todo_id = any($1::text[]::uuid[])
+order by t.name`, ids)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+		var todoID, name string
+		if err := rows.Scan(&todoID, &name); err != nil {
+			return err
+		}
+		if rec, ok := byID[todoID]; ok {
+			rec.Tags = append(rec.Tags, name)
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,216 @@
+package todo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"todo/internal/access"
+	"todo/internal/auth"
+)
+
+// recursionLimit ограничивает глубину рекурсивных запросов по иерархии задач,
+// чтобы случайный цикл в данных не зациклил запрос.
+const recursionLimit = 1000
+
+// Tree возвращает задачу и всех её потомков вне корзины. Порядок записей
+// не определён; связи восстанавливаются по ParentID.
+func (r *Repository) Tree(ctx context.Context, id string) ([]Record, error) {
+	args := queryArgs{id, recursionLimit}
+	cond, err := visible(ctx, "todos", &args)
+	if err != nil {
+		return nil, err
+	}
+	// Подзадачи, не видимые вызывающему, исключаются вместе с их потомками.
+	query := `
+with recursive tree as (
+    select id, 0 as depth from todos where id = $1 and deleted_at is null and ` + cond + `
+    union all
+    select todos.id, tree.depth + 1
+    from todos
+    join tree on todos.parent_id = tree.id
+    where todos.deleted_at is null and tree.depth < $2 and ` + cond + `
+)
+select ` + recordColumns + `
+from todos
+where id in (select id from tree)`
+
+	recs, err := queryRecords(ctx, r.db, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	if len(recs) == 0 {
+		return nil, ErrNotFound
+	}
+	return recs, nil
+}
+
+// Ancestors возвращает цепочку идентификаторов от задачи id (первый элемент)
+// до корня иерархии. Для задачи в корзине или несуществующей задачи
+// возвращается ErrNotFound.
+func (r *Repository) Ancestors(ctx context.Context, id string) ([]string, error) {
+	principal, err := auth.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Сама задача должна быть видна вызывающему, а её предки учитываются
+	// все: от них зависят глубина и циклы.
+	query := `
+with recursive chain as (
+    select id, parent_id, 1 as depth from todos
+    where id = $1 and deleted_at is null and ` + access.TodoVisible("todos", "$3", "$4") + `
+    union all
+    select t.id, t.parent_id, chain.depth + 1
+    from todos t
+    join chain on t.id = chain.parent_id
+    where t.tenant_id = $3 and chain.depth < $2
+)
+select id from chain order by depth`
+
+	rows, err := r.db.QueryContext(ctx, query, id, recursionLimit, principal.TenantID, principal.Subject)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var ids []string
+	for rows.Next() {
+		var ancestor string
+		if err := rows.Scan(&ancestor); err != nil {
+			return nil, err
+		}
+		ids = append(ids, ancestor)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, ErrNotFound
+	}
+	return ids, nil
+}
+
+// hasAncestor сообщает, совпадает ли задача id с ancestorID или лежит под
+// ней в иерархии. Учитываются все предки, в том числе невидимые вызывающему.
+func hasAncestor(ctx context.Context, q querier, tenant, id, ancestorID string) (bool, error) {
+	query := `
+with recursive chain as (
+    select id, parent_id, 1 as depth from todos where id = $1 and tenant_id = $3
+    union all
+    select t.id, t.parent_id, chain.depth + 1
+    from todos t
+    join chain on t.id = chain.parent_id
+    where t.tenant_id = $3 and chain.depth < $4
+)
+select exists (select 1 from chain where id = $2)`
+
+	var found bool
+	if err := q.QueryRowContext(ctx, query, id, ancestorID, tenant, recursionLimit).Scan(&found); err != nil {
+		return false, err
+	}
+	return found, nil
+}
+
+// SubtreeHeight возвращает число уровней в поддереве задачи: 1 для задачи
+// без подзадач.
+func (r *Repository) SubtreeHeight(ctx context.Context, id string) (int, error) {
+	tenant, err := auth.TenantID(ctx)
+	if err != nil {
+		return 0, err
+	}
+	query := `
+with recursive tree as (
+    select id, 1 as depth from todos where id = $1 and tenant_id = $3
+    union all
+    select t.id, tree.depth + 1
+    from todos t
+    join tree on t.parent_id = tree.id
+    where t.tenant_id = $3 and t.deleted_at is null and tree.depth < $2
+)
+select coalesce(max(depth), 0) from tree`
+
+	var height int
+	if err := r.db.QueryRowContext(ctx, query, id, recursionLimit, tenant).Scan(&height); err != nil {
+		return 0, err
+	}
+	return height, nil
+}
+
+// checkOpenDescendants возвращает ErrOpenSubtasks, если у задачи id есть
+// незавершённые потомки вне корзины.
+func checkOpenDescendants(ctx context.Context, q querier, tenant, id string) error {
+	query := `
+with recursive tree as (
+    select id, 0 as depth from todos where id = $1 and tenant_id = $3
+    union all
+    select t.id, tree.depth + 1
+    from todos t
+    join tree on t.parent_id = tree.id
+    where t.tenant_id = $3 and t.deleted_at is null and tree.depth < $2
+)
+select count(*) from todos
+where id in (select id from tree where depth > 0) and not completed`
+
+	var n int
+	if err := q.QueryRowContext(ctx, query, id, recursionLimit, tenant).Scan(&n); err != nil {
+		return err
+	}
+	if n > 0 {
+		return fmt.Errorf("%w: %d", ErrOpenSubtasks, n)
+	}
+	return nil
+}
+
+// uncompletableCondition отбирает задачи alias, которые нельзя завершить
+// вместе с родительской: вызывающий не может их изменять, их блокируют
+// открытые задачи или они повторяются — следующее вхождение серии
+// создаётся, только когда задача завершается сама по себе. tenant и user —
+// плейсхолдеры параметров.
+func uncompletableCondition(alias, tenant, user string) string {
+	return alias + `.recurrence <> '' or not (` + access.TodoEditable(alias, tenant, user) + `) or exists (
+    select 1 from todo_dependencies d
+    where d.todo_id = ` + alias + `.id and ` + openBlockerCondition + `)`
+}
+
+// completeDescendants завершает всех открытых потомков задач ids вне корзины
+// и одним запросом записывает в журнал событие для каждого из них. Если
+// хотя бы одного из них завершить нельзя, ничего не меняется и возвращается
+// ErrOpenSubtasks.
+func completeDescendants(ctx context.Context, q querier, principal auth.Principal, ids ...string) error {
+	const tree = `
+with recursive tree as (
+    select id, 0 as depth from todos where id = any($1::text[]::uuid[]) and tenant_id = $3
+    union all
+    select t.id, tree.depth + 1
+    from todos t
+    join tree on t.parent_id = tree.id
+    where t.tenant_id = $3 and t.deleted_at is null and tree.depth < $2
+)`
+	var stuck int
+	err := q.QueryRowContext(ctx, tree+`
+select count(*) from todos s
+where s.id in (select id from tree where depth > 0) and not s.completed
+  and (`+uncompletableCondition("s", "$3", "$4")+`)`,
+		ids, recursionLimit, principal.TenantID, principal.Subject).Scan(&stuck)
+	if err != nil {
+		return err
+	}
+	if stuck > 0 {
+		return fmt.Errorf("%w: %d of them are blocked, recurring or not editable by the caller", ErrOpenSubtasks, stuck)
+	}
+
+	query := tree + `, done as (
+    update todos
+    set completed = true, version = version + 1, updated_at = $5
+    where id in (select id from tree where depth > 0) and not completed
+    returning id
+)
+insert into todo_events (todo_id, tenant_id, actor, operation, changes, created_at)
+select id, $3, $4, 'update', '{"completed": {"before": false, "after": true}}', $5
+from done`
+
+	_, err = q.ExecContext(ctx, query, ids, recursionLimit, principal.TenantID, principal.Subject, time.Now().UTC())
+	return err
+}
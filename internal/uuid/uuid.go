@@ -0,0 +1,57 @@
+// Package uuid разбирает идентификаторы UUID в тех записях, которые
+// принимает Postgres, и приводит их к каноническому виду.
+package uuid
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// ErrInvalid возвращается, если строка не является UUID.
+var ErrInvalid = errors.New("invalid uuid")
+
+// UUID — 128-битный идентификатор.
+type UUID [16]byte
+
+// Parse разбирает UUID в любом регистре, в фигурных скобках, без дефисов
+// или с дефисом после любой группы из четырёх цифр, как это делает Postgres.
+func Parse(s string) (UUID, error) {
+	var u UUID
+	if strings.HasPrefix(s, "{") {
+		if !strings.HasSuffix(s, "}") {
+			return u, ErrInvalid
+		}
+		s = s[1 : len(s)-1]
+	}
+	digits := make([]byte, 0, 2*len(u))
+	for i := 0; i < len(s); i++ {
+		// Дефис допустим только между группами из четырёх цифр.
+		if s[i] == '-' && len(digits) > 0 && len(digits)%4 == 0 && i+1 < len(s) && s[i+1] != '-' {
+			continue
+		}
+		digits = append(digits, s[i])
+	}
+	if len(digits) != 2*len(u) {
+		return u, ErrInvalid
+	}
+	if _, err := hex.Decode(u[:], digits); err != nil {
+		return u, ErrInvalid
+	}
+	return u, nil
+}
+
+// String возвращает канонический вид: строчные цифры с дефисами 8-4-4-4-12.
+func (u UUID) String() string {
+	buf := make([]byte, 36)
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf)
+}
@@ -0,0 +1,45 @@
+package uuid
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseCanonicalizes(t *testing.T) {
+	const want = "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+	for _, in := range []string{
+		want,
+		"A0EEBC99-9C0B-4EF8-BB6D-6BB9BD380A11",
+		"{a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11}",
+		"a0eebc999c0b4ef8bb6d6bb9bd380a11",
+		"a0ee-bc99-9c0b-4ef8-bb6d-6bb9-bd38-0a11",
+		"{a0eebc99-9c0b4ef8-bb6d6bb9-bd380a11}",
+	} {
+		u, err := Parse(in)
+		if err != nil {
+			t.Fatalf("parse %q: %v", in, err)
+		}
+		if got := u.String(); got != want {
+			t.Fatalf("parse %q: got %s, want %s", in, got, want)
+		}
+	}
+}
+
+func TestParseRejectsMalformed(t *testing.T) {
+	for _, in := range []string{
+		"",
+		"not-a-uuid",
+		"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a1",
+		"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a111",
+		"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a1g",
+		"{a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11",
+		"-a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11",
+		"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11-",
+		"a0eebc99--9c0b-4ef8-bb6d-6bb9bd380a11",
+		"a0e-ebc99-9c0b-4ef8-bb6d-6bb9bd380a11",
+	} {
+		if _, err := Parse(in); !errors.Is(err, ErrInvalid) {
+			t.Fatalf("expected %q to be rejected, got %v", in, err)
+		}
+	}
+}
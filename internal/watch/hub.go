@@ -0,0 +1,136 @@
+// Package watch рассылает подписчикам сигналы об изменениях задач,
+// получаемые из Postgres через LISTEN/NOTIFY. Так об изменении узнают
+// подписчики всех реплик, а не только той, что его записала.
+package watch
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+// Channel — канал уведомлений, в который пишет триггер журнала задач.
+// Payload уведомления — арендатор изменившейся задачи.
+const Channel = "todo_events"
+
+// reconnectDelay — пауза перед повторным подключением после ошибки.
+const reconnectDelay = time.Second
+
+// Hub держит одно соединение с LISTEN на реплику и будит подписчиков
+// арендатора, в задачах которого что-то изменилось.
+type Hub struct {
+	db *sql.DB
+
+	mu   sync.Mutex
+	subs map[string]map[chan struct{}]struct{}
+}
+
+// NewHub создаёт хаб; уведомления начинают поступать после запуска Run.
+func NewHub(db *sql.DB) *Hub {
+	return &Hub{db: db, subs: make(map[string]map[chan struct{}]struct{})}
+}
+
+// Subscribe подписывает на изменения задач арендатора tenant. Сигналы
+// не накапливаются: подписчик, не успевший прочитать сигнал, получит один.
+// Возвращаемую функцию нужно вызвать, чтобы отписаться.
+func (h *Hub) Subscribe(tenant string) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+	h.mu.Lock()
+	if h.subs[tenant] == nil {
+		h.subs[tenant] = make(map[chan struct{}]struct{})
+	}
+	h.subs[tenant][ch] = struct{}{}
+	h.mu.Unlock()
+
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.subs[tenant], ch)
+		if len(h.subs[tenant]) == 0 {
+			delete(h.subs, tenant)
+		}
+	}
+}
+
+// Run слушает уведомления и переподключается после ошибок.
+// Блокируется до отмены ctx.
+func (h *Hub) Run(ctx context.Context) {
+	for {
+		err := h.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("watch todos: %v", err)
+		// Пока соединения не было, уведомления могли потеряться, поэтому
+		// подписчики перечитывают журнал сами.
+		h.notifyAll()
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+func (h *Hub) listen(ctx context.Context) error {
+	conn, err := h.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	return conn.Raw(func(driverConn any) error {
+		sc, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return errors.New("database driver does not support LISTEN")
+		}
+		pc := sc.Conn()
+		if _, err := pc.Exec(ctx, "listen "+Channel); err != nil {
+			return err
+		}
+		// Уведомления, отправленные до LISTEN, подписчики могли не получить.
+		h.notifyAll()
+		for {
+			n, err := pc.WaitForNotification(ctx)
+			if err != nil {
+				// Соединение не возвращается в пул слушающим: после отмены
+				// оно закрывается, и пул его отбросит.
+				_ = pc.Close(context.Background())
+				return err
+			}
+			h.notify(n.Payload)
+		}
+	})
+}
+
+func (h *Hub) notify(tenant string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subs[tenant] {
+		signal(ch)
+	}
+}
+
+func (h *Hub) notifyAll() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, subs := range h.subs {
+		for ch := range subs {
+			signal(ch)
+		}
+	}
+}
+
+func signal(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
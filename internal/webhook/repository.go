@@ -0,0 +1,319 @@
+// Package webhook содержит репозиторий вебхуков, outbox событий и журнала доставок.
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"todo/internal/access"
+	"todo/internal/auth"
+)
+
+// ErrNotFound возвращается, если вебхук не найден или не принадлежит вызывающему.
+var ErrNotFound = errors.New("webhook not found")
+
+// Status — состояние доставки события на вебхук.
+type Status string
+
+// Состояния доставки.
+const (
+	// StatusPending — доставка ждёт очередной попытки.
+	StatusPending Status = "pending"
+	// StatusSucceeded — получатель ответил кодом 2xx.
+	StatusSucceeded Status = "succeeded"
+	// StatusDead — попытки исчерпаны, доставка больше не повторяется.
+	StatusDead Status = "dead"
+)
+
+// Repository инкапсулирует доступ к вебхукам и их доставкам.
+type Repository struct {
+	db *sql.DB
+}
+
+// Record представляет вебхук без секрета.
+type Record struct {
+	ID        string
+	TenantID  string
+	OwnerID   string
+	URL       string
+	CreatedAt time.Time
+}
+
+// Delivery — запись журнала доставок события на вебхук.
+type Delivery struct {
+	ID        int64
+	WebhookID string
+	// EventID — номер события в outbox; одинаков для всех вебхуков.
+	EventID        int64
+	EventType      string
+	Status         Status
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastAttemptAt  *time.Time
+	LastStatusCode int
+	LastError      string
+	CreatedAt      time.Time
+}
+
+// Task — доставка, захваченная диспетчером для очередной попытки.
+type Task struct {
+	DeliveryID int64
+	EventID    int64
+	EventType  string
+	// Attempts — число уже сделанных попыток.
+	Attempts int
+	URL      string
+	Secret   string
+	Payload  []byte
+}
+
+// Outcome — результат попытки доставки.
+type Outcome struct {
+	Status     Status
+	StatusCode int
+	Error      string
+	At         time.Time
+	// NextAttemptAt имеет смысл для StatusPending.
+	NextAttemptAt time.Time
+}
+
+// recordColumns перечисляет колонки, из которых собирается Record.
+const recordColumns = `id, tenant_id, owner_id, url, created_at`
+
+func scanRecord(row interface{ Scan(dest ...any) error }) (Record, error) {
+	var rec Record
+	err := row.Scan(&rec.ID, &rec.TenantID, &rec.OwnerID, &rec.URL, &rec.CreatedAt)
+	return rec, err
+}
+
+// NewRepository создает новый репозиторий вебхуков.
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Create сохраняет вебхук, принадлежащий вызывающему.
+func (r *Repository) Create(ctx context.Context, url, secret string) (Record, error) {
+	principal, err := auth.Require(ctx)
+	if err != nil {
+		return Record{}, err
+	}
+	query := `
+insert into webhooks (tenant_id, owner_id, url, secret, created_at)
+values ($1, $2, $3, $4, $5)
+returning ` + recordColumns
+
+	return scanRecord(r.db.QueryRowContext(ctx, query, principal.TenantID, principal.Subject, url, secret, time.Now().UTC()))
+}
+
+// List возвращает вебхуки вызывающего, начиная с новых.
+func (r *Repository) List(ctx context.Context) ([]Record, error) {
+	principal, err := auth.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.db.QueryContext(ctx, `
+select `+recordColumns+`
+from webhooks
+where tenant_id = $1 and owner_id = $2
+order by created_at desc, id desc`, principal.TenantID, principal.Subject)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var items []Record
+	for rows.Next() {
+		rec, err := scanRecord(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, rec)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// Delete удаляет вебхук вызывающего вместе с журналом его доставок.
+func (r *Repository) Delete(ctx context.Context, id string) error {
+	principal, err := auth.Require(ctx)
+	if err != nil {
+		return err
+	}
+	res, err := r.db.ExecContext(ctx,
+		`delete from webhooks where id = $1 and tenant_id = $2 and owner_id = $3`,
+		id, principal.TenantID, principal.Subject)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Deliveries возвращает доставки на вебхук вызывающего, начиная с новых.
+// Если beforeID больше нуля, возвращаются доставки строго до него.
+func (r *Repository) Deliveries(ctx context.Context, webhookID string, limit int, beforeID int64) ([]Delivery, error) {
+	principal, err := auth.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var exists bool
+	err = r.db.QueryRowContext(ctx,
+		`select exists(select 1 from webhooks where id = $1 and tenant_id = $2 and owner_id = $3)`,
+		webhookID, principal.TenantID, principal.Subject).Scan(&exists)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	query := `
+select d.id, d.webhook_id, d.outbox_id, o.event_type, d.status, d.attempts, d.next_attempt_at,
+       d.last_attempt_at, d.last_status_code, d.last_error, d.created_at
+from webhook_deliveries d
+join outbox o on o.id = d.outbox_id
+where d.webhook_id = $1`
+	params := []any{webhookID}
+	if beforeID > 0 {
+		params = append(params, beforeID)
+		query += ` and d.id < $2`
+	}
+	params = append(params, limit)
+	query += `
+order by d.id desc
+limit $` + strconv.Itoa(len(params))
+
+	rows, err := r.db.QueryContext(ctx, query, params...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var items []Delivery
+	for rows.Next() {
+		var d Delivery
+		if err := rows.Scan(&d.ID, &d.WebhookID, &d.EventID, &d.EventType, &d.Status, &d.Attempts, &d.NextAttemptAt,
+			&d.LastAttemptAt, &d.LastStatusCode, &d.LastError, &d.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// FanOut раскладывает до limit неразосланных событий outbox по вебхукам
+// арендатора и возвращает число обработанных событий. Событие получают
+// вебхуки, созданные не позже него, владельцы которых видят задачу. Доступ
+// к окончательно удалённой задаче определяется её владельцем и ролями на
+// момент события и текущими ролями на её проект.
+// Параллельные диспетчеры обрабатывают разные события.
+func (r *Repository) FanOut(ctx context.Context, limit int) (int, error) {
+	now := time.Now().UTC()
+	query := `
+with batch as (
+    select id from outbox
+    where dispatched_at is null
+    order by id
+    limit $1
+    for update skip locked
+), fanned as (
+    insert into webhook_deliveries (webhook_id, outbox_id, status, next_attempt_at, created_at)
+    select w.id, o.id, 'pending', $2, $2
+    from batch
+    join outbox o on o.id = batch.id
+    join webhooks w on w.tenant_id = o.tenant_id and w.created_at <= o.created_at
+    left join todos t on t.id = o.todo_id
+    where case when t.id is not null then ` + access.TodoVisible("t", "w.tenant_id", "w.owner_id") + `
+        else w.owner_id = o.owner_id or w.owner_id = any(o.grantees) or exists (
+            select 1 from projects p
+            where p.id = o.project_id and ` + access.ProjectVisible("p", "w.tenant_id", "w.owner_id") + `)
+        end
+    on conflict (webhook_id, outbox_id) do nothing
+)
+update outbox set dispatched_at = $2
+where id in (select id from batch)`
+
+	res, err := r.db.ExecContext(ctx, query, limit, now)
+	if err != nil {
+		return 0, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(affected), nil
+}
+
+// Claim захватывает до limit доставок, время попытки которых наступило.
+// Захваченная доставка откладывается на lease, чтобы её не взял другой
+// диспетчер; если попытка не завершится, доставка вернётся после lease.
+func (r *Repository) Claim(ctx context.Context, limit int, lease time.Duration) ([]Task, error) {
+	now := time.Now().UTC()
+	query := `
+with due as (
+    select id from webhook_deliveries
+    where status = 'pending' and next_attempt_at <= $1
+    order by next_attempt_at, id
+    limit $2
+    for update skip locked
+)
+update webhook_deliveries d
+set next_attempt_at = $3
+from due, webhooks w, outbox o
+where d.id = due.id and w.id = d.webhook_id and o.id = d.outbox_id
+returning d.id, d.outbox_id, o.event_type, d.attempts, w.url, w.secret, o.payload`
+
+	rows, err := r.db.QueryContext(ctx, query, now, limit, now.Add(lease))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var tasks []Task
+	for rows.Next() {
+		var t Task
+		if err := rows.Scan(&t.DeliveryID, &t.EventID, &t.EventType, &t.Attempts, &t.URL, &t.Secret, &t.Payload); err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// Finish записывает результат попытки доставки.
+func (r *Repository) Finish(ctx context.Context, deliveryID int64, outcome Outcome) error {
+	next := outcome.NextAttemptAt
+	if outcome.Status != StatusPending {
+		next = outcome.At
+	}
+	_, err := r.db.ExecContext(ctx, `
+update webhook_deliveries
+set status = $2, attempts = attempts + 1, last_attempt_at = $3, last_status_code = $4,
+    last_error = $5, next_attempt_at = $6
+where id = $1`,
+		deliveryID, outcome.Status, outcome.At, outcome.StatusCode, outcome.Error, next)
+	return err
+}